@@ -13,6 +13,7 @@ import (
 
 	env "github.com/aau-network-security/haaukins-agent/internal/environment"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+	"github.com/aau-network-security/haaukins-agent/internal/simulation"
 	"github.com/aau-network-security/haaukins-agent/internal/worker"
 	pb "github.com/aau-network-security/haaukins-agent/pkg/proto"
 	"github.com/rs/zerolog/log"
@@ -30,6 +31,15 @@ type Agent struct {
 	workerPool worker.WorkerPool
 	newLabs    chan pb.Lab
 	EnvPool    *env.EnvPool `json:"envpool,omitempty"`
+	// Only set when running in simulation mode
+	Simulator *simulation.Simulator
+	// Exercise status changes waiting to be sent to the daemon
+	exerciseEvents chan *pb.ExerciseEvent
+	// Labs closed by the agent itself waiting to be sent to the daemon
+	closedLabs chan *pb.ClosedLab
+	// Cancelled by Close to stop the background loops of the agent
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 const DEFAULT_SIGN = "dev-sign-key"
@@ -73,6 +83,10 @@ func NewConfigFromFile(path string) (*Config, error) {
 		c.MaxWorkers = 5
 	}
 
+	if c.MaxSnapshotsPerLab == 0 {
+		c.MaxSnapshotsPerLab = 3
+	}
+
 	// In case paths has not been set, use working directory
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -87,6 +101,10 @@ func NewConfigFromFile(path string) (*Config, error) {
 		c.FileTransferRoot = filepath.Join(pwd, "filetransfer")
 	}
 
+	if c.ImageGC.DockerRoot == "" {
+		c.ImageGC.DockerRoot = "/var/lib/docker"
+	}
+
 	if c.OvaDir == "" {
 		log.Debug().Msg("ova dir not provided in the configuration file")
 		c.OvaDir = filepath.Join(pwd, "vms")
@@ -95,6 +113,7 @@ func NewConfigFromFile(path string) (*Config, error) {
 	for _, repo := range c.DockerRepositories {
 		virtual.Registries[repo.ServerAddress] = repo
 	}
+	virtual.SetRegistryConfig(c.Registry)
 
 	return &c, nil
 }
@@ -120,9 +139,48 @@ func New(conf *Config) (*Agent, error) {
 	workerPool := worker.NewWorkerPool(conf.MaxWorkers)
 	workerPool.Run()
 
+	var sim *simulation.Simulator
+	if conf.Simulation.Enabled {
+		// Replacing docker, the hypervisor, iptables, guacamole and wireguard with fakes
+		sim, err = simulation.Enable(conf.Simulation)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := virtual.InitDocker(nil); err != nil {
+			return nil, err
+		}
+
+		// Selecting the hypervisor for frontends and exercise vms
+		if err := virtual.SetHypervisor(conf.Hypervisor); err != nil {
+			return nil, err
+		}
+		if conf.Hypervisor == virtual.HypervisorLibvirt {
+			log.Warn().Msg("shared folders are not supported by libvirt, vm frontends will not have a file transfer folder")
+		}
+	}
+
 	vlib := virtual.NewLibrary(conf.OvaDir)
 
-	envPool, err := state.ResumeState(vlib, workerPool, conf.StatePath)
+	ctx, cancel := context.WithCancel(context.Background())
+	// Creating agent struct
+	a := &Agent{
+		config:     conf,
+		workerPool: workerPool,
+		vlib:       vlib,
+		auth:       NewAuthenticator(conf.SignKey, conf.AuthKey),
+		newLabs:    make(chan pb.Lab, 1000),
+		State:      &state.State{},
+		Simulator:  sim,
+
+		exerciseEvents: make(chan *pb.ExerciseEvent, 1000),
+		closedLabs:     make(chan *pb.ClosedLab, 1000),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+
+	// Exercise status changes of resumed labs are sent to the daemon the same way as for new labs
+	envPool, err := state.ResumeState(vlib, workerPool, conf.StatePath, a.queueExerciseEvent)
 	if err != nil {
 		log.Error().Err(err).Msg("error resuming state")
 		envPool = &env.EnvPool{
@@ -130,6 +188,7 @@ func New(conf *Config) (*Agent, error) {
 			Envs:         make(map[string]*env.Environment),
 			StartingEnvs: make(map[string]bool),
 			ClosingEnvs:  make(map[string]bool),
+			Schedules:    make(map[string]*env.Schedule),
 		}
 	}
 	if envPool == nil {
@@ -138,21 +197,36 @@ func New(conf *Config) (*Agent, error) {
 			Envs:         make(map[string]*env.Environment),
 			StartingEnvs: make(map[string]bool),
 			ClosingEnvs:  make(map[string]bool),
+			Schedules:    make(map[string]*env.Schedule),
 		}
 	}
-	// Creating agent struct
-	a := &Agent{
-		config:     conf,
-		workerPool: workerPool,
-		vlib:       vlib,
-		auth:       NewAuthenticator(conf.SignKey, conf.AuthKey),
-		newLabs:    make(chan pb.Lab, 1000),
-		EnvPool:    envPool,
-		State:      &state.State{},
+	a.EnvPool = envPool
+
+	// Closing labs that has passed their time to live
+	go a.runLabReaper()
+	// Suspending and resuming labs based on activity
+	go a.runIdleMonitor()
+	// Starting, freezing and tearing down scheduled environments
+	go a.runScheduler()
+	// Keeping warm lab pools of advanced environments at their target size
+	go a.runLabPoolMonitor()
+	// Keeping the amount of unassigned labs in beginner environments within limits
+	go a.runAutoscaler()
+	// Restarting or resetting exercises which fail their health checks
+	go a.runHealthChecks()
+	// Removing docker images no longer used by any environment
+	if conf.ImageGC.Interval > 0 {
+		go a.runImageGC()
 	}
+
 	return a, nil
 }
 
+// Stops the background loops started by New. Environments and labs are left running, so they can be resumed from the state
+func (a *Agent) Close() {
+	a.cancel()
+}
+
 func (d *Agent) NewGRPCServer(opts ...grpc.ServerOption) *grpc.Server {
 
 	streamInterceptor := func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
@@ -0,0 +1,140 @@
+package agent
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/rs/zerolog/log"
+	"github.com/shirou/gopsutil/mem"
+)
+
+const autoscaleInterval = 30 * time.Second
+
+// Periodically keeps the amount of unassigned labs in beginner environments between the configured minimum and maximum.
+// Autoscaling is also triggered whenever a lab gets assigned to a team.
+func (a *Agent) runAutoscaler() {
+	ticker := time.NewTicker(autoscaleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.EnvPool.M.RLock()
+			envs := make([]*environment.Environment, 0, len(a.EnvPool.Envs))
+			for _, env := range a.EnvPool.Envs {
+				envs = append(envs, env)
+			}
+			a.EnvPool.M.RUnlock()
+
+			for _, env := range envs {
+				a.autoscaleEnv(env)
+			}
+		}
+	}
+}
+
+// Creates labs if the amount of unassigned labs is below the minimum, and closes unassigned labs if it is above the maximum
+func (a *Agent) autoscaleEnv(env *environment.Environment) {
+	ec := env.EnvConfig
+	if ec.Type != lab.TypeBeginner || ec.Status != environment.StatusRunning {
+		return
+	}
+	if ec.MinReadyLabs == 0 && ec.MaxReadyLabs == 0 {
+		return
+	}
+	if a.EnvPool.IsEnvFrozen(ec.Tag) {
+		return
+	}
+
+	needed := env.ReserveReadyLabs(a.labAdmissionCapacity())
+	if needed > 0 {
+		log.Info().Str("eventTag", ec.Tag).Int("labs", needed).Msg("scaling up ready labs")
+	}
+	for i := 0; i < needed; i++ {
+		ec.WorkerPool.AddTask(func() {
+			defer env.ReadyLabBuilt()
+			// Kept from the daemon until handed out by AssignLab, so the lab can be scaled down again
+			a.buildBeginnerLab(env, false)
+		})
+	}
+
+	if ec.MaxReadyLabs == 0 {
+		return
+	}
+	unassigned := env.UnassignedLabs()
+	excess := len(unassigned) - ec.MaxReadyLabs
+	if excess <= 0 {
+		return
+	}
+	// Labs sent to the daemon may already have been handed out to a team, so only labs nobody knows about are closed
+	ctx := context.Background()
+	closed := 0
+	for i := len(unassigned) - 1; i >= 0 && closed < excess; i-- {
+		l := unassigned[i]
+		if !env.RemoveUnusedLab(l) {
+			continue
+		}
+		if err := a.closeLab(ctx, l); err != nil {
+			log.Error().Err(err).Str("labTag", l.Tag).Msg("error closing unassigned lab")
+			continue
+		}
+		closed++
+	}
+	if closed == 0 {
+		return
+	}
+	log.Info().Str("eventTag", ec.Tag).Int("labs", closed).Msg("scaled down ready labs")
+	if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+		log.Error().Err(err).Msg("error saving state")
+	}
+}
+
+// Returns the amount of labs that may be created without exceeding the admission limits of the host
+func (a *Agent) labAdmissionCapacity() int {
+	limits := a.config.LabAdmission
+	capacity := math.MaxInt32
+
+	if limits.MinFreeMemoryMB > 0 {
+		memory, err := mem.VirtualMemory()
+		if err != nil {
+			log.Error().Err(err).Msg("error reading available memory")
+			return 0
+		}
+		if memory.Available/1024/1024 < limits.MinFreeMemoryMB {
+			log.Warn().Uint64("memAvailable", memory.Available).Msg("available memory below admission limit, not creating labs")
+			return 0
+		}
+	}
+
+	if limits.MaxLabs > 0 {
+		current := a.EnvPool.GetFullLabCount() + a.workerPool.GetAmountOfQueuedTasks()
+		if current >= limits.MaxLabs {
+			return 0
+		}
+		capacity = int(limits.MaxLabs - current)
+	}
+	return capacity
+}
+
+// Marks the lab of a guacamole user as assigned on first login and rescales the environment
+func (a *Agent) assignLabForGuacUser(env *environment.Environment, username string) {
+	l, err := env.GetLabByGuacUsername(username)
+	if err != nil {
+		return
+	}
+	if !l.MarkAssigned() {
+		return
+	}
+	log.Debug().Str("labTag", l.Tag).Msg("lab assigned to team")
+	go func() {
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+		a.autoscaleEnv(env)
+	}()
+}
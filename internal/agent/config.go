@@ -1,6 +1,10 @@
 package agent
 
 import (
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+	"github.com/aau-network-security/haaukins-agent/internal/simulation"
 	dockerclient "github.com/fsouza/go-dockerclient"
 )
 
@@ -17,6 +21,31 @@ type Config struct {
 	StatePath          string                           `yaml:"state-path"`
 	VPNService         VPNconf                          `yaml:"vpn-service"`
 	DockerRepositories []dockerclient.AuthConfiguration `yaml:"docker-repositories"`
+	Registry           virtual.RegistryConfig           `yaml:"registry"`
+	ImageGC            ImageGCConf                      `yaml:"image-gc"`
+	LabAdmission       LabAdmissionConf                 `yaml:"lab-admission"`
+	MaxSnapshotsPerLab int                              `yaml:"max-snapshots-per-lab"`
+	Hypervisor         string                           `yaml:"hypervisor"`
+	Simulation         simulation.Config                `yaml:"simulation"`
+}
+
+// Limits for when the agent may create labs on its own, ex. when autoscaling beginner environments
+type LabAdmissionConf struct {
+	MaxLabs         uint32 `yaml:"max-labs"`
+	MinFreeMemoryMB uint64 `yaml:"min-free-memory-mb"`
+}
+
+// Garbage collection of docker images which are no longer referenced by any environment
+type ImageGCConf struct {
+	// How often images are pruned, automatic pruning is disabled if zero
+	Interval time.Duration `yaml:"interval"`
+	// Unreferenced images are removed once they have not been used for this long
+	MaxAge time.Duration `yaml:"max-age"`
+	// Unreferenced images are removed, oldest first, while the disk usage of the docker root is above this percentage
+	DiskThreshold float64 `yaml:"disk-threshold"`
+	DockerRoot    string  `yaml:"docker-root"`
+	// Images which are never removed
+	Keep []string `yaml:"keep"`
 }
 
 type VPNconf struct {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aau-network-security/haaukins-agent/internal/environment"
 	env "github.com/aau-network-security/haaukins-agent/internal/environment"
@@ -42,6 +43,18 @@ func (a *Agent) CreateEnvironment(ctx context.Context, req *proto.CreatEnvReques
 		return nil, fmt.Errorf("environment with tag: \"%s\" already exists", req.EventTag)
 	}
 
+	if err := validateSchedule(req); err != nil {
+		return nil, err
+	}
+
+	if req.MaxReadyLabs != 0 && req.MaxReadyLabs < req.MinReadyLabs {
+		return nil, errors.New("maximum amount of ready labs must not be lower than the minimum")
+	}
+
+	if schedule, ok := a.EnvPool.GetSchedule(req.EventTag); ok && !schedule.Started {
+		return nil, fmt.Errorf("environment with tag: \"%s\" is already scheduled", req.EventTag)
+	}
+
 	// Create a new environment for event if it does not exists
 	// Setting up the env config
 	var envConf env.EnvConfig
@@ -49,6 +62,13 @@ func (a *Agent) CreateEnvironment(ctx context.Context, req *proto.CreatEnvReques
 	envConf.Type = lab.LabType(req.EnvType)
 	envConf.WorkerPool = a.workerPool
 	envConf.TeamSize = int(req.TeamSize)
+	envConf.LabTTL = time.Duration(req.LabTtlMinutes) * time.Minute
+	envConf.IdleTimeout = time.Duration(req.IdleTimeoutMinutes) * time.Minute
+	envConf.WarmLabs = int(req.WarmLabs)
+	envConf.MinReadyLabs = int(req.MinReadyLabs)
+	envConf.MaxReadyLabs = int(req.MaxReadyLabs)
+	envConf.SharedScreens = req.SharedScreens
+	envConf.LabConf.Hybrid = req.HybridLabs
 	log.Debug().Str("envtype", envConf.Type.String()).Msg("making environment with type")
 
 	// Unpack into exercise slice
@@ -64,21 +84,76 @@ func (a *Agent) CreateEnvironment(ctx context.Context, req *proto.CreatEnvReques
 	}
 	envConf.LabConf.ExerciseConfs = exerConfs
 
-	frontend := virtual.InstanceConfig{
-		Image:    req.Vm.Image,
-		MemoryMB: uint(req.Vm.MemoryMB),
-		CPU:      req.Vm.Cpu,
+	if req.ImagePolicy != nil {
+		envConf.LabConf.ImagePolicy = virtual.ImagePolicy{
+			RequireDigest:     req.ImagePolicy.RequireDigest,
+			AllowedRegistries: req.ImagePolicy.AllowedRegistries,
+		}
+	}
+	for _, eConf := range exerConfs {
+		if err := eConf.CheckImagePolicy(envConf.LabConf.ImagePolicy); err != nil {
+			log.Error().Err(err).Msg("exercise not allowed by image policy")
+			return nil, err
+		}
+		if err := eConf.CheckHealthChecks(); err != nil {
+			log.Error().Err(err).Msg("invalid exercise health check")
+			return nil, err
+		}
+		if err := eConf.CheckDependencies(); err != nil {
+			log.Error().Err(err).Msg("invalid exercise dependencies")
+			return nil, err
+		}
+		if err := eConf.CheckContainerOptions(); err != nil {
+			log.Error().Err(err).Msg("invalid exercise container options")
+			return nil, err
+		}
 	}
 
 	if req.TeamSize == 0 {
 		return nil, errors.New("cannot create env with 0 teamsize")
 	}
-	for i := 0; i < int(req.TeamSize); i++ {
-		envConf.LabConf.Frontends = append(envConf.LabConf.Frontends, frontend)
+	// Use the list of frontends if provided, otherwise every team member gets the same vm
+	if len(req.Frontends) > 0 {
+		names := make(map[string]bool)
+		for _, vm := range req.Frontends {
+			if vm.Name != "" {
+				if names[vm.Name] {
+					return nil, fmt.Errorf("duplicate frontend name: %s", vm.Name)
+				}
+				names[vm.Name] = true
+			}
+			frontend, err := vmConfToInstanceConf(vm)
+			if err != nil {
+				return nil, err
+			}
+			envConf.LabConf.Frontends = append(envConf.LabConf.Frontends, frontend)
+		}
+	} else {
+		if req.Vm == nil {
+			return nil, errors.New("either vm or frontends is required")
+		}
+		frontend, err := vmConfToInstanceConf(req.Vm)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < int(req.TeamSize); i++ {
+			envConf.LabConf.Frontends = append(envConf.LabConf.Frontends, frontend)
+		}
+	}
+
+	// Environment should be started by the scheduler at a later time. The request has been validated, so invalid requests are not scheduled
+	if req.StartAt > time.Now().Unix() {
+		if err := a.scheduleEnvironment(req); err != nil {
+			log.Error().Err(err).Msg("error scheduling environment")
+			return nil, err
+		}
+		return &proto.StatusResponse{Message: "environment scheduled"}, nil
 	}
 
 	// Set the vlib
 	envConf.LabConf.Vlib = a.vlib
+	// Exercise status changes are sent to the daemon with the monitoring stream
+	envConf.LabConf.OnExerciseStatusChange = a.queueExerciseEvent
 
 	// Get VPN address for environment if participant want to switch from browser to VPN
 	vpnIP, err := getVPNIP()
@@ -97,6 +172,9 @@ func (a *Agent) CreateEnvironment(ctx context.Context, req *proto.CreatEnvReques
 		Dir:      a.config.VPNService.WgConfDir,
 	}
 
+	// Pull the images in parallel while the environment is created, labs only wait for images still missing
+	a.prePullImages(envConf.Tag, envConf.Images())
+
 	// Create environment
 	env, err := envConf.NewEnv(ctx)
 	if err != nil {
@@ -105,69 +183,14 @@ func (a *Agent) CreateEnvironment(ctx context.Context, req *proto.CreatEnvReques
 		return &proto.StatusResponse{Message: "Error creating environment"}, err
 	}
 
-	m := &sync.RWMutex{}
 	// If it is a beginner event, labs will be created and be available beforehand
 	if envConf.Type == lab.TypeBeginner {
+		env.AddReadyLabsBuilding(int(req.InitialLabs))
 		for i := 0; i < int(req.InitialLabs); i++ {
 			// Adding lab creation task to taskqueue
 			envConf.WorkerPool.AddTask(func() {
-				ctx := context.Background()
-				log.Debug().Uint8("envStatus", uint8(envConf.Status)).Msg("environment status when starting worker")
-				// Make sure that environment is still running before creating lab
-				if envConf.Status == environment.StatusClosing || envConf.Status == environment.StatusClosed {
-					log.Info().Msg("environment closed before newlab task was taken from queue, canceling...")
-					return
-				}
-				// Creating containers and frontends
-				lab, err := envConf.LabConf.NewLab(ctx, false, lab.TypeBeginner, envConf.Tag)
-				if err != nil {
-					log.Error().Err(err).Str("eventTag", env.EnvConfig.Tag).Msg("error creating new lab")
-					return
-				}
-				// Starting the created containers and frontends
-				if err := lab.Start(ctx); err != nil {
-					log.Error().Err(err).Str("eventTag", env.EnvConfig.Tag).Msg("error starting new lab")
-					return
-				}
-
-				if err := env.CreateGuacConn(lab); err != nil {
-					log.Error().Err(err).Str("labTag", lab.Tag).Msg("error creating guac connection for lab")
-				}
-
-				log.Debug().Uint8("envStatus", uint8(envConf.Status)).Msg("environment status when ending worker")
-				// If lab was created while running CloseEnvironment, close the lab
-				if envConf.Status == environment.StatusClosing || envConf.Status == environment.StatusClosed {
-					log.Info().Msg("environment closed while newlab task was running from queue, closing lab...")
-					if err := lab.Close(); err != nil {
-						log.Error().Err(err).Msg("error closing lab")
-						return
-					}
-					return
-				}
-				// Sending lab info to daemon
-				// TODO Figure out what exact data should be returned to daemon
-				// TODO use new getChallenges function to get challenges for lab to return flag etc.
-
-				newLab := proto.Lab{
-					Tag:       lab.Tag,
-					EventTag:  envConf.Tag,
-					Exercises: lab.GetExercisesInfo(),
-					IsVPN:     false,
-					GuacCreds: &proto.GuacCreds{
-						Username: lab.GuacUsername,
-						Password: lab.GuacPassword,
-					},
-				}
-				//a.newLabs = append(a.newLabs, newLab)
-				a.newLabs <- newLab
-				// Adding lab to environment
-				m.Lock()
-				env.Labs[lab.Tag] = &lab
-				m.Unlock()
-				// Should not be removed as it runs in a worker
-				if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
-					log.Error().Err(err).Msg("error saving state")
-				}
+				defer env.ReadyLabBuilt()
+				a.buildBeginnerLab(env, true)
 			})
 		}
 	}
@@ -183,9 +206,94 @@ func (a *Agent) CreateEnvironment(ctx context.Context, req *proto.CreatEnvReques
 	}
 
 	a.EnvPool.AddEnv(env)
+	// Start building the warm lab pool for advanced environments
+	a.replenishLabPool(env)
+	// Top up the ready labs for beginner environments in case the minimum is above the initial labs
+	go a.autoscaleEnv(env)
+
+	if req.FreezeAt != 0 || req.TeardownAt != 0 {
+		a.EnvPool.AddSchedule(&environment.Schedule{
+			EventTag:   req.EventTag,
+			StartAt:    time.Now(),
+			FreezeAt:   unixToTime(req.FreezeAt),
+			TeardownAt: unixToTime(req.TeardownAt),
+			Started:    true,
+		})
+	} else {
+		// Nothing left for the scheduler to do if the environment was started from a schedule
+		a.EnvPool.RemoveSchedule(req.EventTag)
+	}
 	return &proto.StatusResponse{Message: "recieved createLabs request... starting labs"}, nil
 }
 
+// Creates and starts a beginner lab with all exercises of the environment. The lab is sent to the daemon if report is set,
+// otherwise it waits in the environment until handed out by AssignLab. Should be run inside a worker.
+func (a *Agent) buildBeginnerLab(env *environment.Environment, report bool) {
+	envConf := env.EnvConfig
+	ctx := context.Background()
+	log.Debug().Uint8("envStatus", uint8(envConf.Status)).Msg("environment status when starting worker")
+	// Make sure that environment is still running before creating lab
+	if envConf.Status == environment.StatusClosing || envConf.Status == environment.StatusClosed {
+		log.Info().Msg("environment closed before newlab task was taken from queue, canceling...")
+		return
+	}
+	// Creating containers and frontends
+	lab, err := envConf.LabConf.NewLab(ctx, false, lab.TypeBeginner, envConf.Tag)
+	if err != nil {
+		log.Error().Err(err).Str("eventTag", envConf.Tag).Msg("error creating new lab")
+		return
+	}
+	// Starting the created containers and frontends
+	if err := lab.Start(ctx); err != nil {
+		log.Error().Err(err).Str("eventTag", envConf.Tag).Msg("error starting new lab")
+		return
+	}
+
+	if err := env.CreateGuacConn(lab); err != nil {
+		log.Error().Err(err).Str("labTag", lab.Tag).Msg("error creating guac connection for lab")
+	}
+
+	log.Debug().Uint8("envStatus", uint8(envConf.Status)).Msg("environment status when ending worker")
+	// If lab was created while running CloseEnvironment, close the lab
+	if envConf.Status == environment.StatusClosing || envConf.Status == environment.StatusClosed {
+		log.Info().Msg("environment closed while newlab task was running from queue, closing lab...")
+		if err := lab.Close(); err != nil {
+			log.Error().Err(err).Msg("error closing lab")
+			return
+		}
+		return
+	}
+	// Sending lab info to daemon
+	// TODO Figure out what exact data should be returned to daemon
+	// TODO use new getChallenges function to get challenges for lab to return flag etc.
+
+	if report {
+		newLab := proto.Lab{
+			Tag:       lab.Tag,
+			EventTag:  envConf.Tag,
+			Exercises: lab.GetExercisesInfo(),
+			IsVPN:     false,
+			GuacCreds: &proto.GuacCreds{
+				Username: lab.GuacUsername,
+				Password: lab.GuacPassword,
+			},
+			MemberCreds: memberCreds(&lab),
+			IsHybrid:    lab.IsHybrid,
+		}
+		//a.newLabs = append(a.newLabs, newLab)
+		a.newLabs <- newLab
+		lab.Reported = true
+	}
+	// Adding lab to environment
+	env.M.Lock()
+	env.Labs[lab.Tag] = &lab
+	env.M.Unlock()
+	// Should not be removed as it runs in a worker
+	if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+		log.Error().Err(err).Msg("error saving state")
+	}
+}
+
 // Closes environment and attached containers/vms, and removes the environment from the event pool
 func (a *Agent) CloseEnvironment(ctx context.Context, req *proto.CloseEnvRequest) (*proto.StatusResponse, error) {
 	a.EnvPool.AddClosingEnv(req.EventTag)
@@ -196,6 +304,12 @@ func (a *Agent) CloseEnvironment(ctx context.Context, req *proto.CloseEnvRequest
 		}
 	}()
 
+	// Closing an environment which has not been started yet cancels the schedule
+	if schedule, ok := a.EnvPool.GetSchedule(req.EventTag); ok && !schedule.Started {
+		a.EnvPool.RemoveSchedule(req.EventTag)
+		return &proto.StatusResponse{Message: "OK"}, nil
+	}
+
 	env, err := a.EnvPool.GetEnv(req.EventTag)
 	if err != nil {
 		log.Error().Str("envTag", req.EventTag).Msg("error finding finding environment with tag")
@@ -221,6 +335,76 @@ func (a *Agent) CloseEnvironment(ctx context.Context, req *proto.CloseEnvRequest
 	env.EnvConfig.Status = environment.StatusClosed
 
 	a.EnvPool.RemoveEnv(envConf.Tag)
+	a.EnvPool.RemoveSchedule(envConf.Tag)
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+// Suspends all frontends, exercises, DNS and DHCP servers in every lab of an environment as well as the guacamole containers.
+// Used to free up host memory between days of a multi day event without losing the progress of teams.
+func (a *Agent) SuspendEnvironment(ctx context.Context, req *proto.SuspendEnvRequest) (*proto.StatusResponse, error) {
+	env, err := a.EnvPool.GetEnv(req.EventTag)
+	if err != nil {
+		log.Error().Str("envTag", req.EventTag).Msg("error finding finding environment with tag")
+		return nil, fmt.Errorf("error finding environment with tag: %s", req.EventTag)
+	}
+
+	env.M.Lock()
+	if env.EnvConfig.Status != environment.StatusRunning {
+		env.M.Unlock()
+		return nil, fmt.Errorf("cannot suspend environment that is not running: %s", req.EventTag)
+	}
+	env.EnvConfig.Status = environment.StatusUpdating
+	env.M.Unlock()
+
+	defer func() {
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	if err := env.Suspend(context.Background()); err != nil {
+		// The status is left as it was, since only some of the labs may have been suspended
+		env.M.Lock()
+		env.EnvConfig.Status = environment.StatusRunning
+		env.M.Unlock()
+		log.Error().Err(err).Str("envTag", req.EventTag).Msg("error suspending environment")
+		return nil, fmt.Errorf("error suspending environment: %v", err)
+	}
+
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+// Resumes a suspended environment, restoring all labs in parallel
+func (a *Agent) ResumeEnvironment(ctx context.Context, req *proto.ResumeEnvRequest) (*proto.StatusResponse, error) {
+	env, err := a.EnvPool.GetEnv(req.EventTag)
+	if err != nil {
+		log.Error().Str("envTag", req.EventTag).Msg("error finding finding environment with tag")
+		return nil, fmt.Errorf("error finding environment with tag: %s", req.EventTag)
+	}
+
+	env.M.Lock()
+	if env.EnvConfig.Status != environment.StatusSuspended {
+		env.M.Unlock()
+		return nil, fmt.Errorf("cannot resume environment that is not suspended: %s", req.EventTag)
+	}
+	env.EnvConfig.Status = environment.StatusUpdating
+	env.M.Unlock()
+
+	defer func() {
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	if err := env.Resume(context.Background()); err != nil {
+		// The status is left as it was, since only some of the labs may have been resumed
+		env.M.Lock()
+		env.EnvConfig.Status = environment.StatusSuspended
+		env.M.Unlock()
+		log.Error().Err(err).Str("envTag", req.EventTag).Msg("error resuming environment")
+		return nil, fmt.Errorf("error resuming environment: %v", err)
+	}
+
 	return &proto.StatusResponse{Message: "OK"}, nil
 }
 
@@ -257,6 +441,24 @@ func (a *Agent) AddExercisesToEnv(ctx context.Context, req *proto.ExerciseReques
 		json.Unmarshal([]byte(ex), &estruct)
 		exerConfs = append(exerConfs, estruct)
 	}
+	for _, reqConf := range exerConfs {
+		if err := reqConf.CheckImagePolicy(env.EnvConfig.LabConf.ImagePolicy); err != nil {
+			log.Error().Err(err).Msg("exercise not allowed by image policy")
+			return nil, err
+		}
+		if err := reqConf.CheckHealthChecks(); err != nil {
+			log.Error().Err(err).Msg("invalid exercise health check")
+			return nil, err
+		}
+		if err := reqConf.CheckDependencies(); err != nil {
+			log.Error().Err(err).Msg("invalid exercise dependencies")
+			return nil, err
+		}
+		if err := reqConf.CheckContainerOptions(); err != nil {
+			log.Error().Err(err).Msg("invalid exercise container options")
+			return nil, err
+		}
+	}
 	for _, eConf := range env.EnvConfig.LabConf.ExerciseConfs {
 		for _, reqConf := range exerConfs {
 			if eConf.Tag == reqConf.Tag {
@@ -266,6 +468,14 @@ func (a *Agent) AddExercisesToEnv(ctx context.Context, req *proto.ExerciseReques
 	}
 	env.EnvConfig.LabConf.ExerciseConfs = append(env.EnvConfig.LabConf.ExerciseConfs, exerConfs...)
 
+	var images []string
+	for _, eConf := range exerConfs {
+		images = append(images, eConf.Images()...)
+	}
+	if len(images) > 0 {
+		a.prePullImages(req.EnvTag, images)
+	}
+
 	// TODO: Is it a problem to use the workerpool here? Maybe just use a go routine for each lab.
 	var wg sync.WaitGroup
 	ctx = context.Background()
@@ -287,9 +497,10 @@ func (a *Agent) AddExercisesToEnv(ctx context.Context, req *proto.ExerciseReques
 // Lists currently running, starting and closing environments.
 func (a *Agent) ListEnvironments(ctx context.Context, req *proto.Empty) (*proto.ListEnvResponse, error) {
 	return &proto.ListEnvResponse{
-		EventTags:         a.EnvPool.GetEnvList(),
-		StartingEventTags: a.EnvPool.GetStartingEnvs(),
-		ClosingEventTags:  a.EnvPool.GetClosingEnvs(),
+		EventTags:          a.EnvPool.GetEnvList(),
+		StartingEventTags:  a.EnvPool.GetStartingEnvs(),
+		ClosingEventTags:   a.EnvPool.GetClosingEnvs(),
+		SuspendedEventTags: a.EnvPool.GetSuspendedEnvs(),
 	}, nil
 }
 
@@ -301,3 +512,39 @@ func getVPNIP() (string, error) {
 	}
 	return ip, nil
 }
+
+func vmConfToInstanceConf(vm *proto.VmConfig) (virtual.InstanceConfig, error) {
+	conf := virtual.InstanceConfig{
+		Image:    vm.Image,
+		MemoryMB: uint(vm.MemoryMB),
+		CPU:      vm.Cpu,
+		Name:     vm.Name,
+		Type:     vm.Type,
+		Protocol: vm.Protocol,
+		Port:     uint(vm.Port),
+	}
+
+	switch conf.Type {
+	case "":
+		conf.Type = virtual.FrontendTypeVbox
+	case virtual.FrontendTypeVbox, virtual.FrontendTypeDocker:
+	default:
+		return conf, fmt.Errorf("unknown frontend type: %s", conf.Type)
+	}
+
+	switch conf.Protocol {
+	case "":
+		conf.Protocol = virtual.ProtocolRDP
+		// Vm frontends use the default protocol of the hypervisor
+		if conf.Type == virtual.FrontendTypeVbox {
+			conf.Protocol = virtual.DefaultHypervisor().DisplayProtocols()[0]
+		}
+	case virtual.ProtocolRDP, virtual.ProtocolVNC:
+		if conf.Type == virtual.FrontendTypeVbox && !virtual.SupportsDisplayProtocol(conf.Protocol) {
+			return conf, fmt.Errorf("%s is not supported by the %s hypervisor", conf.Protocol, virtual.DefaultHypervisor().Name())
+		}
+	default:
+		return conf, fmt.Errorf("unknown frontend protocol: %s", conf.Protocol)
+	}
+	return conf, nil
+}
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/rs/zerolog/log"
+)
+
+const labReaperInterval = 1 * time.Minute
+
+// Periodically closes labs which has passed their expiry time.
+// Expired labs are closed the same way as through CloseLab, including removal of VPN peers and iptables rules.
+func (a *Agent) runLabReaper() {
+	ticker := time.NewTicker(labReaperInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.reapExpiredLabs()
+		}
+	}
+}
+
+func (a *Agent) reapExpiredLabs() {
+	type expiredLab struct {
+		lab       *lab.Lab
+		eventTag  string
+		expiresAt time.Time
+	}
+	var expired []expiredLab
+	a.EnvPool.M.RLock()
+	for tag, env := range a.EnvPool.Envs {
+		// Closing environments will take care of their own labs
+		if env.EnvConfig.Status == environment.StatusClosing || env.EnvConfig.Status == environment.StatusClosed {
+			continue
+		}
+		env.M.RLock()
+		for _, l := range env.Labs {
+			l.M.RLock()
+			if l.IsExpired() {
+				expired = append(expired, expiredLab{lab: l, eventTag: tag, expiresAt: l.ExpiresAt})
+			}
+			l.M.RUnlock()
+		}
+		env.M.RUnlock()
+	}
+	a.EnvPool.M.RUnlock()
+
+	if len(expired) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	for _, e := range expired {
+		log.Info().Str("labTag", e.lab.Tag).Time("expiresAt", e.expiresAt).Msg("lab has expired, closing lab")
+		if err := a.closeLab(ctx, e.lab); err != nil {
+			log.Error().Err(err).Str("labTag", e.lab.Tag).Msg("error closing expired lab")
+			continue
+		}
+		// The daemon must stop handing out the lab
+		a.queueClosedLab(e.eventTag, e.lab.Tag, "expired")
+	}
+
+	if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+		log.Error().Err(err).Msg("error saving state")
+	}
+}
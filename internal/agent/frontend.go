@@ -0,0 +1,197 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/aau-network-security/haaukins-agent/pkg/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// Clones a new frontend into a running lab and creates its guacamole connection.
+// Returns the updated lab, including the credentials of a new team member if the team size is larger than one.
+func (a *Agent) AddFrontendToLab(ctx context.Context, req *proto.AddFrontendRequest) (*proto.GetLabResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+	if req.Vm == nil {
+		return nil, errors.New("vm config is required")
+	}
+	conf, err := vmConfToInstanceConf(req.Vm)
+	if err != nil {
+		return nil, err
+	}
+
+	envTag := strings.Split(l.Tag, "-")[0]
+	env, err := a.EnvPool.GetEnv(envTag)
+	if err != nil {
+		log.Error().Str("envTag", envTag).Msg("error finding finding environment with tag")
+		return nil, fmt.Errorf("error finding environment with tag: %s", envTag)
+	}
+	if a.EnvPool.IsEnvFrozen(envTag) {
+		return nil, errors.New("cannot add frontend to lab in frozen environment")
+	}
+
+	l.M.Lock()
+	defer func() {
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	ctx = context.Background()
+	port, err := l.AddFrontend(ctx, conf)
+	if err != nil {
+		l.M.Unlock()
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error adding frontend to lab")
+		return nil, err
+	}
+
+	if err := env.AddFrontendGuacConn(l, port); err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error creating guac connection for frontend")
+		// Do not leave a frontend behind which cannot be reached
+		if frontend, err := l.RemoveFrontend(port); err == nil {
+			if err := env.RemoveFrontendGuacConn(frontend); err != nil {
+				log.Error().Err(err).Str("labTag", l.Tag).Msg("error removing guac connection for frontend")
+			}
+		}
+		l.M.Unlock()
+		return nil, err
+	}
+	l.M.Unlock()
+
+	return a.GetLab(ctx, &proto.GetLabRequest{LabTag: l.Tag})
+}
+
+// Closes a frontend in a running lab and removes its guacamole connection and team member user
+func (a *Agent) RemoveFrontendFromLab(ctx context.Context, req *proto.RemoveFrontendRequest) (*proto.StatusResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+
+	envTag := strings.Split(l.Tag, "-")[0]
+	env, err := a.EnvPool.GetEnv(envTag)
+	if err != nil {
+		log.Error().Str("envTag", envTag).Msg("error finding finding environment with tag")
+		return nil, fmt.Errorf("error finding environment with tag: %s", envTag)
+	}
+
+	l.M.Lock()
+	defer func() {
+		l.M.Unlock()
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	var port uint
+	switch {
+	case req.FrontendName != "":
+		port, err = l.GetFrontendPortByName(req.FrontendName)
+	case req.MemberUsername != "":
+		port, err = l.GetFrontendPortByMember(req.MemberUsername)
+	default:
+		return nil, errors.New("either frontend name or member username is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	frontend, err := l.RemoveFrontend(port)
+	if err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error removing frontend from lab")
+		return nil, err
+	}
+
+	if err := env.RemoveFrontendGuacConn(frontend); err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error removing guac connection for frontend")
+		return nil, err
+	}
+
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+// Changes memory and cpu of a frontend in a lab. Virtualbox frontends are restarted to apply the new size.
+// If the lab only has a single frontend it does not have to be named in the request.
+func (a *Agent) ResizeVmInLab(ctx context.Context, req *proto.ResizeVmRequest) (*proto.StatusResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+	if req.MemoryMB == 0 && req.Cpu <= 0 {
+		return nil, errors.New("either memory or cpu is required")
+	}
+
+	l.M.Lock()
+	defer func() {
+		l.M.Unlock()
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	port, err := selectFrontend(l, req.FrontendName, req.MemberUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.Background()
+	if err := l.ResizeFrontend(ctx, port, uint(req.MemoryMB), req.Cpu); err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error resizing frontend")
+		return nil, err
+	}
+
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+// Changes memory and cpu of the running containers of an exercise in a lab
+func (a *Agent) ResizeExerciseInLab(ctx context.Context, req *proto.ResizeExerciseRequest) (*proto.StatusResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+	if req.MemoryMB == 0 && req.Cpu <= 0 {
+		return nil, errors.New("either memory or cpu is required")
+	}
+
+	l.M.Lock()
+	defer func() {
+		l.M.Unlock()
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	if err := l.ResizeExercise(req.Exercise, uint(req.MemoryMB), req.Cpu); err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Str("exerciseTag", req.Exercise).Msg("error resizing exercise")
+		return nil, err
+	}
+
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+// Returns the port of the frontend targeted by its name or by the team member owning it.
+// Labs with a single frontend do not require the frontend to be named
+func selectFrontend(l *lab.Lab, frontendName, memberUsername string) (uint, error) {
+	switch {
+	case frontendName != "":
+		return l.GetFrontendPortByName(frontendName)
+	case memberUsername != "":
+		return l.GetFrontendPortByMember(memberUsername)
+	case len(l.Frontends) == 1:
+		for port := range l.Frontends {
+			return port, nil
+		}
+	}
+	return 0, errors.New("either frontend name or member username is required")
+}
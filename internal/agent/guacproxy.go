@@ -1,7 +1,9 @@
 package agent
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -54,6 +56,19 @@ func (a *Agent) proxy(c *gin.Context) {
 		return
 	}
 
+	// Resume the lab of the user logging in in case it has been suspended due to inactivity,
+	// and mark it as assigned to a team
+	if c.Request.Method == http.MethodPost && strings.HasSuffix(c.Request.URL.Path, "/api/tokens") {
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err == nil {
+			c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+			if form, err := url.ParseQuery(string(body)); err == nil && form.Get("username") != "" {
+				a.resumeLabForGuacUser(env, form.Get("username"))
+				a.assignLabForGuacUser(env, form.Get("username"))
+			}
+		}
+	}
+
 	log.Debug().Uint("guacPort", env.Guac.Port).Msg("guacport for environment")
 	baseGuacHost := fmt.Sprintf("http://127.0.0.1:%d", env.Guac.Port)
 	guacUrl, err := url.Parse(baseGuacHost + "/guacamole")
@@ -79,7 +94,7 @@ func (a *Agent) proxy(c *gin.Context) {
 func (a *Agent) guaclogin(c *gin.Context) {
 	envTag := strings.Split(c.Request.Host, ".")[0]
 
-	_, ok := a.EnvPool.Envs[envTag]
+	env, ok := a.EnvPool.Envs[envTag]
 	if !ok {
 		c.JSON(http.StatusBadRequest, ProxyResponse{Message: "no guacamole for that event"})
 		return
@@ -91,6 +106,8 @@ func (a *Agent) guaclogin(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, ProxyResponse{Message: "Bad request"})
 		return
 	}
+	a.resumeLabForGuacUser(env, username)
+	a.assignLabForGuacUser(env, username)
 	c.HTML(http.StatusOK, "guaclogin.html", gin.H{
 		"content": "This is the guaclogin page",
 	})
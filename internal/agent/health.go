@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/rs/zerolog/log"
+)
+
+// How often health checks are considered, each health check is run at its own interval
+const healthCheckInterval = 5 * time.Second
+
+// Maximum time spent probing the exercises of a single lab in each round
+const healthCheckLabTimeout = 30 * time.Second
+
+// Maximum number of labs checked at the same time
+const maxConcurrentHealthChecks = 10
+
+// Periodically runs the health checks of exercises in running environments.
+// Unhealthy exercise containers are restarted, or their exercise is reset, depending on the health check
+func (a *Agent) runHealthChecks() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.checkExerciseHealth()
+		}
+	}
+}
+
+func (a *Agent) checkExerciseHealth() {
+	var labs []*lab.Lab
+	a.EnvPool.M.RLock()
+	for _, env := range a.EnvPool.Envs {
+		env.M.RLock()
+		if env.EnvConfig.Status == environment.StatusRunning {
+			for _, l := range env.Labs {
+				labs = append(labs, l)
+			}
+		}
+		env.M.RUnlock()
+	}
+	a.EnvPool.M.RUnlock()
+
+	var (
+		wg       sync.WaitGroup
+		changedM sync.Mutex
+		changed  bool
+	)
+	sem := make(chan struct{}, maxConcurrentHealthChecks)
+	for _, l := range labs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(l *lab.Lab) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			probeCtx, cancel := context.WithTimeout(a.ctx, healthCheckLabTimeout)
+			defer cancel()
+			if l.CheckExerciseHealth(a.ctx, probeCtx) {
+				changedM.Lock()
+				changed = true
+				changedM.Unlock()
+			}
+		}(l)
+	}
+	wg.Wait()
+
+	if changed {
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}
+}
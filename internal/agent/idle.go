@@ -0,0 +1,164 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/rs/zerolog/log"
+)
+
+const idleCheckInterval = 1 * time.Minute
+
+// Periodically checks labs in environments with an idle timeout for activity.
+// Activity is determined from active guacamole connections for browser labs and wireguard handshakes for VPN labs.
+// Labs that have been idle for longer than the idle timeout are suspended, and resumed if activity is seen again.
+func (a *Agent) runIdleMonitor() {
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.checkIdleLabs()
+		}
+	}
+}
+
+func (a *Agent) checkIdleLabs() {
+	var envs []*environment.Environment
+	a.EnvPool.M.RLock()
+	for _, env := range a.EnvPool.Envs {
+		if env.EnvConfig.IdleTimeout > 0 && env.EnvConfig.Status == environment.StatusRunning {
+			envs = append(envs, env)
+		}
+	}
+	a.EnvPool.M.RUnlock()
+
+	ctx := context.Background()
+	for _, env := range envs {
+		a.checkIdleLabsInEnv(ctx, env)
+	}
+}
+
+func (a *Agent) checkIdleLabsInEnv(ctx context.Context, env *environment.Environment) {
+	// If we are unable to get activity from guacamole or wireguard, labs are not suspended
+	// as we cannot know if they are in use
+	activeUsers, guacErr := env.Guac.GetActiveUsers()
+	if guacErr != nil {
+		log.Warn().Err(guacErr).Str("envTag", env.EnvConfig.Tag).Msg("error getting active guacamole connections")
+	}
+	handshakes, wgErr := env.GetVpnHandshakes(ctx)
+	if wgErr != nil {
+		log.Warn().Err(wgErr).Str("envTag", env.EnvConfig.Tag).Msg("error getting wireguard handshakes")
+	}
+
+	env.M.RLock()
+	labs := make([]*lab.Lab, 0, len(env.Labs))
+	for _, l := range env.Labs {
+		labs = append(labs, l)
+	}
+	env.M.RUnlock()
+
+	for _, l := range labs {
+		active := false
+		known := true
+		if l.HasFrontends() {
+			if guacErr != nil {
+				known = false
+			}
+			l.M.RLock()
+			for _, u := range l.GuacUsernames() {
+				if activeUsers[u] {
+					active = true
+				}
+			}
+			l.M.RUnlock()
+		}
+		// Hybrid labs are active if the team uses either guacamole or the vpn
+		if l.IsVPN {
+			keys, err := env.GetLabPeerKeys(ctx, l.Tag)
+			if wgErr != nil || err != nil {
+				known = false
+			}
+			for _, key := range keys {
+				if age, ok := handshakes[key]; ok && age < env.EnvConfig.IdleTimeout {
+					active = true
+				}
+			}
+		}
+
+		l.M.Lock()
+		if active {
+			l.LastActivity = time.Now()
+		}
+		suspended := l.IdleSuspended
+		idle := time.Since(l.LastActivity) > env.EnvConfig.IdleTimeout
+		l.M.Unlock()
+
+		switch {
+		case active && suspended:
+			go a.resumeIdleLab(l)
+		case known && !active && !suspended && idle:
+			go a.suspendIdleLab(l)
+		}
+	}
+}
+
+func (a *Agent) suspendIdleLab(l *lab.Lab) {
+	l.M.Lock()
+	defer func() {
+		l.M.Unlock()
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+	if l.IdleSuspended {
+		return
+	}
+
+	log.Info().Str("labTag", l.Tag).Time("lastActivity", l.LastActivity).Msg("lab is idle, suspending lab")
+	if err := l.Suspend(context.Background()); err != nil {
+		// Not marked as suspended, so suspending is tried again on the next check
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error suspending idle lab")
+		return
+	}
+	l.IdleSuspended = true
+}
+
+// Resumes a lab which has been suspended due to inactivity. Does nothing if the lab is not suspended
+func (a *Agent) resumeIdleLab(l *lab.Lab) {
+	l.M.Lock()
+	defer l.M.Unlock()
+	if !l.IdleSuspended {
+		return
+	}
+
+	log.Info().Str("labTag", l.Tag).Msg("activity in idle lab, resuming lab")
+	if err := l.Resume(context.Background()); err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error resuming idle lab")
+		return
+	}
+	l.IdleSuspended = false
+	l.LastActivity = time.Now()
+
+	// Lock is held by the deferred unlock, so state is saved in the background
+	go func() {
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+}
+
+// Resumes the lab belonging to a guacamole user logging in through the guacamole proxy.
+// The lab is resumed in the background, so the login is not held up while its machines are started
+func (a *Agent) resumeLabForGuacUser(env *environment.Environment, username string) {
+	l, err := env.GetLabByGuacUsername(username)
+	if err != nil {
+		return
+	}
+	go a.resumeIdleLab(l)
+}
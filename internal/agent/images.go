@@ -0,0 +1,158 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+	"github.com/aau-network-security/haaukins-agent/pkg/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// Pulls the images used by an environment and any additional images in parallel, streaming the progress of each image.
+// Returns when all images are available locally, or with an error listing the images which could not be pulled.
+func (a *Agent) PrePullImages(req *proto.PrePullImagesRequest, stream proto.Agent_PrePullImagesServer) error {
+	images := req.Images
+	if req.EnvTag != "" {
+		env, err := a.EnvPool.GetEnv(req.EnvTag)
+		if err != nil {
+			log.Error().Str("envTag", req.EnvTag).Msg("error finding environment with tag")
+			return fmt.Errorf("error finding environment with tag: %s", req.EnvTag)
+		}
+		env.M.RLock()
+		images = append(env.EnvConfig.Images(), images...)
+		env.M.RUnlock()
+	}
+	if len(images) == 0 {
+		return errors.New("no images to pull")
+	}
+
+	var sendErr error
+	err := virtual.PullImages(stream.Context(), images, func(p virtual.PullProgress) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(pullProgressToProto(p))
+	})
+	if sendErr != nil {
+		log.Error().Err(sendErr).Msg("error sending image pull progress")
+		return sendErr
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error pulling images")
+		return err
+	}
+	return nil
+}
+
+// Starts pulling images in the background, so labs being created only have to wait for the images which are not pulled yet
+func (a *Agent) prePullImages(envTag string, images []string) {
+	go func() {
+		log.Info().Str("envTag", envTag).Int("images", len(images)).Msg("pre-pulling images")
+		err := virtual.PullImages(context.Background(), images, func(p virtual.PullProgress) {
+			switch p.Status {
+			case virtual.PullStatusPulled:
+				log.Info().Str("envTag", envTag).Str("image", p.Image).Msg("pulled image")
+			case virtual.PullStatusFailed:
+				log.Error().Err(p.Err).Str("envTag", envTag).Str("image", p.Image).Msg("error pulling image")
+			}
+		})
+		if err != nil {
+			log.Error().Err(err).Str("envTag", envTag).Msg("error pre-pulling images")
+			return
+		}
+		log.Info().Str("envTag", envTag).Msg("all images pre-pulled")
+	}()
+}
+
+func pullProgressToProto(p virtual.PullProgress) *proto.ImagePullProgress {
+	progress := &proto.ImagePullProgress{
+		Image:   p.Image,
+		Status:  p.Status,
+		Current: p.Current,
+		Total:   p.Total,
+	}
+	if p.Err != nil {
+		progress.Error = p.Err.Error()
+	}
+	return progress
+}
+
+// Removes docker images which are not referenced by any environment or used by any container, based on the
+// configured max age and disk threshold. With dry run the images which would be removed are only reported
+func (a *Agent) PruneImages(ctx context.Context, req *proto.PruneImagesRequest) (*proto.PruneImagesResponse, error) {
+	maxAge := a.config.ImageGC.MaxAge
+	if req.MaxAgeMinutes > 0 {
+		maxAge = time.Duration(req.MaxAgeMinutes) * time.Minute
+	}
+
+	pruned, err := a.pruneImages(maxAge, req.DryRun)
+	if err != nil {
+		log.Error().Err(err).Msg("error pruning images")
+		return nil, err
+	}
+
+	resp := &proto.PruneImagesResponse{}
+	for _, p := range pruned {
+		resp.Images = append(resp.Images, &proto.PrunedImage{
+			Id:     p.Id,
+			Tags:   p.Tags,
+			Size:   p.Size,
+			Reason: p.Reason,
+		})
+		resp.ReclaimedBytes += p.Size
+	}
+	return resp, nil
+}
+
+// Periodically removes images which are no longer referenced
+func (a *Agent) runImageGC() {
+	ticker := time.NewTicker(a.config.ImageGC.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.pruneImages(a.config.ImageGC.MaxAge, false); err != nil {
+				log.Error().Err(err).Msg("error pruning images")
+			}
+		}
+	}
+}
+
+func (a *Agent) pruneImages(maxAge time.Duration, dryRun bool) ([]virtual.PrunedImage, error) {
+	pruned, err := virtual.PruneImages(virtual.PruneOptions{
+		Referenced:    a.referencedImages(),
+		MaxAge:        maxAge,
+		DiskPath:      a.config.ImageGC.DockerRoot,
+		DiskThreshold: a.config.ImageGC.DiskThreshold,
+		DryRun:        dryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range pruned {
+		log.Info().Str("id", p.Id).Strs("tags", p.Tags).Int64("size", p.Size).Str("reason", p.Reason).Bool("dryRun", dryRun).Msg("pruned image")
+	}
+	return pruned, nil
+}
+
+// Returns the images used by the agent itself, the images configured to be kept, and the images of all environments.
+// Exercises added directly to labs are not included, but their images are kept since they are used by containers
+func (a *Agent) referencedImages() []string {
+	images := append(environment.BuiltinImages(), a.config.ImageGC.Keep...)
+
+	a.EnvPool.M.RLock()
+	defer a.EnvPool.M.RUnlock()
+	for _, env := range a.EnvPool.Envs {
+		env.M.RLock()
+		images = append(images, env.EnvConfig.Images()...)
+		env.M.RUnlock()
+	}
+	return images
+}
@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/aau-network-security/haaukins-agent/internal/environment"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
@@ -24,13 +24,31 @@ func (a *Agent) CreateLabForEnv(ctx context.Context, req *proto.CreateLabRequest
 		return nil, errors.New("environment for event does not exist")
 	}
 
-	if env.EnvConfig.Type == lab.TypeBeginner && req.IsVPN {
+	if env.EnvConfig.Type == lab.TypeBeginner && req.IsVPN && !env.EnvConfig.LabConf.Hybrid {
 		return nil, errors.New("cannot create vpn lab for beginner environment")
 	}
 
+	if env.EnvConfig.Status == environment.StatusSuspended {
+		return nil, errors.New("cannot create lab for suspended environment")
+	}
+
+	if a.EnvPool.IsEnvFrozen(req.EventTag) {
+		return nil, errors.New("cannot create lab for frozen environment")
+	}
+
 	ec := env.EnvConfig
 
-	m := &sync.RWMutex{}
+	// A ttl in the request overrides the default ttl of the environment
+	ttl := ec.LabTTL
+	if req.TtlMinutes > 0 {
+		ttl = time.Duration(req.TtlMinutes) * time.Minute
+	}
+
+	// Browser labs for advanced environments can be claimed instantly from the warm lab pool
+	if ec.Type == lab.TypeAdvanced && !req.IsVPN && a.claimWarmLab(env, ttl) != nil {
+		return &proto.StatusResponse{Message: "OK"}, nil
+	}
+
 	ec.WorkerPool.AddTask(func() {
 		ctx := context.Background()
 		log.Debug().Uint8("envStatus", uint8(ec.Status)).Msg("environment status when starting worker")
@@ -52,32 +70,18 @@ func (a *Agent) CreateLabForEnv(ctx context.Context, req *proto.CreateLabRequest
 			return
 		}
 
-		if !l.IsVPN {
+		l.SetTTL(ttl)
+
+		if l.HasFrontends() {
 			if err := env.CreateGuacConn(l); err != nil {
 				log.Error().Err(err).Str("labTag", l.Tag).Msg("error creating guac connection for lab")
 			}
-		} else {
+		}
+		if l.IsVPN {
 			env.M.Lock()
-
-			labSubnet := fmt.Sprintf("%s/24", l.DhcpServer.Subnet)
-
-			vpnConfig := lab.VpnConfig{
-				Host:            a.config.Host,
-				VpnAddress:      env.EnvConfig.VPNAddress,
-				VPNEndpointPort: env.EnvConfig.VPNEndpointPort,
-				IpAddresses:     env.IpAddrs,
-				LabSubnet:       labSubnet,
-				TeamSize:        env.EnvConfig.TeamSize,
-			}
-
-			labConfigsFiles, vpnIPs, _ := l.CreateVPNConfigs(env.Wg, req.EventTag, vpnConfig)
-
-			env.IpT.CreateRejectRule(labSubnet)
-			env.IpT.CreateStateRule(labSubnet)
-			env.IpT.CreateAcceptRule(labSubnet, strings.Join(vpnIPs, ","))
-			env.IpRules[l.Tag] = environment.IpRules{
-				Labsubnet: labSubnet,
-				VpnIps:    strings.Join(vpnIPs, ","),
+			labConfigsFiles, err := a.createLabVPN(env, &l)
+			if err != nil {
+				log.Error().Err(err).Str("labTag", l.Tag).Msg("error creating vpn configs for lab")
 			}
 			l.VpnConfs = labConfigsFiles
 			env.M.Unlock()
@@ -103,14 +107,18 @@ func (a *Agent) CreateLabForEnv(ctx context.Context, req *proto.CreateLabRequest
 				Username: l.GuacUsername,
 				Password: l.GuacPassword,
 			},
-			VpnConfs: l.VpnConfs,
+			VpnConfs:    l.VpnConfs,
+			ExpiresAt:   l.ExpiresAtUnix(),
+			MemberCreds: memberCreds(&l),
+			IsHybrid:    l.IsHybrid,
 		}
 
 		//a.newLabs = append(a.newLabs, newLab)
 		a.newLabs <- newLab
-		m.Lock()
+		l.Reported = true
+		env.M.Lock()
 		env.Labs[l.Tag] = &l
-		m.Unlock()
+		env.M.Unlock()
 		// Should not be removed as it runs inside a worker
 		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
 			log.Error().Err(err).Msg("error saving state")
@@ -121,12 +129,17 @@ func (a *Agent) CreateLabForEnv(ctx context.Context, req *proto.CreateLabRequest
 }
 
 func (a *Agent) GetLab(ctx context.Context, req *proto.GetLabRequest) (*proto.GetLabResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 	eventTag := strings.Split(l.Tag, "-")[0]
+	l.M.Lock()
+	// The daemon knows about the lab from now on, so it must not be scaled down
+	l.Reported = true
+	expiresAt := l.ExpiresAtUnix()
+	l.M.Unlock()
 
 	labToReturn := &proto.Lab{
 		Tag:       l.Tag,
@@ -137,15 +150,19 @@ func (a *Agent) GetLab(ctx context.Context, req *proto.GetLabRequest) (*proto.Ge
 			Username: l.GuacUsername,
 			Password: l.GuacPassword,
 		},
-		VpnConfs: l.VpnConfs,
+		VpnConfs:    l.VpnConfs,
+		ExpiresAt:   expiresAt,
+		TeamId:      l.TeamID,
+		MemberCreds: memberCreds(l),
+		IsHybrid:    l.IsHybrid,
 	}
 	return &proto.GetLabResponse{Lab: labToReturn}, nil
 }
 
 func (a *Agent) CreateVpnConfForLab(ctx context.Context, req *proto.CreateVpnConfRequest) (*proto.CreateVpnConfResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 
@@ -172,6 +189,64 @@ func (a *Agent) CreateVpnConfForLab(ctx context.Context, req *proto.CreateVpnCon
 		return nil, errors.New("VPN configs already generated for this lab")
 	}
 
+	labConfigsFiles, err := a.createLabVPN(env, l)
+	if err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error creating vpn configs for lab")
+		return nil, err
+	}
+
+	return &proto.CreateVpnConfResponse{Configs: labConfigsFiles}, nil
+}
+
+// Enables VPN access for a running browser lab, so the team can use both guacamole and VPN.
+// Only hybrid labs are created on a network which the VPN can route to.
+func (a *Agent) EnableVPNForLab(ctx context.Context, req *proto.CreateVpnConfRequest) (*proto.CreateVpnConfResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+
+	if !l.IsHybrid {
+		return nil, errors.New("vpn can only be enabled for labs in environments with hybrid labs")
+	}
+
+	envTag := strings.Split(l.Tag, "-")[0]
+	env, err := a.EnvPool.GetEnv(envTag)
+	if err != nil {
+		log.Error().Str("envTag", envTag).Msg("error finding finding environment with tag")
+		return nil, fmt.Errorf("error finding environment with tag: %s", envTag)
+	}
+
+	// The environment lock is always taken before the lab lock, as AssignLab does
+	env.M.Lock()
+	l.M.Lock()
+	defer func() {
+		l.M.Unlock()
+		env.M.Unlock()
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	if _, ok := env.IpRules[l.Tag]; ok {
+		return nil, errors.New("vpn is already enabled for this lab")
+	}
+
+	labConfigsFiles, err := a.createLabVPN(env, l)
+	if err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error enabling vpn for lab")
+		return nil, err
+	}
+	l.IsVPN = true
+	l.VpnConfs = labConfigsFiles
+
+	return &proto.CreateVpnConfResponse{Configs: labConfigsFiles}, nil
+}
+
+// Creates wireguard configs for the members of a team and the iptables rules allowing them to reach the lab subnet.
+// The caller must hold the environment lock.
+func (a *Agent) createLabVPN(env *environment.Environment, l *lab.Lab) ([]string, error) {
 	labSubnet := fmt.Sprintf("%s/24", l.DhcpServer.Subnet)
 
 	vpnConfig := lab.VpnConfig{
@@ -183,7 +258,10 @@ func (a *Agent) CreateVpnConfForLab(ctx context.Context, req *proto.CreateVpnCon
 		TeamSize:        env.EnvConfig.TeamSize,
 	}
 
-	labConfigsFiles, vpnIPs, err := l.CreateVPNConfigs(env.Wg, envTag, vpnConfig)
+	labConfigsFiles, vpnIPs, err := l.CreateVPNConfigs(env.Wg, env.EnvConfig.Tag, vpnConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	env.IpT.CreateRejectRule(labSubnet)
 	env.IpT.CreateStateRule(labSubnet)
@@ -192,14 +270,13 @@ func (a *Agent) CreateVpnConfForLab(ctx context.Context, req *proto.CreateVpnCon
 		Labsubnet: labSubnet,
 		VpnIps:    strings.Join(vpnIPs, ","),
 	}
-
-	return &proto.CreateVpnConfResponse{Configs: labConfigsFiles}, nil
+	return labConfigsFiles, nil
 }
 
 func (a *Agent) GetHostsInLab(ctx context.Context, req *proto.GetHostsRequest) (*proto.GetHostsResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 
@@ -210,12 +287,16 @@ func (a *Agent) GetHostsInLab(ctx context.Context, req *proto.GetHostsRequest) (
 
 // Reset lab resets DHCP, DNS, exercises and frontends in lab
 func (a *Agent) ResetLab(ctx context.Context, req *proto.ResetLabRequest) (*proto.StatusResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 
+	if a.EnvPool.IsEnvFrozen(req.EventTag) {
+		return nil, errors.New("cannot reset lab in frozen environment")
+	}
+
 	l.M.Lock()
 	defer func() {
 		l.M.Unlock()
@@ -225,13 +306,13 @@ func (a *Agent) ResetLab(ctx context.Context, req *proto.ResetLabRequest) (*prot
 	}()
 	// Reset the DHCP
 	if err := l.RefreshDHCP(ctx); err != nil {
-		log.Error().Err(err).Str("labTag", req.LabTag).Msg("error resetting DHCP")
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error resetting DHCP")
 		return nil, err
 	}
 
 	// Reset the DNS
 	if err := l.RefreshDNS(ctx); err != nil {
-		log.Error().Err(err).Str("labTag", req.LabTag).Msg("error resetting DNS")
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error resetting DNS")
 		return nil, err
 	}
 
@@ -244,34 +325,43 @@ func (a *Agent) ResetLab(ctx context.Context, req *proto.ResetLabRequest) (*prot
 	}
 
 	// Stop then start all frontends
-	for _, conf := range l.Frontends {
-		switch conf.Vm.Info().State {
+	for port, conf := range l.Frontends {
+		vm := conf.Instance()
+		switch vm.Info().State {
 		case virtual.Running:
-			if err := conf.Vm.Stop(); err != nil {
+			if err := vm.Stop(); err != nil {
 				return nil, err
 			}
-			if err := conf.Vm.Start(ctx); err != nil {
+			if err := vm.Start(ctx); err != nil {
 				return nil, err
 			}
 		case virtual.Stopped:
-			if err := conf.Vm.Start(ctx); err != nil {
+			if err := vm.Start(ctx); err != nil {
 				return nil, err
 			}
 		case virtual.Suspended:
-			if err := conf.Vm.Start(ctx); err != nil {
+			if err := vm.Start(ctx); err != nil {
 				return nil, err
 			}
-			if err := conf.Vm.Stop(); err != nil {
+			if err := vm.Stop(); err != nil {
 				return nil, err
 			}
-			if err := conf.Vm.Start(ctx); err != nil {
+			if err := vm.Start(ctx); err != nil {
 				return nil, err
 			}
 		case virtual.Error:
-			if err := conf.Vm.Create(ctx); err != nil {
+			// Broken container frontends are replaced, so the new container is connected to the lab network
+			// and the old one is removed
+			if conf.Container != nil {
+				if err := l.ResetVm(ctx, port, strings.Split(l.Tag, "-")[0]); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := vm.Create(ctx); err != nil {
 				return nil, err
 			}
-			if err := conf.Vm.Start(ctx); err != nil {
+			if err := vm.Start(ctx); err != nil {
 				return nil, err
 			}
 		}
@@ -281,9 +371,9 @@ func (a *Agent) ResetLab(ctx context.Context, req *proto.ResetLabRequest) (*prot
 }
 
 func (a *Agent) ResetVmInLab(ctx context.Context, req *proto.VmRequest) (*proto.StatusResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 
@@ -294,6 +384,31 @@ func (a *Agent) ResetVmInLab(ctx context.Context, req *proto.VmRequest) (*proto.
 		return nil, fmt.Errorf("error finding environment with tag: %s", envTag)
 	}
 
+	// A frontend can be targeted directly by its name or by the team member owning it
+	if req.FrontendName != "" || req.MemberUsername != "" {
+		l.M.Lock()
+		defer func() {
+			l.M.Unlock()
+			if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+				log.Error().Err(err).Msg("error saving state")
+			}
+		}()
+		var port uint
+		if req.FrontendName != "" {
+			port, err = l.GetFrontendPortByName(req.FrontendName)
+		} else {
+			port, err = l.GetFrontendPortByMember(req.MemberUsername)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := l.ResetVm(ctx, port, envTag); err != nil {
+			log.Error().Err(err).Msg("error resetting vm")
+			return nil, err
+		}
+		return &proto.StatusResponse{Message: "OK"}, nil
+	}
+
 	// In case teamsize is larger than one
 	// A connectionIdentifier is required to determine which vm to reset
 	if env.EnvConfig.TeamSize > 1 {
@@ -343,16 +458,62 @@ func (a *Agent) ResetVmInLab(ctx context.Context, req *proto.VmRequest) (*proto.
 
 // Shuts down and removes all frontends and containers related to specific lab. Then removes it from the environment's lab map.
 func (a *Agent) CloseLab(ctx context.Context, req *proto.CloseLabRequest) (*proto.StatusResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+	defer func() {
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	if err := a.closeLab(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+// Extends the lifetime of a lab by the requested amount of minutes, and returns the new expiry time
+func (a *Agent) ExtendLab(ctx context.Context, req *proto.ExtendLabRequest) (*proto.ExtendLabResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
+
+	if req.Minutes == 0 {
+		return nil, errors.New("cannot extend lab by 0 minutes")
+	}
+
+	l.M.Lock()
 	defer func() {
+		l.M.Unlock()
 		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
 			log.Error().Err(err).Msg("error saving state")
 		}
 	}()
+
+	l.Extend(time.Duration(req.Minutes) * time.Minute)
+	log.Debug().Str("labTag", l.Tag).Time("expiresAt", l.ExpiresAt).Msg("extended lab")
+
+	return &proto.ExtendLabResponse{ExpiresAt: l.ExpiresAtUnix()}, nil
+}
+
+// Closes the lab in a worker, removes it from the environment and cleans up VPN peers and iptables rules if it is a VPN lab.
+// Used both by CloseLab and when labs expire.
+func (a *Agent) closeLab(ctx context.Context, l *lab.Lab) error {
+	envKey := strings.Split(l.Tag, "-")
+	env, err := a.EnvPool.GetEnv(envKey[0])
+	if err != nil {
+		log.Error().Str("envKey", envKey[0]).Msg("error finding environment for lab")
+		return err
+	}
+
+	log.Debug().Str("envKey", envKey[0]).Msg("env for lab")
+
 	a.workerPool.AddTask(func() {
 		l.M.Lock()
 		defer l.M.Unlock()
@@ -361,32 +522,31 @@ func (a *Agent) CloseLab(ctx context.Context, req *proto.CloseLabRequest) (*prot
 		}
 	})
 
-	envKey := strings.Split(req.LabTag, "-")
-	env, _ := a.EnvPool.GetEnv(envKey[0])
-
-	log.Debug().Str("envKey", envKey[0]).Msg("env for lab")
-
-	a.EnvPool.Envs[envKey[0]].M.Lock()
-	delete(a.EnvPool.Envs[envKey[0]].Labs, req.LabTag)
-	a.EnvPool.Envs[envKey[0]].M.Unlock()
+	env.M.Lock()
+	delete(env.Labs, l.Tag)
+	env.M.Unlock()
 
 	if l.IsVPN {
-		env.RemoveVpnLabPeers(ctx, req.LabTag)
+		env.RemoveVpnLabPeers(ctx, l.Tag)
 	}
 
-	return &proto.StatusResponse{Message: "OK"}, nil
+	return nil
 }
 
 // GRPc endpoint that adds exercises to an already running lab. It requires the lab tag, and an array of exercise tags.
 // It starts by creating the containers needed for the exercise, then it refreshes the DNS and starts the containers afterwards.
 // It utilizes a mutex lock to make sure that if anyone tries to run the same GRPc call twice without the first being finished, the second one will wait
 func (a *Agent) AddExercisesToLab(ctx context.Context, req *proto.ExerciseRequest) (*proto.StatusResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EnvTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 
+	if a.EnvPool.IsEnvFrozen(req.EnvTag) {
+		return nil, errors.New("cannot add exercises to lab in frozen environment")
+	}
+
 	if l.Type == lab.TypeBeginner {
 		return nil, errors.New("cannot add arbitrary exercise to lab of type beginner")
 	}
@@ -422,12 +582,16 @@ func (a *Agent) AddExercisesToLab(ctx context.Context, req *proto.ExerciseReques
 
 // Starts a suspended/stopped exercise in a specific lab
 func (a *Agent) StartExerciseInLab(ctx context.Context, req *proto.ExerciseRequest) (*proto.StatusResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EnvTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 
+	if a.EnvPool.IsEnvFrozen(req.EnvTag) {
+		return nil, errors.New("cannot start exercise in frozen environment")
+	}
+
 	defer func() {
 		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
 			log.Error().Err(err).Msg("error saving state")
@@ -444,9 +608,9 @@ func (a *Agent) StartExerciseInLab(ctx context.Context, req *proto.ExerciseReque
 
 // Stops a running exercise for a specific lab
 func (a *Agent) StopExerciseInLab(ctx context.Context, req *proto.ExerciseRequest) (*proto.StatusResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EnvTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 
@@ -466,12 +630,16 @@ func (a *Agent) StopExerciseInLab(ctx context.Context, req *proto.ExerciseReques
 
 // Recreates and starts an exercise in a specific lab in case it should be having problems of any sorts.
 func (a *Agent) ResetExerciseInLab(ctx context.Context, req *proto.ExerciseRequest) (*proto.StatusResponse, error) {
-	l, err := a.EnvPool.GetLabByTag(req.LabTag)
+	l, err := a.getLab(req.LabTag, req.EnvTag, req.TeamId)
 	if err != nil {
-		log.Error().Str("labTag", req.LabTag).Err(err).Msg("error getting lab by tag")
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
 		return nil, err
 	}
 
+	if a.EnvPool.IsEnvFrozen(req.EnvTag) {
+		return nil, errors.New("cannot reset exercise in frozen environment")
+	}
+
 	defer func() {
 		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
 			log.Error().Err(err).Msg("error saving state")
@@ -486,3 +654,19 @@ func (a *Agent) ResetExerciseInLab(ctx context.Context, req *proto.ExerciseReque
 
 	return &proto.StatusResponse{Message: "OK"}, nil
 }
+
+// Returns the guacamole credentials of the individual team members in a lab, ordered by frontend
+func memberCreds(l *lab.Lab) []*proto.GuacCreds {
+	var creds []*proto.GuacCreds
+	for _, port := range l.RdpConnPorts() {
+		f := l.Frontends[port]
+		if f.GuacUsername == "" {
+			continue
+		}
+		creds = append(creds, &proto.GuacCreds{
+			Username: f.GuacUsername,
+			Password: f.GuacPassword,
+		})
+	}
+	return creds
+}
@@ -0,0 +1,169 @@
+package agent
+
+import (
+	"context"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/aau-network-security/haaukins-agent/pkg/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const labPoolInterval = 1 * time.Minute
+
+// Periodically tops up the warm lab pools of all advanced environments.
+// Replenishment is also triggered whenever a lab is claimed, this makes sure pools recover from failed builds and restarts.
+func (a *Agent) runLabPoolMonitor() {
+	ticker := time.NewTicker(labPoolInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.EnvPool.M.RLock()
+			envs := make([]*environment.Environment, 0, len(a.EnvPool.Envs))
+			for _, env := range a.EnvPool.Envs {
+				envs = append(envs, env)
+			}
+			a.EnvPool.M.RUnlock()
+
+			for _, env := range envs {
+				a.replenishLabPool(env)
+			}
+		}
+	}
+}
+
+// Queues builds of warm labs until the pool of the environment reaches its target size
+func (a *Agent) replenishLabPool(env *environment.Environment) {
+	ec := env.EnvConfig
+	if ec.Type != lab.TypeAdvanced || ec.WarmLabs == 0 || ec.Status != environment.StatusRunning {
+		return
+	}
+	if a.EnvPool.IsEnvFrozen(ec.Tag) {
+		return
+	}
+
+	needed := env.ReserveWarmLabs()
+	for i := 0; i < needed; i++ {
+		ec.WorkerPool.AddTask(func() {
+			defer env.WarmLabBuilt()
+			a.buildWarmLab(env)
+		})
+	}
+}
+
+// Creates and starts an exercise-less browser lab and adds it to the warm lab pool of the environment
+func (a *Agent) buildWarmLab(env *environment.Environment) {
+	ec := env.EnvConfig
+	ctx := context.Background()
+	if ec.Status == environment.StatusClosing || ec.Status == environment.StatusClosed {
+		log.Info().Msg("environment closed before warm lab task was taken from queue, canceling...")
+		return
+	}
+
+	l, err := ec.LabConf.NewLab(ctx, false, ec.Type, ec.Tag)
+	if err != nil {
+		log.Error().Err(err).Str("eventTag", ec.Tag).Msg("error creating warm lab")
+		return
+	}
+	if err := l.Start(ctx); err != nil {
+		log.Error().Err(err).Str("eventTag", ec.Tag).Msg("error starting warm lab")
+		if err := l.Close(); err != nil {
+			log.Error().Err(err).Msg("error closing warm lab")
+		}
+		return
+	}
+
+	if err := env.CreateGuacConn(l); err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error creating guac connection for warm lab")
+	}
+
+	if ec.Status == environment.StatusClosing || ec.Status == environment.StatusClosed {
+		log.Info().Msg("environment closed while warm lab task was running from queue, closing lab...")
+		if err := l.Close(); err != nil {
+			log.Error().Err(err).Msg("error closing lab")
+		}
+		return
+	}
+
+	env.AddWarmLab(&l)
+	log.Debug().Str("labTag", l.Tag).Str("eventTag", ec.Tag).Msg("added lab to warm lab pool")
+	// Should not be removed as it runs inside a worker
+	if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+		log.Error().Err(err).Msg("error saving state")
+	}
+}
+
+// Moves a lab from the warm lab pool into the environment and sends it to the daemon.
+// Returns nil if the pool is empty.
+func (a *Agent) claimWarmLab(env *environment.Environment, ttl time.Duration) *lab.Lab {
+	l := env.ClaimWarmLab()
+	if l == nil {
+		return nil
+	}
+
+	l.M.Lock()
+	l.SetTTL(ttl)
+	l.LastActivity = time.Now()
+	l.Reported = true
+	expiresAt := l.ExpiresAtUnix()
+	l.M.Unlock()
+
+	env.M.Lock()
+	env.Labs[l.Tag] = l
+	env.M.Unlock()
+
+	a.newLabs <- proto.Lab{
+		Tag:       l.Tag,
+		EventTag:  env.EnvConfig.Tag,
+		Exercises: l.GetExercisesInfo(),
+		IsVPN:     false,
+		GuacCreds: &proto.GuacCreds{
+			Username: l.GuacUsername,
+			Password: l.GuacPassword,
+		},
+		ExpiresAt:   expiresAt,
+		MemberCreds: memberCreds(l),
+		IsHybrid:    l.IsHybrid,
+	}
+
+	if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+		log.Error().Err(err).Msg("error saving state")
+	}
+	log.Info().Str("labTag", l.Tag).Str("eventTag", env.EnvConfig.Tag).Msg("claimed lab from warm lab pool")
+
+	go a.replenishLabPool(env)
+	return l
+}
+
+// Returns the status of the warm lab pools and beginner ready labs for the monitoring stream
+func (a *Agent) getLabPools() []*proto.LabPool {
+	a.EnvPool.M.RLock()
+	defer a.EnvPool.M.RUnlock()
+
+	var pools []*proto.LabPool
+	for tag, env := range a.EnvPool.Envs {
+		var ready, building, target int
+		switch {
+		case env.EnvConfig.Type == lab.TypeAdvanced && env.EnvConfig.WarmLabs > 0:
+			ready, building = env.WarmPoolStatus()
+			target = env.EnvConfig.WarmLabs
+		case env.EnvConfig.Type == lab.TypeBeginner && env.EnvConfig.MinReadyLabs > 0:
+			ready, building = env.ReadyPoolStatus()
+			target = env.EnvConfig.MinReadyLabs
+		default:
+			continue
+		}
+		pools = append(pools, &proto.LabPool{
+			EventTag: tag,
+			Ready:    uint32(ready),
+			Building: uint32(building),
+			Target:   uint32(target),
+		})
+	}
+	return pools
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/exercise"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
 	"github.com/aau-network-security/haaukins-agent/pkg/proto"
 	"github.com/rs/zerolog/log"
@@ -67,6 +68,7 @@ func (a *Agent) MonitorStream(stream proto.Agent_MonitorStreamServer) error {
 		resp := &proto.MonitorResponse{
 			Hb:          "alive",
 			QueuedTasks: a.workerPool.GetAmountOfQueuedTasks(),
+			LabPools:    a.getLabPools(),
 			Resources: &proto.Resources{
 				Cpu:            cpuPerc[0],
 				MemPercentUsed: memory.UsedPercent,
@@ -91,9 +93,52 @@ func (a *Agent) MonitorStream(stream proto.Agent_MonitorStreamServer) error {
 				break L
 			}
 		}
+	E:
+		for {
+			select {
+			case e := <-a.exerciseEvents:
+				resp.ExerciseEvents = append(resp.ExerciseEvents, e)
+			default:
+				break E
+			}
+		}
+	C:
+		for {
+			select {
+			case l := <-a.closedLabs:
+				resp.ClosedLabs = append(resp.ClosedLabs, l)
+			default:
+				break C
+			}
+		}
 
 		if err := stream.Send(resp); err != nil {
 			log.Error().Err(err).Msg("error sending monitoring response")
 		}
 	}
 }
+
+// Queues an exercise status change to be sent to the daemon with the next monitoring response
+func (a *Agent) queueExerciseEvent(e exercise.StatusEvent) {
+	event := &proto.ExerciseEvent{
+		LabTag:      e.LabTag,
+		ExerciseTag: e.Tag,
+		Status:      e.Status.String(),
+		Reason:      e.Reason,
+		Timestamp:   e.Time.Unix(),
+	}
+	select {
+	case a.exerciseEvents <- event:
+	default:
+		log.Warn().Str("labTag", e.LabTag).Str("exTag", e.Tag).Msg("exercise event queue is full, dropping event")
+	}
+}
+
+// Queues a lab closed by the agent to be sent to the daemon with the next monitoring response
+func (a *Agent) queueClosedLab(eventTag, labTag, reason string) {
+	select {
+	case a.closedLabs <- &proto.ClosedLab{Tag: labTag, EventTag: eventTag, Reason: reason}:
+	default:
+		log.Warn().Str("labTag", labTag).Msg("closed lab queue is full, dropping event")
+	}
+}
@@ -0,0 +1,151 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/exercise"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+	"github.com/aau-network-security/haaukins-agent/pkg/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// Lists the OVAs in the library with their size, cached checksum and whether they are used by an environment
+func (a *Agent) ListOvas(ctx context.Context, req *proto.Empty) (*proto.ListOvasResponse, error) {
+	images, err := a.vlib.ListImages()
+	if err != nil {
+		log.Error().Err(err).Msg("error listing ovas")
+		return nil, err
+	}
+
+	var ovas []*proto.Ova
+	for _, img := range images {
+		ova := ovaToProto(img)
+		ova.InUse = a.ovaInUse(img.Name)
+		ovas = append(ovas, ova)
+	}
+	return &proto.ListOvasResponse{Ovas: ovas}, nil
+}
+
+// Receives an OVA in chunks. The first chunk must contain the name of the OVA,
+// and if any chunk contains a checksum the upload is verified against it before being added to the library
+func (a *Agent) UploadOva(stream proto.Agent_UploadOvaServer) error {
+	var upload *virtual.OvaUpload
+	var checksum string
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("error receiving ova chunk")
+			if upload != nil {
+				upload.Abort()
+			}
+			return err
+		}
+
+		if upload == nil {
+			upload, err = a.vlib.NewUpload(chunk.Name)
+			if err != nil {
+				log.Error().Err(err).Str("name", chunk.Name).Msg("error starting ova upload")
+				return err
+			}
+			log.Info().Str("name", chunk.Name).Msg("receiving ova upload")
+		}
+		if chunk.Checksum != "" {
+			checksum = chunk.Checksum
+		}
+		if _, err := upload.Write(chunk.Data); err != nil {
+			log.Error().Err(err).Msg("error writing ova chunk")
+			upload.Abort()
+			return err
+		}
+	}
+	if upload == nil {
+		return errors.New("no ova received")
+	}
+
+	img, err := upload.Commit(checksum)
+	if err != nil {
+		log.Error().Err(err).Msg("error finishing ova upload")
+		return err
+	}
+	log.Info().Str("name", img.Name).Str("checksum", img.Checksum).Msg("ova uploaded")
+	return stream.SendAndClose(ovaToProto(img))
+}
+
+// Imports and snapshots the base vm of an OVA ahead of an event, so labs using it can be cloned right away
+func (a *Agent) PrepareOva(ctx context.Context, req *proto.OvaRequest) (*proto.Ova, error) {
+	if err := virtual.CheckOvaName(req.Name); err != nil {
+		return nil, err
+	}
+	if !a.vlib.IsAvailable(req.Name) {
+		return nil, fmt.Errorf("ova not found: %s", req.Name)
+	}
+
+	ctx = context.Background()
+	img, err := a.vlib.PrepareImage(ctx, req.Name)
+	if err != nil {
+		log.Error().Err(err).Str("name", req.Name).Msg("error preparing ova")
+		return nil, err
+	}
+	ova := ovaToProto(img)
+	ova.InUse = a.ovaInUse(img.Name)
+	return ova, nil
+}
+
+// Removes an OVA and its base vm, if it is not used by any environment
+func (a *Agent) RemoveOva(ctx context.Context, req *proto.OvaRequest) (*proto.StatusResponse, error) {
+	if err := virtual.CheckOvaName(req.Name); err != nil {
+		return nil, err
+	}
+	if !a.vlib.IsAvailable(req.Name) {
+		return nil, fmt.Errorf("ova not found: %s", req.Name)
+	}
+	if a.ovaInUse(req.Name) {
+		return nil, fmt.Errorf("ova is used by a running environment: %s", req.Name)
+	}
+
+	ctx = context.Background()
+	if err := a.vlib.RemoveImage(ctx, req.Name); err != nil {
+		log.Error().Err(err).Str("name", req.Name).Msg("error removing ova")
+		return nil, err
+	}
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+// Returns true if the OVA is used as frontend or exercise by any environment
+func (a *Agent) ovaInUse(name string) bool {
+	path := a.vlib.GetImagePath(name)
+
+	a.EnvPool.M.RLock()
+	defer a.EnvPool.M.RUnlock()
+	for _, env := range a.EnvPool.Envs {
+		for _, f := range env.EnvConfig.LabConf.Frontends {
+			if f.Type != virtual.FrontendTypeDocker && a.vlib.GetImagePath(f.Image) == path {
+				return true
+			}
+		}
+		for _, e := range env.EnvConfig.LabConf.ExerciseConfs {
+			for _, i := range e.Instance {
+				if strings.Contains(i.Image, exercise.OvaSuffix) && a.vlib.GetImagePath(i.Image) == path {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func ovaToProto(img virtual.OvaImage) *proto.Ova {
+	return &proto.Ova{
+		Name:     img.Name,
+		Size:     img.Size,
+		Checksum: img.Checksum,
+		Imported: img.Imported,
+	}
+}
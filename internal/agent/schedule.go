@@ -0,0 +1,158 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/aau-network-security/haaukins-agent/pkg/proto"
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/rs/zerolog/log"
+)
+
+const schedulerInterval = 30 * time.Second
+
+// Lists the scheduled start, freeze and teardown times for all environments with a schedule
+func (a *Agent) ListSchedules(ctx context.Context, req *proto.Empty) (*proto.ListSchedulesResponse, error) {
+	var schedules []*proto.Schedule
+	for _, s := range a.EnvPool.GetSchedules() {
+		schedules = append(schedules, &proto.Schedule{
+			EventTag:   s.EventTag,
+			StartAt:    timeToUnix(s.StartAt),
+			FreezeAt:   timeToUnix(s.FreezeAt),
+			TeardownAt: timeToUnix(s.TeardownAt),
+			Started:    s.Started,
+			Frozen:     s.Frozen,
+		})
+	}
+	return &proto.ListSchedulesResponse{Schedules: schedules}, nil
+}
+
+// Stores the create environment request in the environment pool so the scheduler can create the environment at its start time
+func (a *Agent) scheduleEnvironment(req *proto.CreatEnvRequest) error {
+	reqBytes, err := protobuf.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	a.EnvPool.AddSchedule(&environment.Schedule{
+		EventTag:   req.EventTag,
+		StartAt:    unixToTime(req.StartAt),
+		FreezeAt:   unixToTime(req.FreezeAt),
+		TeardownAt: unixToTime(req.TeardownAt),
+		Request:    reqBytes,
+	})
+
+	if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+		log.Error().Err(err).Msg("error saving state")
+	}
+	log.Info().Str("eventTag", req.EventTag).Time("startAt", unixToTime(req.StartAt)).Msg("scheduled environment")
+	return nil
+}
+
+// Periodically starts, freezes and tears down environments according to their schedule.
+// Schedules are part of the state, so they survive restarts of the agent.
+func (a *Agent) runScheduler() {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.runSchedules()
+		}
+	}
+}
+
+func (a *Agent) runSchedules() {
+	now := time.Now()
+	changed := false
+	for _, s := range a.EnvPool.GetSchedules() {
+		switch {
+		case s.StartDue(now):
+			a.EnvPool.UpdateSchedule(s.EventTag, func(s *environment.Schedule) {
+				s.Started = true
+			})
+			changed = true
+			go a.startScheduledEnv(s)
+		case s.TeardownDue(now):
+			log.Info().Str("eventTag", s.EventTag).Msg("tearing down scheduled environment")
+			if _, err := a.CloseEnvironment(context.Background(), &proto.CloseEnvRequest{EventTag: s.EventTag}); err != nil {
+				log.Error().Err(err).Str("eventTag", s.EventTag).Msg("error tearing down scheduled environment")
+			}
+			// The environment is either gone or cannot be closed, either way there is nothing left to do
+			a.EnvPool.RemoveSchedule(s.EventTag)
+			changed = true
+		case s.FreezeDue(now):
+			log.Info().Str("eventTag", s.EventTag).Msg("freezing scheduled environment")
+			a.EnvPool.UpdateSchedule(s.EventTag, func(s *environment.Schedule) {
+				s.Frozen = true
+			})
+			changed = true
+			env, err := a.EnvPool.GetEnv(s.EventTag)
+			if err != nil {
+				log.Error().Err(err).Str("eventTag", s.EventTag).Msg("error finding environment to freeze")
+				continue
+			}
+			if err := env.Freeze(context.Background()); err != nil {
+				log.Error().Err(err).Str("eventTag", s.EventTag).Msg("error freezing environment")
+			}
+		}
+	}
+
+	if changed {
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}
+}
+
+func (a *Agent) startScheduledEnv(s environment.Schedule) {
+	log.Info().Str("eventTag", s.EventTag).Msg("starting scheduled environment")
+	req := &proto.CreatEnvRequest{}
+	if err := protobuf.Unmarshal(s.Request, req); err != nil {
+		log.Error().Err(err).Str("eventTag", s.EventTag).Msg("error unmarshalling scheduled create environment request")
+		a.EnvPool.RemoveSchedule(s.EventTag)
+		return
+	}
+	// Start time has been reached, so the environment is created right away
+	req.StartAt = 0
+	if _, err := a.CreateEnvironment(context.Background(), req); err != nil {
+		log.Error().Err(err).Str("eventTag", s.EventTag).Msg("error creating scheduled environment")
+		a.EnvPool.RemoveSchedule(s.EventTag)
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}
+}
+
+// Makes sure that the scheduled times of a create environment request are in order
+func validateSchedule(req *proto.CreatEnvRequest) error {
+	if req.FreezeAt != 0 && req.FreezeAt < req.StartAt {
+		return errors.New("freeze time must be after start time")
+	}
+	if req.TeardownAt != 0 && req.TeardownAt < req.StartAt {
+		return errors.New("teardown time must be after start time")
+	}
+	if req.FreezeAt != 0 && req.TeardownAt != 0 && req.TeardownAt < req.FreezeAt {
+		return errors.New("teardown time must be after freeze time")
+	}
+	return nil
+}
+
+func unixToTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(t, 0)
+}
+
+func timeToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
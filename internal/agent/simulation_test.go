@@ -0,0 +1,178 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+	"github.com/aau-network-security/haaukins-agent/internal/simulation"
+	pb "github.com/aau-network-security/haaukins-agent/pkg/proto"
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// Creates an agent in simulation mode, backed by the simulated docker, hypervisor, iptables, guacamole and wireguard
+func newSimulatedAgent(t *testing.T) (*Agent, *simulation.Simulator) {
+	t.Helper()
+	dir := t.TempDir()
+	conf := &Config{
+		StatePath:        filepath.Join(dir, "state"),
+		FileTransferRoot: filepath.Join(dir, "filetransfer"),
+		OvaDir:           filepath.Join(dir, "vms"),
+		MaxWorkers:       2,
+		Simulation:       simulation.Config{Enabled: true},
+	}
+	if err := os.MkdirAll(conf.OvaDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	// Guacamole images are pulled from ghcr.io, which the simulated registry serves without credentials
+	virtual.Registries["ghcr.io"] = docker.AuthConfiguration{}
+	// The simulated hypervisor only needs the ova to exist
+	if err := os.WriteFile(filepath.Join(conf.OvaDir, "kali.ova"), []byte("kali"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := New(conf)
+	if err != nil {
+		t.Fatalf("error creating agent: %v", err)
+	}
+	t.Cleanup(a.Close)
+	if a.Simulator == nil {
+		t.Fatal("expected the agent to run in simulation mode")
+	}
+	return a, a.Simulator
+}
+
+// Waits for cond to become true, failing the test if it does not happen in time
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestSimulatedLabLifecycle(t *testing.T) {
+	a, sim := newSimulatedAgent(t)
+	ctx := context.Background()
+
+	_, err := a.CreateEnvironment(ctx, &pb.CreatEnvRequest{
+		EventTag: "sim",
+		EnvType:  int32(lab.TypeAdvanced),
+		Vm:       &pb.VmConfig{Image: "kali"},
+		TeamSize: 1,
+		ExerciseConfigs: []*pb.ExerciseConfig{
+			{
+				Tag:      "web",
+				Instance: []*pb.ExerciseInstanceConfig{{Image: "nginx:1.25", Memory: 128}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error creating environment: %v", err)
+	}
+	environment, err := a.EnvPool.GetEnv("sim")
+	if err != nil {
+		t.Fatalf("environment not in pool: %v", err)
+	}
+	defer environment.Close()
+
+	containers, vms := sim.Docker.ContainerCount(), sim.Hypervisor.VmCount()
+	guacUsers, guacConns := sim.Guacamole.Counts()
+
+	if _, err := a.CreateLabForEnv(ctx, &pb.CreateLabRequest{EventTag: "sim"}); err != nil {
+		t.Fatalf("error creating lab: %v", err)
+	}
+	// The lab is sent to the daemon right before it is added to the environment
+	select {
+	case <-a.newLabs:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for lab to be created")
+	}
+	var l *lab.Lab
+	waitFor(t, "lab to be added to the environment", func() bool {
+		environment.M.RLock()
+		defer environment.M.RUnlock()
+		for _, el := range environment.Labs {
+			l = el
+		}
+		return l != nil
+	})
+
+	// The exercise and service containers, and the frontend vm of the lab
+	labContainers := sim.Docker.ContainerCount() - containers
+	if labContainers < 2 {
+		t.Fatalf("expected exercise and service containers for the lab, got %d containers", labContainers)
+	}
+	if sim.Hypervisor.VmCount() == vms {
+		t.Fatal("expected a frontend vm for the lab")
+	}
+	users, conns := sim.Guacamole.Counts()
+	if users != guacUsers+1 || conns != guacConns+1 {
+		t.Fatalf("expected one guac user and connection for the lab, got %d users and %d connections", users-guacUsers, conns-guacConns)
+	}
+	if l.GuacUsername == "" {
+		t.Fatal("expected guac credentials for the lab")
+	}
+
+	var frontends []*virtual.Vm
+	for _, f := range l.Frontends {
+		if f.Vm == nil || sim.Hypervisor.State(ctx, f.Vm) != virtual.Running {
+			t.Fatal("expected the frontend vm of the lab to be running")
+		}
+		frontends = append(frontends, f.Vm)
+	}
+	ids := make(map[string]bool)
+	for _, ex := range l.Exercises {
+		for _, m := range ex.Machines {
+			ids[m.Info().Id] = true
+		}
+	}
+
+	if _, err := a.ResetLab(ctx, &pb.ResetLabRequest{LabTag: l.Tag}); err != nil {
+		t.Fatalf("error resetting lab: %v", err)
+	}
+	for _, ex := range l.Exercises {
+		if status, reason, _ := ex.GetStatus(); status.String() != "running" {
+			t.Fatalf("expected exercise %s to be running after reset, got %s: %s", ex.Tag, status, reason)
+		}
+		for _, m := range ex.Machines {
+			if ids[m.Info().Id] {
+				t.Fatalf("expected exercise %s to be recreated by reset", ex.Tag)
+			}
+			c, err := sim.Docker.InspectContainer(m.Info().Id)
+			if err != nil || !c.State.Running {
+				t.Fatalf("expected container of exercise %s to be running after reset", ex.Tag)
+			}
+		}
+	}
+	if n := sim.Docker.ContainerCount() - containers; n != labContainers {
+		t.Fatalf("expected reset to replace the exercise containers, lab has %d containers instead of %d", n, labContainers)
+	}
+
+	// Frontends are not touched by a reset, so guacamole keeps the same users and connections
+	if u, c := sim.Guacamole.Counts(); u != users || c != conns {
+		t.Fatalf("expected guac users and connections to be kept by reset, got %d users and %d connections", u, c)
+	}
+
+	if _, err := a.CloseLab(ctx, &pb.CloseLabRequest{LabTag: l.Tag}); err != nil {
+		t.Fatalf("error closing lab: %v", err)
+	}
+	waitFor(t, "lab containers and vms to be removed", func() bool {
+		for _, vm := range frontends {
+			if sim.Hypervisor.Exists(ctx, vm.Id) {
+				return false
+			}
+		}
+		return sim.Docker.ContainerCount() == containers
+	})
+	if _, err := a.EnvPool.GetLabByTag(l.Tag); err == nil {
+		t.Fatal("expected lab to be removed from the environment")
+	}
+}
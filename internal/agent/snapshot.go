@@ -0,0 +1,144 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/aau-network-security/haaukins-agent/pkg/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// Takes a snapshot of a frontend in a lab, so the team can revert to it later.
+// The amount of snapshots in a lab is limited by the agent configuration
+func (a *Agent) SnapshotVmInLab(ctx context.Context, req *proto.SnapshotRequest) (*proto.StatusResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+
+	l.M.Lock()
+	defer func() {
+		l.M.Unlock()
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	port, err := selectFrontend(l, req.FrontendName, req.MemberUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.Background()
+	snapshot, err := l.SnapshotFrontend(ctx, port, req.Name, a.config.MaxSnapshotsPerLab)
+	if err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error taking snapshot of frontend")
+		return nil, err
+	}
+
+	return &proto.StatusResponse{Message: snapshot.Name}, nil
+}
+
+// Lists the snapshots of all frontends in a lab, or of a single frontend if it is named in the request
+func (a *Agent) ListVmSnapshots(ctx context.Context, req *proto.SnapshotRequest) (*proto.ListVmSnapshotsResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+
+	l.M.RLock()
+	defer l.M.RUnlock()
+
+	var only *uint
+	if req.FrontendName != "" || req.MemberUsername != "" {
+		port, err := selectFrontend(l, req.FrontendName, req.MemberUsername)
+		if err != nil {
+			return nil, err
+		}
+		only = &port
+	}
+
+	var snapshots []*proto.VmSnapshot
+	for port, f := range l.Frontends {
+		if only != nil && *only != port {
+			continue
+		}
+		for _, s := range f.Snapshots {
+			snapshots = append(snapshots, &proto.VmSnapshot{
+				Name:           s.Name,
+				FrontendName:   f.Conf.Name,
+				MemberUsername: f.GuacUsername,
+				CreatedAt:      s.CreatedAt.Unix(),
+			})
+		}
+	}
+
+	return &proto.ListVmSnapshotsResponse{
+		Snapshots:    snapshots,
+		MaxSnapshots: uint32(a.config.MaxSnapshotsPerLab),
+	}, nil
+}
+
+// Reverts a frontend in a lab to one of its snapshots
+func (a *Agent) RevertVmInLab(ctx context.Context, req *proto.SnapshotRequest) (*proto.StatusResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errors.New("snapshot name is required")
+	}
+
+	l.M.Lock()
+	defer l.M.Unlock()
+
+	port, err := selectFrontend(l, req.FrontendName, req.MemberUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.Background()
+	if err := l.RevertFrontend(ctx, port, req.Name); err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error reverting frontend to snapshot")
+		return nil, err
+	}
+
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+// Deletes a snapshot of a frontend in a lab, freeing up room for a new one
+func (a *Agent) DeleteVmSnapshot(ctx context.Context, req *proto.SnapshotRequest) (*proto.StatusResponse, error) {
+	l, err := a.getLab(req.LabTag, req.EventTag, req.TeamId)
+	if err != nil {
+		log.Error().Str("labTag", req.LabTag).Str("teamId", req.TeamId).Err(err).Msg("error getting lab")
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errors.New("snapshot name is required")
+	}
+
+	l.M.Lock()
+	defer func() {
+		l.M.Unlock()
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+	}()
+
+	port, err := selectFrontend(l, req.FrontendName, req.MemberUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.Background()
+	if err := l.DeleteFrontendSnapshot(ctx, port, req.Name); err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error deleting snapshot of frontend")
+		return nil, err
+	}
+
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/state"
+	"github.com/aau-network-security/haaukins-agent/pkg/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// Binds a team to a lab in an environment. If no lab tag is given, a ready beginner lab or a lab from the warm lab pool is claimed.
+func (a *Agent) AssignLab(ctx context.Context, req *proto.AssignLabRequest) (*proto.GetLabResponse, error) {
+	env, err := a.EnvPool.GetEnv(req.EventTag)
+	if err != nil {
+		log.Error().Str("envTag", req.EventTag).Msg("error finding finding environment with tag")
+		return nil, fmt.Errorf("error finding environment with tag: %s", req.EventTag)
+	}
+
+	l, assigned, err := env.AssignLab(req.TeamId, req.LabTag)
+	if errors.Is(err, environment.ErrNoReadyLab) && env.EnvConfig.Type == lab.TypeAdvanced {
+		if warm := a.claimWarmLab(env, env.EnvConfig.LabTTL); warm != nil {
+			l, assigned, err = env.AssignLab(req.TeamId, warm.Tag)
+		}
+	}
+	if err != nil {
+		log.Error().Err(err).Str("envTag", req.EventTag).Str("teamId", req.TeamId).Msg("error assigning lab to team")
+		return nil, err
+	}
+
+	if assigned {
+		l.M.Lock()
+		// Labs which has not been given a ttl at creation starts their ttl when assigned
+		if l.ExpiresAt.IsZero() {
+			l.SetTTL(env.EnvConfig.LabTTL)
+		}
+		l.M.Unlock()
+		log.Info().Str("labTag", l.Tag).Str("teamId", req.TeamId).Msg("assigned lab to team")
+
+		if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+			log.Error().Err(err).Msg("error saving state")
+		}
+		go a.autoscaleEnv(env)
+	}
+
+	return a.GetLab(ctx, &proto.GetLabRequest{LabTag: l.Tag})
+}
+
+// Removes the binding between a team and its lab, the lab itself is left running
+func (a *Agent) ReleaseLab(ctx context.Context, req *proto.ReleaseLabRequest) (*proto.StatusResponse, error) {
+	env, err := a.EnvPool.GetEnv(req.EventTag)
+	if err != nil {
+		log.Error().Str("envTag", req.EventTag).Msg("error finding finding environment with tag")
+		return nil, fmt.Errorf("error finding environment with tag: %s", req.EventTag)
+	}
+
+	l, err := env.ReleaseLab(req.TeamId)
+	if err != nil {
+		log.Error().Err(err).Str("envTag", req.EventTag).Str("teamId", req.TeamId).Msg("error releasing lab")
+		return nil, err
+	}
+	log.Info().Str("labTag", l.Tag).Str("teamId", req.TeamId).Msg("released lab from team")
+
+	if err := state.SaveState(a.EnvPool, a.config.StatePath); err != nil {
+		log.Error().Err(err).Msg("error saving state")
+	}
+	return &proto.StatusResponse{Message: "OK"}, nil
+}
+
+func (a *Agent) GetLabForTeam(ctx context.Context, req *proto.GetLabForTeamRequest) (*proto.GetLabResponse, error) {
+	return a.GetLab(ctx, &proto.GetLabRequest{EventTag: req.EventTag, TeamId: req.TeamId})
+}
+
+// Finds the lab addressed by a lab scoped request, either by lab tag or by the team assigned to it within an event
+func (a *Agent) getLab(labTag, eventTag, teamID string) (*lab.Lab, error) {
+	if labTag != "" {
+		return a.EnvPool.GetLabByTag(labTag)
+	}
+	if eventTag == "" || teamID == "" {
+		return nil, errors.New("either lab tag or event tag and team id is required")
+	}
+	env, err := a.EnvPool.GetEnv(eventTag)
+	if err != nil {
+		return nil, err
+	}
+	return env.GetLabByTeam(teamID)
+}
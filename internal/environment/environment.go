@@ -16,14 +16,21 @@ import (
 
 	wgproto "github.com/aau-network-security/gwireguard/proto" //v1.0.3
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/exercise"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/network/dhcp"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/network/dns"
 	wg "github.com/aau-network-security/haaukins-agent/internal/environment/lab/network/vpn"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+	"github.com/hashicorp/go-multierror"
 	"github.com/rs/zerolog/log"
 )
 
 var (
 	VPNPortmin = 5000
 	VPNPortmax = 6000
+
+	// Connects to the wireguard service of an environment
+	NewVPNClient = wg.NewGRPCVPNClient
 )
 
 func (ec *EnvConfig) NewEnv(ctx context.Context) (*Environment, error) {
@@ -34,7 +41,7 @@ func (ec *EnvConfig) NewEnv(ctx context.Context) (*Environment, error) {
 		return nil, err
 	}
 	// Getting wireguard client from config
-	wgClient, err := wg.NewGRPCVPNClient(ec.VpnConfig)
+	wgClient, err := NewVPNClient(ec.VpnConfig)
 	if err != nil {
 		log.Error().Err(err).Msg("error connecting to wg server")
 		guac.Close()
@@ -43,7 +50,7 @@ func (ec *EnvConfig) NewEnv(ctx context.Context) (*Environment, error) {
 
 	ipT := IPTables{
 		Sudo:     true,
-		ExecFunc: ShellExec,
+		ExecFunc: DefaultExecFunc,
 	}
 
 	dockerHost := virtual.NewHost()
@@ -65,6 +72,7 @@ func (ec *EnvConfig) NewEnv(ctx context.Context) (*Environment, error) {
 		Guac:          guac,
 		IpAddrs:       eventVPNIPs,
 		Labs:          map[string]*lab.Lab{},
+		WarmLabs:      map[string]*lab.Lab{},
 		GuacUserStore: NewGuacUserStore(),
 		Wg:            wgClient,
 		Dockerhost:    dockerHost,
@@ -75,6 +83,25 @@ func (ec *EnvConfig) NewEnv(ctx context.Context) (*Environment, error) {
 	return env, nil
 }
 
+// Returns the docker images used by every environment, regardless of its exercises and frontends
+func BuiltinImages() []string {
+	return []string{guacdImage, guacDbImage, guacWebImage, dns.Image, dhcp.Image}
+}
+
+// Returns the docker images needed by the environment and its labs without duplicates
+func (ec *EnvConfig) Images() []string {
+	seen := make(map[string]bool)
+	var images []string
+	for _, image := range append(BuiltinImages(), ec.LabConf.Images()...) {
+		if seen[image] {
+			continue
+		}
+		seen[image] = true
+		images = append(images, image)
+	}
+	return images
+}
+
 func (env *Environment) Start(ctx context.Context) error {
 	// Just for Logging purposes
 	var frontendNames []string
@@ -126,6 +153,7 @@ func (env *Environment) RemoveVpnLabPeers(ctx context.Context, labTag string) er
 	env.IpT.RemoveStateRule(labIpRules.Labsubnet)
 	env.IpT.RemoveAcceptRule(labIpRules.Labsubnet, labIpRules.VpnIps)
 	delete(env.IpRules, labTag)
+	delete(env.vpnPeerKeys, labTag)
 
 	log.Debug().Msgf("removing wg peers for lab: %s", labTag)
 	vpnIps := strings.Split(labIpRules.VpnIps, ",")
@@ -163,6 +191,176 @@ func (env *Environment) RemoveVpnLabPeers(ctx context.Context, labTag string) er
 	return nil
 }
 
+// Returns the lab in the environment which the guacamole user belongs to
+func (env *Environment) GetLabByGuacUsername(username string) (*lab.Lab, error) {
+	env.M.RLock()
+	defer env.M.RUnlock()
+
+	for _, l := range env.Labs {
+		for _, u := range l.GuacUsernames() {
+			if u == username {
+				return l, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("could not find lab for guac user: %s", username)
+}
+
+// Returns the time since the latest handshake for each peer on the wireguard interface of the environment, keyed by the peer public key
+func (env *Environment) GetVpnHandshakes(ctx context.Context) (map[string]time.Duration, error) {
+	resp, err := env.Wg.ListPeers(ctx, &wgproto.ListPeersReq{Nicname: env.EnvConfig.Tag})
+	if err != nil {
+		return nil, err
+	}
+	return parseHandshakes(resp.Response), nil
+}
+
+// Returns the wireguard public keys of the peers generated for a VPN lab.
+// The keys are cached on the environment since they do not change during the lifetime of the lab.
+func (env *Environment) GetLabPeerKeys(ctx context.Context, labTag string) ([]string, error) {
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	if keys, ok := env.vpnPeerKeys[labTag]; ok {
+		return keys, nil
+	}
+
+	labIpRules, ok := env.IpRules[labTag]
+	if !ok {
+		return nil, fmt.Errorf("no VPN peers found for lab: %s", labTag)
+	}
+
+	var keys []string
+	vpnIps := strings.Split(labIpRules.VpnIps, ",")
+	// Subnet is the last ip and we only want the peers
+	for i := 0; i < len(vpnIps)-1; i++ {
+		ipBytes := strings.Split(vpnIps[i], ".")
+		lastByteStr := strings.Split(ipBytes[3], "/")[0]
+		pubKeyResp, err := env.Wg.GetPublicKey(ctx, &wgproto.PubKeyReq{PubKeyName: env.EnvConfig.Tag + "_" + labTag + "_" + lastByteStr})
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, strings.TrimSpace(pubKeyResp.Message))
+	}
+
+	if env.vpnPeerKeys == nil {
+		env.vpnPeerKeys = make(map[string][]string)
+	}
+	env.vpnPeerKeys[labTag] = keys
+	return keys, nil
+}
+
+// Suspends all labs in the environment in parallel, including frontends, exercises, DNS and DHCP servers, and finally the guacamole containers.
+// Team progress is kept, so the environment can be resumed at a later time.
+func (env *Environment) Suspend(ctx context.Context) error {
+	var res error
+	var m sync.Mutex
+	var wg sync.WaitGroup
+	for _, l := range env.getLabs() {
+		wg.Add(1)
+		go func(l *lab.Lab) {
+			defer wg.Done()
+			l.M.Lock()
+			defer l.M.Unlock()
+			if err := l.SuspendAll(ctx); err != nil {
+				m.Lock()
+				res = multierror.Append(res, fmt.Errorf("error suspending lab %s: %v", l.Tag, err))
+				m.Unlock()
+			}
+		}(l)
+	}
+	wg.Wait()
+
+	if err := env.Guac.Suspend(ctx); err != nil {
+		res = multierror.Append(res, fmt.Errorf("error suspending guacamole: %v", err))
+	}
+	if res != nil {
+		return res
+	}
+
+	env.M.Lock()
+	env.EnvConfig.Status = StatusSuspended
+	env.M.Unlock()
+	return nil
+}
+
+// Stops all exercises in every lab of the environment so no more flags can be captured.
+// Frontends are left running so teams can still access their labs.
+// Stopped exercises are not started again when a suspended lab is resumed.
+func (env *Environment) Freeze(ctx context.Context) error {
+	env.M.Lock()
+	env.EnvConfig.Frozen = true
+	env.M.Unlock()
+
+	var res error
+	var m sync.Mutex
+	var wg sync.WaitGroup
+	for _, l := range env.getLabs() {
+		l.M.RLock()
+		for _, e := range l.Exercises {
+			wg.Add(1)
+			go func(labTag string, e *exercise.Exercise) {
+				defer wg.Done()
+				if err := e.Stop(ctx); err != nil {
+					m.Lock()
+					res = multierror.Append(res, fmt.Errorf("error stopping exercise %s in lab %s: %v", e.Tag, labTag, err))
+					m.Unlock()
+				}
+			}(l.Tag, e)
+		}
+		l.M.RUnlock()
+	}
+	wg.Wait()
+	return res
+}
+
+// Resumes the guacamole containers and then all labs in the environment in parallel
+func (env *Environment) Resume(ctx context.Context) error {
+	var res error
+	if err := env.Guac.Resume(ctx); err != nil {
+		res = multierror.Append(res, fmt.Errorf("error resuming guacamole: %v", err))
+	}
+
+	var m sync.Mutex
+	var wg sync.WaitGroup
+	for _, l := range env.getLabs() {
+		wg.Add(1)
+		go func(l *lab.Lab) {
+			defer wg.Done()
+			l.M.Lock()
+			defer l.M.Unlock()
+			if err := l.ResumeAll(ctx); err != nil {
+				m.Lock()
+				res = multierror.Append(res, fmt.Errorf("error resuming lab %s: %v", l.Tag, err))
+				m.Unlock()
+				return
+			}
+			// Labs get a fresh idle period after being resumed
+			l.IdleSuspended = false
+			l.LastActivity = time.Now()
+		}(l)
+	}
+	wg.Wait()
+	if res != nil {
+		return res
+	}
+
+	env.M.Lock()
+	env.EnvConfig.Status = StatusRunning
+	env.M.Unlock()
+	return nil
+}
+
+func (env *Environment) getLabs() []*lab.Lab {
+	env.M.RLock()
+	defer env.M.RUnlock()
+	labs := make([]*lab.Lab, 0, len(env.Labs))
+	for _, l := range env.Labs {
+		labs = append(labs, l)
+	}
+	return labs
+}
+
 // Closes environment including removing all related containers, and vpn configs
 func (env *Environment) Close() error {
 	env.M.Lock()
@@ -180,6 +378,15 @@ func (env *Environment) Close() error {
 			defer wg.Done()
 		}(l)
 	}
+	for _, l := range env.WarmLabs {
+		wg.Add(1)
+		go func(c io.Closer) {
+			if err := c.Close(); err != nil {
+				log.Warn().Msgf("error while closing warm lab for event '%s': %s", env.EnvConfig.Tag, err)
+			}
+			defer wg.Done()
+		}(l)
+	}
 
 	env.removeVPNConfs()
 	env.removeIPTableRules()
@@ -227,6 +434,57 @@ func removeVPNConfigs(confFile string) error {
 	return err
 }
 
+// Parses the output of "wg show <interface>" into the time since the latest handshake for each peer.
+// Peers that has never completed a handshake are left out.
+func parseHandshakes(output string) map[string]time.Duration {
+	handshakes := make(map[string]time.Duration)
+	var peer string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "peer:"):
+			peer = strings.TrimSpace(strings.TrimPrefix(line, "peer:"))
+		case strings.HasPrefix(line, "latest handshake:") && peer != "":
+			age, ok := parseHandshakeAge(strings.TrimSpace(strings.TrimPrefix(line, "latest handshake:")))
+			if ok {
+				handshakes[peer] = age
+			}
+		}
+	}
+	return handshakes
+}
+
+// Parses handshake ages from wg like "1 hour, 2 minutes, 3 seconds ago" or "Now"
+func parseHandshakeAge(s string) (time.Duration, bool) {
+	if s == "Now" {
+		return 0, true
+	}
+	units := map[string]time.Duration{
+		"second": time.Second,
+		"minute": time.Minute,
+		"hour":   time.Hour,
+		"day":    24 * time.Hour,
+		"year":   365 * 24 * time.Hour,
+	}
+	var age time.Duration
+	for _, part := range strings.Split(strings.TrimSuffix(s, " ago"), ",") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return 0, false
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return 0, false
+		}
+		unit, ok := units[strings.TrimSuffix(fields[1], "s")]
+		if !ok {
+			return 0, false
+		}
+		age += time.Duration(n) * unit
+	}
+	return age, true
+}
+
 func makeRange(min, max int) []int {
 	a := make([]int, max-min+1)
 	for i := range a {
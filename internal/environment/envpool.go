@@ -2,6 +2,7 @@ package environment
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
 )
@@ -139,3 +140,93 @@ func (ep *EnvPool) RemoveClosingEnv(eventTag string) {
 
 	delete(ep.ClosingEnvs, eventTag)
 }
+
+// Returns the tags of environments which are currently suspended
+func (ep *EnvPool) GetSuspendedEnvs() map[string]bool {
+	ep.M.RLock()
+	defer ep.M.RUnlock()
+
+	suspended := make(map[string]bool)
+	for eventTag, env := range ep.Envs {
+		if env.EnvConfig.Status == StatusSuspended {
+			suspended[eventTag] = true
+		}
+	}
+	return suspended
+}
+
+// Adds or replaces the schedule for an environment
+func (ep *EnvPool) AddSchedule(schedule *Schedule) {
+	ep.M.Lock()
+	defer ep.M.Unlock()
+
+	ep.Schedules[schedule.EventTag] = schedule
+}
+
+// Returns a copy of the schedule for an environment
+func (ep *EnvPool) GetSchedule(eventTag string) (Schedule, bool) {
+	ep.M.RLock()
+	defer ep.M.RUnlock()
+
+	s, ok := ep.Schedules[eventTag]
+	if !ok {
+		return Schedule{}, false
+	}
+	return *s, true
+}
+
+func (ep *EnvPool) RemoveSchedule(eventTag string) {
+	ep.M.Lock()
+	defer ep.M.Unlock()
+
+	delete(ep.Schedules, eventTag)
+}
+
+// Returns a copy of all schedules so they can be read without holding the lock
+func (ep *EnvPool) GetSchedules() []Schedule {
+	ep.M.RLock()
+	defer ep.M.RUnlock()
+
+	var schedules []Schedule
+	for _, s := range ep.Schedules {
+		schedules = append(schedules, *s)
+	}
+	return schedules
+}
+
+// Updates a schedule under lock if it still exists
+func (ep *EnvPool) UpdateSchedule(eventTag string, update func(s *Schedule)) {
+	ep.M.Lock()
+	defer ep.M.Unlock()
+
+	if s, ok := ep.Schedules[eventTag]; ok {
+		update(s)
+	}
+}
+
+// Returns true if the environment has been frozen, in which case no exercises may be started in it
+func (ep *EnvPool) IsEnvFrozen(eventTag string) bool {
+	ep.M.RLock()
+	defer ep.M.RUnlock()
+
+	if env, ok := ep.Envs[eventTag]; ok && env.EnvConfig.Frozen {
+		return true
+	}
+	s, ok := ep.Schedules[eventTag]
+	return ok && s.Frozen
+}
+
+// Returns true if the environment should be created
+func (s Schedule) StartDue(now time.Time) bool {
+	return !s.Started && !now.Before(s.StartAt)
+}
+
+// Returns true if the exercises in the environment should be stopped
+func (s Schedule) FreezeDue(now time.Time) bool {
+	return s.Started && !s.Frozen && !s.FreezeAt.IsZero() && !now.Before(s.FreezeAt)
+}
+
+// Returns true if the environment should be closed
+func (s Schedule) TeardownDue(now time.Time) bool {
+	return s.Started && !s.TeardownAt.IsZero() && !now.Before(s.TeardownAt)
+}
@@ -23,6 +23,15 @@ import (
 var (
 	DefaultAdminUser = "guacadmin"
 	DefaultAdminPass = "guacadmin"
+
+	// Transport used for the guacamole API, the default transport is used if nil
+	GuacTransport http.RoundTripper
+)
+
+const (
+	guacdImage   = "guacamole/guacd:1.5.3"
+	guacDbImage  = "ghcr.io/campfire-security/guac-db:latest"
+	guacWebImage = "guacamole/guacamole:1.5.3"
 )
 
 // TODO Go through all the code, make sure it makes sense, comment the code
@@ -37,15 +46,11 @@ func (ge *GuacError) Error() string {
 
 // Creates a new Guacamole struct for an environment.
 func NewGuac(ctx context.Context, eventTag string) (Guacamole, error) {
-	jar, err := cookiejar.New(nil)
+	client, err := NewGuacClient()
 	if err != nil {
 		return Guacamole{}, err
 	}
 
-	client := &http.Client{
-		Jar: jar,
-	}
-
 	adminPass := uuid.New().String()
 	log.Debug().Str("guacpassword", adminPass).Msg("setting password for guac")
 	guac := Guacamole{
@@ -60,6 +65,19 @@ func NewGuac(ctx context.Context, eventTag string) (Guacamole, error) {
 	return guac, nil
 }
 
+// Creates the http client used for the guacamole API
+func NewGuacClient() (*http.Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Jar:       jar,
+		Transport: GuacTransport,
+	}, nil
+}
+
 /*
 Creates the necessary containers for guacamole and configures the instance with a new admin password
 */
@@ -75,7 +93,7 @@ func (guac *Guacamole) create(ctx context.Context, eventTag string) error {
 	containers := map[string]*virtual.Container{}
 
 	containers["guacd"] = virtual.NewContainer(virtual.ContainerConfig{
-		Image:     "guacamole/guacd:1.5.3",
+		Image:     guacdImage,
 		UseBridge: true,
 		Labels: map[string]string{
 			"hkn": "guacamole_guacd",
@@ -89,7 +107,7 @@ func (guac *Guacamole) create(ctx context.Context, eventTag string) error {
 	mysqlPass := uuid.New().String()
 	log.Debug().Str("mysqlPass", mysqlPass).Msg("mysql pw for guac")
 	containers["db"] = virtual.NewContainer(virtual.ContainerConfig{
-		Image: "ghcr.io/campfire-security/guac-db:latest",
+		Image: guacDbImage,
 		EnvVars: map[string]string{
 			"MYSQL_ROOT_PASSWORD": uuid.New().String(),
 			"MYSQL_DATABASE":      "guacamole_db",
@@ -105,7 +123,7 @@ func (guac *Guacamole) create(ctx context.Context, eventTag string) error {
 	guacdAlias := uuid.New().String()
 	dbAlias := uuid.New().String()
 	containers["web"] = virtual.NewContainer(virtual.ContainerConfig{
-		Image: "guacamole/guacamole:1.5.3",
+		Image: guacWebImage,
 		EnvVars: map[string]string{
 			"MYSQL_DATABASE": "guacamole_db?useSSL=false",
 			"MYSQL_USER":     "guacamole_user",
@@ -169,13 +187,37 @@ func (guac *Guacamole) Close() error {
 	return nil
 }
 
+// Pauses the guacamole containers
+func (guac *Guacamole) Suspend(ctx context.Context) error {
+	for _, c := range guac.Containers {
+		if c.Info().State != virtual.Running {
+			continue
+		}
+		if err := c.Suspend(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unpauses the guacamole containers
+func (guac *Guacamole) Resume(ctx context.Context) error {
+	for _, c := range guac.Containers {
+		if c.Info().State == virtual.Running {
+			continue
+		}
+		if err := c.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Connects VMs in a lab to the corresponding guacamole instance for the environment.
+// For teams with more than one member, each member gets its own guacamole user owning a single frontend,
+// while the guacamole user of the lab can access all of them.
+// If shared screens are enabled for the environment, members can also access the frontends of their teammates.
 func (env *Environment) CreateGuacConn(lab lab.Lab) error {
-	enableWallPaper := true
-	enableDrive := true
-	createDrivePath := true
-	// Drive path is the home folder inside the docker guacamole
-	drivePath := "/home/" + lab.GuacUsername
 	rdpPorts := lab.RdpConnPorts()
 	if n := len(rdpPorts); n == 0 {
 		log.
@@ -186,13 +228,10 @@ func (env *Environment) CreateGuacConn(lab lab.Lab) error {
 		return errors.New("error too few rdp connections")
 	}
 
+	perMember := env.EnvConfig.TeamSize > 1
+	// The team user of the lab is always created, as its credentials are returned to the daemon
 	log.Debug().Str("username", lab.GuacUsername).Str("password", lab.GuacPassword).Msg("creating guac user with credentials")
-	u := GuacUser{
-		Username: lab.GuacUsername,
-		Password: lab.GuacPassword,
-	}
-
-	if err := env.Guac.CreateUser(u.Username, u.Password); err != nil {
+	if err := env.Guac.CreateUser(lab.GuacUsername, lab.GuacPassword); err != nil {
 		log.
 			Debug().
 			Str("err", err.Error()).
@@ -206,39 +245,167 @@ func (env *Environment) CreateGuacConn(lab lab.Lab) error {
 	}
 
 	for i, port := range rdpPorts {
-		num := i + 1
-		name := fmt.Sprintf("%s-client%d", lab.GuacUsername, num)
-
-		log.Debug().Uint("port", port).Msg("Creating RDP Connection for lab")
-		if err := env.Guac.CreateRDPConn(CreateRDPConnOpts{
-			Host:            hostIp,
-			Port:            port,
-			Name:            name,
-			GuacUser:        u.Username,
-			Username:        &u.Username,
-			Password:        &u.Password,
-			EnableWallPaper: &enableWallPaper,
-			EnableDrive:     &enableDrive,
-			CreateDrivePath: &createDrivePath,
-			DrivePath:       &drivePath,
-		}); err != nil {
+		if err := env.createFrontendConn(lab, port, i+1, hostIp); err != nil {
 			return err
 		}
 	}
 
-	instanceInfo := lab.InstanceInfo()
+	if perMember && env.EnvConfig.SharedScreens {
+		for _, port := range rdpPorts {
+			env.shareFrontendConn(lab, port)
+		}
+	}
+
 	// Will not handle error below since this is not a critical function
 	_ = virtual.CreateUserFolder(lab.GuacUsername, env.EnvConfig.Tag)
 
-	for i := 0; i < len(rdpPorts); i++ {
-		if err := virtual.CreateFolderLink(instanceInfo[i].Id, env.EnvConfig.Tag, lab.GuacUsername); err != nil {
-			log.Error().Err(err).Str("instanceId", instanceInfo[i].Id).Msg("error creating folder link for instance with id")
+	// Shared folders are only available for virtualbox frontends
+	for _, port := range rdpPorts {
+		vm := lab.Frontends[port].Vm
+		if vm == nil {
+			continue
+		}
+		if err := virtual.CreateFolderLink(vm.Info().Id, env.EnvConfig.Tag, lab.GuacUsername); err != nil {
+			log.Error().Err(err).Str("instanceId", vm.Info().Id).Msg("error creating folder link for instance with id")
+		}
+	}
+
+	return nil
+}
+
+// Creates the guacamole connection for a frontend added to a running lab, including a guacamole user for the new team member and the file transfer link
+func (env *Environment) AddFrontendGuacConn(l *lab.Lab, port uint) error {
+	hostIp, err := env.Dockerhost.GetDockerHostIP()
+	if err != nil {
+		return err
+	}
+
+	if err := env.createFrontendConn(*l, port, l.NextFrontendNum(), hostIp); err != nil {
+		return err
+	}
+
+	if env.EnvConfig.TeamSize > 1 && env.EnvConfig.SharedScreens {
+		env.shareFrontendConn(*l, port)
+	}
+
+	if vm := l.Frontends[port].Vm; vm != nil {
+		if err := virtual.CreateFolderLink(vm.Info().Id, env.EnvConfig.Tag, l.GuacUsername); err != nil {
+			log.Error().Err(err).Uint("port", port).Msg("error creating folder link for frontend")
+		}
+	}
+	return nil
+}
+
+// Removes the guacamole connection of a frontend, and the guacamole user of the team member owning it
+func (env *Environment) RemoveFrontendGuacConn(frontend lab.FrontendConf) error {
+	if frontend.ConnectionID != "" {
+		if err := env.Guac.DeleteConnection(frontend.ConnectionID); err != nil {
+			return err
+		}
+	}
+	if frontend.GuacUsername != "" {
+		if err := env.Guac.DeleteUser(frontend.GuacUsername); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Creates the RDP connection for a single frontend. Teams with more than one member gets a guacamole user per frontend,
+// and the connection is added to the guacamole user of the lab in either case.
+func (env *Environment) createFrontendConn(lab lab.Lab, port uint, num int, hostIp string) error {
+	enableWallPaper := true
+	enableDrive := true
+	createDrivePath := true
+	// Drive path is the home folder inside the docker guacamole
+	drivePath := "/home/" + lab.GuacUsername
+
+	name := fmt.Sprintf("%s-client%d", lab.GuacUsername, num)
+	// Connection names has to be unique within guacamole, so the lab username is kept in named connections
+	if frontendName := lab.Frontends[port].Conf.Name; frontendName != "" {
+		name = fmt.Sprintf("%s (%s)", frontendName, lab.GuacUsername)
+	}
+
+	perMember := env.EnvConfig.TeamSize > 1
+	u := GuacUser{
+		Username: lab.GuacUsername,
+		Password: lab.GuacPassword,
+	}
+	if perMember {
+		u = GuacUser{
+			Username: fmt.Sprintf("%s-%d", lab.GuacUsername, num),
+			Password: uuid.New().String()[0:8],
+		}
+		log.Debug().Str("username", u.Username).Msg("creating guac user for team member")
+		if err := env.Guac.CreateUser(u.Username, u.Password); err != nil {
+			log.
+				Debug().
+				Str("err", err.Error()).
+				Msg("Unable to create guacamole user")
+			return err
 		}
 	}
 
+	opts := CreateRDPConnOpts{
+		Host:            hostIp,
+		Port:            port,
+		Name:            name,
+		GuacUser:        u.Username,
+		Username:        &u.Username,
+		Password:        &u.Password,
+		EnableWallPaper: &enableWallPaper,
+		EnableDrive:     &enableDrive,
+		CreateDrivePath: &createDrivePath,
+		DrivePath:       &drivePath,
+	}
+	// VNC frontends handle their own authentication and have no shared drive
+	if lab.Frontends[port].Conf.Protocol == virtual.ProtocolVNC {
+		opts = CreateRDPConnOpts{
+			Host:     hostIp,
+			Port:     port,
+			Name:     name,
+			Protocol: virtual.ProtocolVNC,
+			GuacUser: u.Username,
+		}
+	}
+
+	log.Debug().Uint("port", port).Str("protocol", opts.Protocol).Msg("Creating remote desktop connection for lab")
+	connId, err := env.Guac.CreateRDPConn(opts)
+	if err != nil {
+		return err
+	}
+
+	frontend := lab.Frontends[port]
+	frontend.ConnectionID = connId
+	frontend.ConnectionNum = num
+	if perMember {
+		// The team user of the lab can access the frontends of all members
+		if err := env.Guac.addConnectionToUser(connId, lab.GuacUsername); err != nil {
+			log.Error().Err(err).Str("username", lab.GuacUsername).Msg("error adding member connection to team guac user")
+		}
+		frontend.GuacUsername = u.Username
+		frontend.GuacPassword = u.Password
+	}
+	lab.Frontends[port] = frontend
 	return nil
 }
 
+// Gives the owner of a frontend access to the frontends of its teammates and the other way around
+func (env *Environment) shareFrontendConn(lab lab.Lab, port uint) {
+	owner := lab.Frontends[port]
+	for p, member := range lab.Frontends {
+		if p == port || member.GuacUsername == "" {
+			continue
+		}
+		if err := env.Guac.addConnectionToUser(owner.ConnectionID, member.GuacUsername); err != nil {
+			log.Error().Err(err).Str("username", member.GuacUsername).Msg("error sharing teammate connection with guac user")
+		}
+		if err := env.Guac.addConnectionToUser(member.ConnectionID, owner.GuacUsername); err != nil {
+			log.Error().Err(err).Str("username", owner.GuacUsername).Msg("error sharing teammate connection with guac user")
+		}
+	}
+}
+
 // Creates a new user in Apache guacamole which can access a specific set of VMs
 func (guac *Guacamole) CreateUser(username, password string) error {
 	action := func(t string) (*http.Response, error) {
@@ -270,18 +437,18 @@ func (guac *Guacamole) CreateUser(username, password string) error {
 	return nil
 }
 
-// Creates the Apache Guacamole RDP connection to a specific vm
-func (guac *Guacamole) CreateRDPConn(opts CreateRDPConnOpts) error {
+// Creates the Apache Guacamole RDP connection to a specific vm and returns the identifier of the connection
+func (guac *Guacamole) CreateRDPConn(opts CreateRDPConnOpts) (string, error) {
 	if opts.Host == "" {
-		return errors.New("host is missing")
+		return "", errors.New("host is missing")
 	}
 
 	if opts.Port == 0 {
-		return errors.New("port is missing")
+		return "", errors.New("port is missing")
 	}
 
 	if opts.Name == "" {
-		return errors.New("name is missing")
+		return "", errors.New("name is missing")
 	}
 
 	if opts.ResolutionWidth == 0 || opts.ResolutionHeight == 0 {
@@ -294,13 +461,19 @@ func (guac *Guacamole) CreateRDPConn(opts CreateRDPConnOpts) error {
 	}
 
 	if opts.ColorDepth%8 != 0 || opts.ColorDepth > 32 {
-		return errors.New("colorDepth can take the following values: 8, 16, 24, 32")
+		return "", errors.New("colorDepth can take the following values: 8, 16, 24, 32")
 	}
 
 	if opts.ColorDepth == 0 {
 		opts.ColorDepth = 16
 	}
-	log.Debug().Str("drive-path", *opts.DrivePath).Msg("Drivepath for user is")
+
+	if opts.Protocol == "" {
+		opts.Protocol = virtual.ProtocolRDP
+	}
+	if opts.DrivePath != nil {
+		log.Debug().Str("drive-path", *opts.DrivePath).Msg("Drivepath for user is")
+	}
 	conf := createRDPConnConf{
 		Hostname:        &opts.Host,
 		Width:           &opts.ResolutionWidth,
@@ -324,7 +497,7 @@ func (guac *Guacamole) CreateRDPConn(opts CreateRDPConnOpts) error {
 	}{
 		Name:             opts.Name,
 		ParentIdentifier: "ROOT",
-		Protocol:         "rdp",
+		Protocol:         opts.Protocol,
 		Attributes: createRDPConnAttr{
 			MaxConn:        opts.MaxConn,
 			MaxConnPerUser: opts.MaxConn,
@@ -349,10 +522,48 @@ func (guac *Guacamole) CreateRDPConn(opts CreateRDPConnOpts) error {
 		Id string `json:"identifier"`
 	}
 	if err := guac.authAction("create rdp connection", action, &out); err != nil {
-		return err
+		return "", err
 	}
 
 	if err := guac.addConnectionToUser(out.Id, opts.GuacUser); err != nil {
+		return "", err
+	}
+
+	return out.Id, nil
+}
+
+// Deletes a guacamole connection by its identifier
+func (guac *Guacamole) DeleteConnection(id string) error {
+	action := func(t string) (*http.Response, error) {
+		endpoint := fmt.Sprintf("%s/guacamole/api/session/data/mysql/connections/%s?token=%s", guac.baseUrl(), id, t)
+		req, err := http.NewRequest("DELETE", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return guac.Client.Do(req)
+	}
+
+	if err := guac.authAction("delete connection", action, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Deletes a guacamole user
+func (guac *Guacamole) DeleteUser(username string) error {
+	action := func(t string) (*http.Response, error) {
+		endpoint := fmt.Sprintf("%s/guacamole/api/session/data/mysql/users/%s?token=%s", guac.baseUrl(), username, t)
+		req, err := http.NewRequest("DELETE", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return guac.Client.Do(req)
+	}
+
+	if err := guac.authAction("delete user", action, nil); err != nil {
 		return err
 	}
 
@@ -523,6 +734,33 @@ func (guac *Guacamole) GetPortFromConnectionIdentifier(connectionIdentifier stri
 	return resp.Port, nil
 }
 
+// Returns the set of guacamole users which currently has an active connection to one of their VMs
+func (guac *Guacamole) GetActiveUsers() (map[string]bool, error) {
+	action := func(t string) (*http.Response, error) {
+		endpoint := guac.baseUrl() + "/guacamole/api/session/data/mysql/activeConnections?token=" + t
+		req, err := http.NewRequest("GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		return guac.Client.Do(req)
+	}
+
+	var resp map[string]struct {
+		Username string `json:"username"`
+	}
+	if err := guac.authAction("get active connections", action, &resp); err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]bool)
+	for _, conn := range resp {
+		users[conn.Username] = true
+	}
+	return users, nil
+}
+
 func (guac *Guacamole) baseUrl() string {
 	return fmt.Sprintf("http://127.0.0.1:%d", guac.Port)
 }
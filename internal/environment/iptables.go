@@ -134,6 +134,10 @@ func (ipTab *IPTables) exec(cmd string, args ...string) ([]byte, error) {
 	}
 	return out, nil
 }
+
+// ExecFunc used for the iptables of new and resumed environments
+var DefaultExecFunc ExecFunc = ShellExec
+
 func ShellExec(cmd string, args ...string) ([]byte, error) {
 	return exec.Command(cmd, args...).CombinedOutput()
 }
@@ -11,7 +11,6 @@ import (
 	"github.com/aau-network-security/haaukins-agent/pkg/proto"
 	"github.com/hashicorp/go-multierror"
 	"github.com/rs/zerolog/log"
-	"golang.org/x/exp/slices"
 )
 
 // AddExercises uses exercise configs from the exercise service to configure containers and flags to be started at a later time
@@ -19,6 +18,25 @@ func (l *Lab) AddExercises(ctx context.Context, confs ...exercise.ExerciseConfig
 	var e *exercise.Exercise
 	var aRecord string
 
+	// Refuse all exercises before creating any of them if one of them is not allowed
+	for _, conf := range confs {
+		if err := conf.CheckImagePolicy(l.ImagePolicy); err != nil {
+			return err
+		}
+		if err := conf.CheckHealthChecks(); err != nil {
+			return err
+		}
+		if err := conf.CheckDependencies(); err != nil {
+			return err
+		}
+		if err := conf.CheckContainerOptions(); err != nil {
+			return err
+		}
+	}
+	if err := l.checkExerciseDependencies(confs); err != nil {
+		return err
+	}
+
 	for _, conf := range confs {
 		if conf.Tag == "" {
 			return errors.New("No tags, need atleast one tag")
@@ -31,8 +49,12 @@ func (l *Lab) AddExercises(ctx context.Context, confs ...exercise.ExerciseConfig
 		if conf.Static {
 			// TODO remove static exercises on agent side, but need the overview first
 			e = exercise.NewExercise(conf, nil, nil, "")
+			e.LabTag = l.Tag
+			e.OnStatusChange = l.OnExerciseStatusChange
 		} else {
 			e = exercise.NewExercise(conf, l.Vlib, l.Network, l.DnsAddress)
+			e.LabTag = l.Tag
+			e.OnStatusChange = l.OnExerciseStatusChange
 			if err := e.Create(ctx); err != nil {
 				return err
 			}
@@ -56,9 +78,96 @@ func (l *Lab) AddExercises(ctx context.Context, confs ...exercise.ExerciseConfig
 		//.Exercises = append(l.Exercises, e)
 	}
 
+	if _, err := l.ensureProber(ctx); err != nil {
+		return fmt.Errorf("error starting health check prober: %w", err)
+	}
 	return nil
 }
 
+// Returns an error if the exercises depend on exercises which are not in the lab or being added, or on each other in a cycle
+func (l *Lab) checkExerciseDependencies(confs []exercise.ExerciseConfig) error {
+	deps := make(map[string][]string)
+	for tag, e := range l.Exercises {
+		deps[tag] = e.DependsOn
+	}
+	for _, conf := range confs {
+		deps[conf.Tag] = conf.DependsOn
+	}
+	for _, conf := range confs {
+		for _, dep := range conf.DependsOn {
+			if _, ok := deps[dep]; !ok {
+				return fmt.Errorf("exercise %s depends on exercise %s which is not in the lab", conf.Tag, dep)
+			}
+		}
+	}
+	return exercise.DependencyCycle(deps)
+}
+
+// Starts the exercises concurrently, except that exercises wait for the exercises they depend on to be ready.
+// Exercises they depend on which are not running are started as well
+func (l *Lab) startExercises(ctx context.Context, exercises ...*exercise.Exercise) error {
+	toStart := make(map[string]*exercise.Exercise)
+	var add func(e *exercise.Exercise)
+	add = func(e *exercise.Exercise) {
+		if _, ok := toStart[e.Tag]; ok {
+			return
+		}
+		toStart[e.Tag] = e
+		for _, dep := range e.DependsOn {
+			d, ok := l.Exercises[dep]
+			if !ok {
+				continue
+			}
+			if status, _, _ := d.GetStatus(); status != exercise.StatusRunning {
+				add(d)
+			}
+		}
+	}
+	for _, e := range exercises {
+		add(e)
+	}
+
+	done := make(map[string]chan struct{})
+	for tag := range toStart {
+		done[tag] = make(chan struct{})
+	}
+
+	var res error
+	var m sync.Mutex
+	var wg sync.WaitGroup
+	for _, ex := range toStart {
+		wg.Add(1)
+		go func(e *exercise.Exercise) {
+			defer wg.Done()
+			defer close(done[e.Tag])
+			for _, dep := range e.DependsOn {
+				d, ok := l.Exercises[dep]
+				if !ok {
+					continue
+				}
+				if ch, ok := done[dep]; ok {
+					<-ch
+				}
+				if err := d.WaitReady(ctx); err != nil {
+					err = fmt.Errorf("dependency of exercise %s is not ready: %w", e.Tag, err)
+					e.SetFailed(err)
+					m.Lock()
+					res = multierror.Append(res, err)
+					m.Unlock()
+					return
+				}
+			}
+			if err := e.Start(ctx); err != nil {
+				m.Lock()
+				res = multierror.Append(res, err)
+				m.Unlock()
+			}
+		}(ex)
+	}
+	wg.Wait()
+	return res
+}
+
 // Used to add exercises to an already running lab.
 // It configures the containers, refreshes the DNS to add the new records and then starts the new exercise containers
 func (l *Lab) AddAndStartExercises(ctx context.Context, exerConfs ...exercise.ExerciseConfig) error {
@@ -76,29 +185,13 @@ func (l *Lab) AddAndStartExercises(ctx context.Context, exerConfs ...exercise.Ex
 		return err
 	}
 
-	newExTags := []string{}
-	for _, exerConf := range exerConfs {
-		newExTags = append(newExTags, exerConf.Tag)
-	}
-
 	// Start the exercises
-	var res error
-	var wg sync.WaitGroup
-	for _, ex := range l.Exercises {
-		if slices.Contains(newExTags, ex.Tag) {
-			wg.Add(1)
-			go func(e *exercise.Exercise) {
-				if err := e.Start(ctx); err != nil {
-					res = multierror.Append(res, err)
-				}
-				wg.Done()
-			}(ex)
-		}
-
+	var newExercises []*exercise.Exercise
+	for _, exerConf := range exerConfs {
+		newExercises = append(newExercises, l.Exercises[exerConf.Tag])
 	}
-	wg.Wait()
-	if res != nil {
-		return res
+	if err := l.startExercises(ctx, newExercises...); err != nil {
+		return err
 	}
 	return nil
 }
@@ -110,7 +203,7 @@ func (l *Lab) StartExercise(ctx context.Context, exTag string) error {
 		return fmt.Errorf("could not find exercise with tag: %s", exTag)
 	}
 
-	if err := e.Start(ctx); err != nil {
+	if err := l.startExercises(ctx, e); err != nil {
 		return err
 	}
 	return nil
@@ -140,17 +233,34 @@ func (l *Lab) ResetExercise(ctx context.Context, exTag string) error {
 	return nil
 }
 
+// Updates memory and cpu of the containers of an exercise without restarting them
+func (l *Lab) ResizeExercise(exTag string, memoryMB uint, cpu float64) error {
+	e, ok := l.Exercises[exTag]
+	if !ok {
+		return fmt.Errorf("could not find exercise with tag: %s", exTag)
+	}
+
+	if err := e.Resize(memoryMB, cpu); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Returns all exercises currently in lab to be sent to the daemon
 func (l *Lab) GetExercisesInfo() []*proto.Exercise {
 	var exercises []*proto.Exercise
 	for _, e := range l.Exercises {
 		var machines []*proto.Machine
 		for _, m := range e.Machines {
+			info := m.Info()
 			machine := &proto.Machine{
-				Id:     m.Info().Id,
-				Status: m.Info().State.String(),
-				Type:   m.Info().Type,
-				Image:  m.Info().Image,
+				Id:          info.Id,
+				Status:      info.State.String(),
+				Type:        info.Type,
+				Image:       info.Image,
+				Digest:      info.Digest,
+				Health:      info.Health.String(),
+				HealthError: info.HealthError,
 			}
 			machines = append(machines, machine)
 		}
@@ -165,10 +275,14 @@ func (l *Lab) GetExercisesInfo() []*proto.Exercise {
 			protoChildExercises = append(protoChildExercises, protoChildExercise)
 		}
 
+		status, reason, changed := e.GetStatus()
 		exercise := &proto.Exercise{
-			Tag:            e.Tag,
-			ChildExercises: protoChildExercises,
-			Machines:       machines,
+			Tag:             e.Tag,
+			ChildExercises:  protoChildExercises,
+			Machines:        machines,
+			Status:          status.String(),
+			StatusReason:    reason,
+			StatusChangedAt: changed.Unix(),
 		}
 		exercises = append(exercises, exercise)
 	}
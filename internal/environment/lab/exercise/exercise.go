@@ -3,9 +3,13 @@ package exercise
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
 	"github.com/hashicorp/go-multierror"
@@ -20,8 +24,39 @@ var (
 	tagRawRegexp    = `^[a-z0-9][a-z0-9-]*[a-z0-9]$`
 	tagRegex        = regexp.MustCompile(tagRawRegexp)
 	OvaSuffix       = ".ova"
+	capabilityRegex = regexp.MustCompile(`^[A-Z0-9_]+$`)
 )
 
+const (
+	// How long to wait for a machine to be ready before starting the machines or exercises depending on it
+	readyTimeout      = 5 * time.Minute
+	readyPollInterval = 2 * time.Second
+)
+
+// Actions taken when a container of an exercise is unhealthy
+const (
+	HealthActionRestart = "restart"
+	HealthActionReset   = "reset"
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusCreating:
+		return "creating"
+	case StatusStarting:
+		return "starting"
+	case StatusRunning:
+		return "running"
+	case StatusStopped:
+		return "stopped"
+	case StatusResetting:
+		return "resetting"
+	case StatusFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
 // TODO add comments
 func NewExercise(conf ExerciseConfig, vlib *virtual.VboxLibrary, net *virtual.Network, dnsAddr string) *Exercise {
 	var containerOpts []ContainerOptions
@@ -44,17 +79,69 @@ func NewExercise(conf ExerciseConfig, vlib *virtual.VboxLibrary, net *virtual.Ne
 			Vlib:          vlib,
 			Net:           net,
 			DnsAddr:       dnsAddr,
+			DependsOn:     conf.DependsOn,
+			// Stopped until created, so creating the exercise is reported as a change
+			Status: StatusStopped,
 		}
 	} else {
+		// Static exercises have nothing to run, so they are always running
 		ex = &Exercise{
 			ContainerOpts: containerOpts,
 			Tag:           conf.Tag,
+			DependsOn:     conf.DependsOn,
+			Status:        StatusRunning,
 		}
 	}
+	ex.StatusChanged = time.Now()
 	return ex
 }
 
+// Returns the current status of the exercise, the reason if it has failed and when it changed
+func (e *Exercise) GetStatus() (Status, string, time.Time) {
+	e.statusM.Lock()
+	defer e.statusM.Unlock()
+	return e.Status, e.StatusReason, e.StatusChanged
+}
+
+// Changes the status of the exercise and notifies OnStatusChange. If err is not nil the exercise has failed
+func (e *Exercise) setStatus(status Status, err error) {
+	e.statusM.Lock()
+	reason := ""
+	if err != nil {
+		status, reason = StatusFailed, err.Error()
+	}
+	if e.Status == status && e.StatusReason == reason {
+		e.statusM.Unlock()
+		return
+	}
+	e.Status, e.StatusReason, e.StatusChanged = status, reason, time.Now()
+	event := StatusEvent{
+		LabTag: e.LabTag,
+		Tag:    e.Tag,
+		Status: status,
+		Reason: reason,
+		Time:   e.StatusChanged,
+	}
+	e.statusM.Unlock()
+
+	log.Debug().Str("labTag", e.LabTag).Str("exTag", e.Tag).Str("status", status.String()).Str("reason", reason).Msg("exercise status changed")
+	if e.OnStatusChange != nil {
+		e.OnStatusChange(event)
+	}
+}
+
+// Creates the machines of the exercise, which are stopped afterwards
 func (e *Exercise) Create(ctx context.Context) error {
+	e.setStatus(StatusCreating, nil)
+	if err := e.create(ctx); err != nil {
+		e.setStatus(StatusFailed, err)
+		return err
+	}
+	e.setStatus(StatusStopped, nil)
+	return nil
+}
+
+func (e *Exercise) create(ctx context.Context) error {
 	var machines []virtual.Instance
 	var newIps []int
 	for i, opt := range e.ContainerOpts {
@@ -127,52 +214,221 @@ func (e *Exercise) Create(ctx context.Context) error {
 }
 
 func (e *Exercise) Start(ctx context.Context) error {
+	e.setStatus(StatusStarting, nil)
+	if err := e.start(ctx); err != nil {
+		e.setStatus(StatusFailed, err)
+		return err
+	}
+	e.Suspended = false
+	e.setStatus(StatusRunning, nil)
+	return nil
+}
+
+// Starts the machines concurrently, except that machines wait for the machines they depend on to be ready
+func (e *Exercise) start(ctx context.Context) error {
 	var res error
+	var m sync.Mutex
 	var wg sync.WaitGroup
 
-	for _, m := range e.Machines {
+	deps := e.machineDependencies()
+	done := make([]chan struct{}, len(e.Machines))
+	failed := make([]bool, len(e.Machines))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	fail := func(i int, err error) {
+		m.Lock()
+		defer m.Unlock()
+		failed[i] = true
+		res = multierror.Append(res, err)
+	}
+
+	for i, machine := range e.Machines {
 		wg.Add(1)
-		go func(m virtual.Instance) {
-			if m.Info().State != virtual.Running {
-				if err := m.Start(ctx); err != nil && res == nil {
-					res = multierror.Append(res, err)
+		go func(i int, machine virtual.Instance) {
+			defer wg.Done()
+			defer close(done[i])
+			for _, d := range deps[i] {
+				<-done[d]
+				m.Lock()
+				depFailed := failed[d]
+				m.Unlock()
+				if depFailed {
+					fail(i, fmt.Errorf("dependency %s of %s failed to start", e.machineName(d), e.machineName(i)))
+					return
+				}
+				if err := e.waitMachineReady(ctx, d); err != nil {
+					fail(i, fmt.Errorf("dependency %s of %s is not ready: %w", e.machineName(d), e.machineName(i), err))
+					return
 				}
 			}
-			wg.Done()
-		}(m)
+			if machine.Info().State != virtual.Running {
+				if err := machine.Start(ctx); err != nil {
+					fail(i, err)
+				}
+			}
+		}(i, machine)
 	}
 	wg.Wait()
 
 	return res
 }
 
+// Returns the indices of the machines each machine depends on. Containers come before vms in the machines,
+// each in the order of their options
+func (e *Exercise) machineDependencies() [][]int {
+	var names []string
+	var dependsOn [][]string
+	for _, opt := range e.ContainerOpts {
+		names = append(names, opt.Name)
+		dependsOn = append(dependsOn, opt.DependsOn)
+	}
+	for _, opt := range e.VboxOpts {
+		names = append(names, opt.Name)
+		dependsOn = append(dependsOn, opt.DependsOn)
+	}
+
+	deps := make([][]int, len(e.Machines))
+	if len(names) != len(e.Machines) {
+		return deps
+	}
+	for i, depNames := range dependsOn {
+		for _, dep := range depNames {
+			for j, name := range names {
+				if name != "" && name == dep {
+					deps[i] = append(deps[i], j)
+				}
+			}
+		}
+	}
+	return deps
+}
+
+// Returns the name of the instance the machine was created from, or its image if it has no name
+func (e *Exercise) machineName(i int) string {
+	if i < len(e.ContainerOpts) && e.ContainerOpts[i].Name != "" {
+		return e.ContainerOpts[i].Name
+	}
+	if j := i - len(e.ContainerOpts); j >= 0 && j < len(e.VboxOpts) && e.VboxOpts[j].Name != "" {
+		return e.VboxOpts[j].Name
+	}
+	return e.Machines[i].Info().Image
+}
+
+// Returns the ip of the container on the lab network, empty for vms which get their ip from dhcp
+func (e *Exercise) machineIP(i int) string {
+	if i >= len(e.Ips) || e.Net == nil {
+		return ""
+	}
+	return e.Net.FormatIP(e.Ips[i])
+}
+
+// Waits until the machine is running, and its health check passes if it has one
+func (e *Exercise) waitMachineReady(ctx context.Context, i int) error {
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+
+	var err error
+	for {
+		m := e.Machines[i]
+		if m.Info().State != virtual.Running {
+			err = errors.New("machine is not running")
+		} else if c, ok := m.(*virtual.Container); ok {
+			err = c.Probe(ctx, e.Prober, e.machineIP(i))
+		} else {
+			err = nil
+		}
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %s: %w", e.machineName(i), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Waits until the exercise is running and all of its machines are ready. Used to start exercises which depend on it
+func (e *Exercise) WaitReady(ctx context.Context) error {
+	if status, reason, _ := e.GetStatus(); status != StatusRunning {
+		if reason != "" {
+			return fmt.Errorf("exercise %s is %s: %s", e.Tag, status, reason)
+		}
+		return fmt.Errorf("exercise %s is %s", e.Tag, status)
+	}
+	for i := range e.Machines {
+		if err := e.waitMachineReady(ctx, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Marks the exercise as failed without changing its machines, used when it cannot be started
+func (e *Exercise) SetFailed(err error) {
+	e.setStatus(StatusFailed, err)
+}
+
 // TODO: Add multierror or go routine
 func (e *Exercise) Stop(ctx context.Context) error {
 	for _, m := range e.Machines {
 		if err := m.Stop(); err != nil {
+			e.setStatus(StatusFailed, err)
 			return err
 		}
 	}
 
+	e.Suspended = false
+	e.setStatus(StatusStopped, nil)
 	return nil
 }
 
-func (e *Exercise) Reset(ctx context.Context) error {
-	if err := e.Close(); err != nil {
-		return err
+// Suspends running machines in the exercise. Suspended machines are resumed by Start
+func (e *Exercise) Suspend(ctx context.Context) error {
+	for _, m := range e.Machines {
+		if m.Info().State != virtual.Running {
+			continue
+		}
+		if err := m.Suspend(ctx); err != nil {
+			e.setStatus(StatusFailed, err)
+			return err
+		}
+		e.Suspended = true
 	}
 
-	if err := e.Create(ctx); err != nil {
+	e.setStatus(StatusStopped, nil)
+	return nil
+}
+
+// Recreates the machines of the exercise and starts them
+func (e *Exercise) Reset(ctx context.Context) error {
+	e.setStatus(StatusResetting, nil)
+	e.close()
+
+	if err := e.create(ctx); err != nil {
+		e.setStatus(StatusFailed, err)
 		return err
 	}
 
-	if err := e.Start(ctx); err != nil {
+	if err := e.start(ctx); err != nil {
+		e.setStatus(StatusFailed, err)
 		return err
 	}
+	e.setStatus(StatusRunning, nil)
 	return nil
 }
 
 func (e *Exercise) Close() error {
+	e.close()
+	e.setStatus(StatusStopped, nil)
+	return nil
+}
+
+func (e *Exercise) close() {
 	var wg sync.WaitGroup
 
 	for _, m := range e.Machines {
@@ -188,6 +444,43 @@ func (e *Exercise) Close() error {
 	wg.Wait()
 
 	e.Machines = nil
+}
+
+// Updates the memory and cpu of the running containers in the exercise. Zero values are left unchanged.
+// The new size is kept in the container options, so it survives a reset of the exercise
+func (e *Exercise) Resize(memoryMB uint, cpu float64) error {
+	for i := range e.ContainerOpts {
+		res := virtual.Resources{}
+		if e.ContainerOpts[i].DockerConf.Resources != nil {
+			res = *e.ContainerOpts[i].DockerConf.Resources
+		}
+		if memoryMB > 0 {
+			res.MemoryMB = memoryMB
+		}
+		if cpu > 0 {
+			res.CPU = cpu
+		}
+		e.ContainerOpts[i].DockerConf.Resources = &res
+
+		// Containers are created in the same order as the container options
+		if i >= len(e.Machines) {
+			continue
+		}
+		c, ok := e.Machines[i].(*virtual.Container)
+		if !ok {
+			continue
+		}
+		if memoryMB > 0 {
+			if err := c.SetRAM(memoryMB); err != nil {
+				return err
+			}
+		}
+		if cpu > 0 {
+			if err := c.SetCPU(cpu); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -198,6 +491,255 @@ func CreateContainer(ctx context.Context, conf virtual.ContainerConfig) (*virtua
 	return c, err
 }
 
+// Returns the docker images used by the exercise. Ova images and static exercises are skipped
+func (e ExerciseConfig) Images() []string {
+	if e.Static {
+		return nil
+	}
+	var images []string
+	for _, conf := range e.Instance {
+		if conf.Image == "" || strings.Contains(conf.Image, OvaSuffix) {
+			continue
+		}
+		images = append(images, conf.Image)
+	}
+	return images
+}
+
+// Returns an error if any of the docker images of the exercise are not allowed by the policy
+func (e ExerciseConfig) CheckImagePolicy(policy virtual.ImagePolicy) error {
+	for _, image := range e.Images() {
+		if err := policy.Check(image); err != nil {
+			return fmt.Errorf("exercise %s: %w", e.Tag, err)
+		}
+	}
+	return nil
+}
+
+// Returns an error if any of the health checks of the exercise are invalid
+func (e ExerciseConfig) CheckHealthChecks() error {
+	for _, conf := range e.Instance {
+		hc := conf.HealthCheck
+		if hc == nil {
+			continue
+		}
+		if strings.Contains(conf.Image, OvaSuffix) {
+			return fmt.Errorf("exercise %s: health checks are only supported for docker instances", e.Tag)
+		}
+		if err := hc.Validate(); err != nil {
+			return fmt.Errorf("exercise %s: %w", e.Tag, err)
+		}
+		switch hc.Action {
+		case "", HealthActionRestart, HealthActionReset:
+		default:
+			return fmt.Errorf("exercise %s: unknown health check action: %q", e.Tag, hc.Action)
+		}
+	}
+	return nil
+}
+
+// Returns an error if any of the container options of the exercise are invalid. Options of ova instances are ignored
+func (e ExerciseConfig) CheckContainerOptions() error {
+	for _, conf := range e.Instance {
+		if strings.Contains(conf.Image, OvaSuffix) {
+			continue
+		}
+		for _, c := range append(append([]string{}, conf.CapAdd...), conf.CapDrop...) {
+			if !capabilityRegex.MatchString(strings.TrimPrefix(strings.ToUpper(c), "CAP_")) {
+				return fmt.Errorf("exercise %s: invalid capability: %q", e.Tag, c)
+			}
+		}
+		for _, c := range conf.CapAdd {
+			// Equivalent to running the container privileged
+			if strings.EqualFold(c, "ALL") {
+				return fmt.Errorf("exercise %s: adding all capabilities is not allowed", e.Tag)
+			}
+		}
+		for key := range conf.Sysctls {
+			if key == "" {
+				return fmt.Errorf("exercise %s: sysctl without name", e.Tag)
+			}
+		}
+		for path := range conf.Tmpfs {
+			if !strings.HasPrefix(path, "/") {
+				return fmt.Errorf("exercise %s: tmpfs mount path must be absolute: %q", e.Tag, path)
+			}
+		}
+		for _, h := range conf.ExtraHosts {
+			host, ip, _ := strings.Cut(h, ":")
+			if host == "" || net.ParseIP(ip) == nil {
+				return fmt.Errorf("exercise %s: extra host must be hostname:ip: %q", e.Tag, h)
+			}
+		}
+	}
+	return nil
+}
+
+// Returns an error if the instances of the exercise depend on instances which do not exist, or depend on each other in a cycle
+func (e ExerciseConfig) CheckDependencies() error {
+	deps := make(map[string][]string)
+	for _, conf := range e.Instance {
+		if len(conf.DependsOn) > 0 && conf.Name == "" {
+			return fmt.Errorf("exercise %s: instances with dependencies must have a name", e.Tag)
+		}
+		if conf.Name == "" {
+			continue
+		}
+		if _, ok := deps[conf.Name]; ok {
+			return fmt.Errorf("exercise %s: instance name %s is used more than once", e.Tag, conf.Name)
+		}
+		deps[conf.Name] = conf.DependsOn
+	}
+	for name, dependsOn := range deps {
+		for _, dep := range dependsOn {
+			if _, ok := deps[dep]; !ok {
+				return fmt.Errorf("exercise %s: instance %s depends on unknown instance %s", e.Tag, name, dep)
+			}
+		}
+	}
+	if err := DependencyCycle(deps); err != nil {
+		return fmt.Errorf("exercise %s: %w", e.Tag, err)
+	}
+	return nil
+}
+
+// Returns an error naming a cycle in the dependencies, if there is one. Unknown dependencies are ignored
+func DependencyCycle(deps map[string][]string) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			for i, p := range path {
+				if p == name {
+					return fmt.Errorf("dependency cycle: %s -> %s", strings.Join(path[i:], " -> "), name)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range deps[name] {
+			if _, ok := deps[dep]; !ok {
+				continue
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	// Sorted so the same cycle is reported every time
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns true if any of the health checks of the exercise are run from a prober on the lab network
+func (e *Exercise) NeedsProber() bool {
+	for _, opt := range e.ContainerOpts {
+		if hc := opt.DockerConf.HealthCheck; hc != nil && hc.NeedsProber() {
+			return true
+		}
+	}
+	return false
+}
+
+// Health check of an exercise container, taken as a snapshot so it can be run without holding the lab lock
+type HealthCheck struct {
+	Container *virtual.Container
+	prober    *virtual.Container
+	ip        string
+}
+
+// Returns the health checks of the exercise containers, if the exercise is running.
+// Must be called while holding the lab lock, since the machines of the exercise are replaced by resets
+func (e *Exercise) HealthChecks() []HealthCheck {
+	if status, _, _ := e.GetStatus(); status != StatusRunning {
+		return nil
+	}
+
+	var checks []HealthCheck
+	// Containers are created in the same order as the container options and their ips
+	for i, m := range e.Machines {
+		c, ok := m.(*virtual.Container)
+		if !ok || c.Conf.HealthCheck == nil {
+			continue
+		}
+		checks = append(checks, HealthCheck{Container: c, prober: e.Prober, ip: e.machineIP(i)})
+	}
+	return checks
+}
+
+// Runs the health check if it is due, and returns the health of the container
+func (hc HealthCheck) Run(ctx context.Context) virtual.HealthStatus {
+	return hc.Container.CheckHealth(ctx, hc.prober, hc.ip)
+}
+
+// Restarts the unhealthy containers of the exercise, or resets the exercise if their health check asks for it.
+// Containers which are no longer machines of the exercise are ignored, since it has been reset in the meantime.
+// Must be called while holding the lab lock. Returns true if the exercise was reset, which replaces its machines
+func (e *Exercise) RecoverUnhealthy(ctx context.Context, unhealthy []*virtual.Container) bool {
+	if status, _, _ := e.GetStatus(); status != StatusRunning {
+		return false
+	}
+
+	for _, c := range unhealthy {
+		if !e.hasMachine(c) {
+			continue
+		}
+
+		_, reason := c.Health()
+		if c.Conf.HealthCheck.Action == HealthActionReset {
+			log.Warn().Str("labTag", e.LabTag).Str("exTag", e.Tag).Str("reason", reason).Msg("exercise container is unhealthy, resetting exercise")
+			if err := e.Reset(ctx); err != nil {
+				log.Error().Err(err).Str("labTag", e.LabTag).Str("exTag", e.Tag).Msg("error resetting unhealthy exercise")
+			}
+			return true
+		}
+
+		log.Warn().Str("labTag", e.LabTag).Str("exTag", e.Tag).Str("reason", reason).Msg("exercise container is unhealthy, restarting container")
+		if err := c.Stop(); err != nil {
+			log.Error().Err(err).Str("labTag", e.LabTag).Str("exTag", e.Tag).Msg("error stopping unhealthy container")
+			continue
+		}
+		if err := c.Start(ctx); err != nil {
+			log.Error().Err(err).Str("labTag", e.LabTag).Str("exTag", e.Tag).Msg("error starting unhealthy container")
+			e.setStatus(StatusFailed, err)
+			return false
+		}
+		c.ResetHealth()
+	}
+	return false
+}
+
+func (e *Exercise) hasMachine(instance virtual.Instance) bool {
+	for _, m := range e.Machines {
+		if m == instance {
+			return true
+		}
+	}
+	return false
+}
+
 func (e ExerciseConfig) CreateContainerOpts() []ContainerOptions {
 	var opts []ContainerOptions
 
@@ -241,7 +783,18 @@ func (e ExerciseConfig) CreateContainerOpts() []ContainerOptions {
 					MemoryMB: conf.MemoryMB,
 					CPU:      conf.CPU,
 				},
-				EnvVars: envVars,
+				EnvVars:        envVars,
+				HealthCheck:    conf.HealthCheck,
+				Cmd:            conf.Cmd,
+				Entrypoint:     conf.Entrypoint,
+				WorkingDir:     conf.WorkingDir,
+				User:           conf.User,
+				CapAdd:         conf.CapAdd,
+				CapDrop:        conf.CapDrop,
+				Sysctls:        conf.Sysctls,
+				Tmpfs:          conf.Tmpfs,
+				ReadOnlyRootfs: conf.ReadOnlyRootfs,
+				ExtraHosts:     conf.ExtraHosts,
 			}
 		}
 
@@ -249,6 +802,8 @@ func (e ExerciseConfig) CreateContainerOpts() []ContainerOptions {
 			DockerConf:     spec,
 			Records:        conf.Records,
 			ChildExercises: childExercises,
+			Name:           conf.Name,
+			DependsOn:      conf.DependsOn,
 		})
 	}
 
@@ -1,23 +1,62 @@
 package exercise
 
 import (
+	"sync"
+	"time"
+
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
 )
 
-//todo manage exercise status somehow
+type Status uint8
+
+const (
+	StatusCreating Status = iota
+	StatusStarting
+	StatusRunning
+	StatusStopped
+	StatusResetting
+	StatusFailed
+)
+
 type Exercise struct {
 	ContainerOpts []ContainerOptions
 	VboxOpts      []ExerciseInstanceConfig
 
-	Tag  string
-	Vlib *virtual.VboxLibrary
-	Net  *virtual.Network
+	Tag    string
+	LabTag string
+	Vlib   *virtual.VboxLibrary
+	Net    *virtual.Network
 
 	DnsAddr    string
 	DnsRecords []RecordConfig
 
 	Ips      []int
 	Machines []virtual.Instance
+
+	// Tags of exercises in the lab which must be ready before the exercise is started
+	DependsOn []string
+	// Runs tcp and http health checks, shared by the exercises of the lab
+	Prober *virtual.Container
+	// Set when Suspend paused running machines of the exercise, so resuming the lab only starts exercises it suspended
+	Suspended bool
+
+	// Maintained by Create, Start, Stop, Suspend, Reset and Close. Reason is only set when failed
+	Status        Status
+	StatusReason  string
+	StatusChanged time.Time
+	statusM       sync.Mutex
+
+	// Called whenever the status of the exercise changes, if set
+	OnStatusChange func(StatusEvent)
+}
+
+// Sent to OnStatusChange whenever the status of an exercise changes
+type StatusEvent struct {
+	LabTag string
+	Tag    string
+	Status Status
+	Reason string
+	Time   time.Time
 }
 
 type ExerciseConfig struct {
@@ -26,6 +65,8 @@ type ExerciseConfig struct {
 	// true: none , false: docker/vm
 	Static         bool                     `json:"static,omitempty"`
 	Instance       []ExerciseInstanceConfig `json:"instance,omitempty"`
+	// Tags of exercises in the same lab which must be ready before this exercise is started
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 type ExerciseInstanceConfig struct {
@@ -35,12 +76,36 @@ type ExerciseInstanceConfig struct {
 	Envs     []EnvVarConfig       `json:"envs,omitempty"`
 	Flags    []ChildrenChalConfig `json:"children,omitempty"`
 	Records  []RecordConfig       `json:"records,omitempty"`
+	// Only supported for docker instances
+	HealthCheck *virtual.HealthCheck `json:"healthcheck,omitempty"`
+	// Name other instances of the exercise refer to the instance by, and the names of the instances
+	// which must be ready before this instance is started
+	Name      string   `json:"name,omitempty"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Overrides of the command, entrypoint, working directory and user of the image
+	Cmd        []string `json:"cmd,omitempty"`
+	Entrypoint []string `json:"entrypoint,omitempty"`
+	WorkingDir string   `json:"workingDir,omitempty"`
+	User       string   `json:"user,omitempty"`
+	// Linux capabilities added to and dropped from the default set, ex. NET_ADMIN
+	CapAdd  []string `json:"capAdd,omitempty"`
+	CapDrop []string `json:"capDrop,omitempty"`
+	// Namespaced kernel parameters, ex. net.ipv4.ip_forward
+	Sysctls map[string]string `json:"sysctls,omitempty"`
+	// Tmpfs mounts by path, with mount options such as size=64m
+	Tmpfs          map[string]string `json:"tmpfs,omitempty"`
+	ReadOnlyRootfs bool              `json:"readOnlyRootfs,omitempty"`
+	// Extra /etc/hosts entries as hostname:ip
+	ExtraHosts []string `json:"extraHosts,omitempty"`
 }
 
 type ContainerOptions struct {
 	DockerConf     virtual.ContainerConfig
 	Records        []RecordConfig
 	ChildExercises []ChildExercise
+	Name           string
+	DependsOn      []string
 }
 
 type ChildrenChalConfig struct {
@@ -7,9 +7,11 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	wgproto "github.com/aau-network-security/gwireguard/proto" //v1.0.3
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/exercise"
@@ -51,6 +53,29 @@ func (lType LabType) String() string {
 
 // TODO Add comments to remaining functions
 
+// Returns the docker images needed to create a lab, including the dns and dhcp servers and docker frontends
+func (lc *LabConf) Images() []string {
+	images := []string{dns.Image, dhcp.Image}
+	for _, f := range lc.Frontends {
+		if f.Type == virtual.FrontendTypeDocker {
+			images = append(images, f.Image)
+		}
+	}
+	prober := false
+	for _, e := range lc.ExerciseConfs {
+		images = append(images, e.Images()...)
+		for _, conf := range e.Instance {
+			if conf.HealthCheck != nil && conf.HealthCheck.NeedsProber() {
+				prober = true
+			}
+		}
+	}
+	if prober {
+		images = append(images, virtual.ProberImage)
+	}
+	return images
+}
+
 // Creates and starts a new virtual lab
 func (lc *LabConf) NewLab(ctx context.Context, isVPN bool, labType LabType, eventTag string) (Lab, error) {
 	lab := Lab{
@@ -61,13 +86,22 @@ func (lc *LabConf) NewLab(ctx context.Context, isVPN bool, labType LabType, even
 		GuacUsername:    uuid.New().String()[0:8],
 		GuacPassword:    uuid.New().String()[0:8],
 		IsVPN:           isVPN,
+		IsHybrid:        lc.Hybrid,
+		ImagePolicy:     lc.ImagePolicy,
+		LastActivity:    time.Now(),
+
+		OnExerciseStatusChange: lc.OnExerciseStatusChange,
 	}
 
-	// Create lab network
-	if err := lab.CreateNetwork(ctx, isVPN); err != nil {
+	// Create lab network, hybrid labs always use a bridge network so VPN access can be enabled later
+	if err := lab.CreateNetwork(ctx, isVPN || lc.Hybrid); err != nil {
 		return Lab{}, fmt.Errorf("error creating network for lab: %v", err)
 	}
 
+	// Generate unique tag for lab
+	lab.Tag = generateTag(eventTag)
+	lab.Type = labType
+
 	// If labtype is beginner lab, ready all exercises from the start
 	if labType == TypeBeginner {
 		// Add exercises to new lab
@@ -78,12 +112,8 @@ func (lc *LabConf) NewLab(ctx context.Context, isVPN bool, labType LabType, even
 
 	lab.DockerHost = virtual.NewHost()
 
-	// Generate unique tag for lab
-	lab.Tag = generateTag(eventTag)
-	lab.Type = labType
-
-	// If not a VPN lab
-	if !isVPN {
+	// If not a VPN lab, or a hybrid lab which has both
+	if !isVPN || lc.Hybrid {
 		// Configure and add frontends to lab
 		lab.Frontends = map[uint]FrontendConf{}
 		for _, f := range lc.Frontends {
@@ -118,24 +148,16 @@ func (l *Lab) Start(ctx context.Context) error {
 	if _, err := l.Network.Connect(l.DhcpServer.Container(), 2); err != nil {
 		return err
 	}
-	var res error
-	var wg sync.WaitGroup
-	for _, ex := range l.Exercises {
-		wg.Add(1)
-		go func(e *exercise.Exercise) {
-			if err := e.Start(ctx); err != nil {
-				res = multierror.Append(res, err)
-			}
-			wg.Done()
-		}(ex)
+	var exercises []*exercise.Exercise
+	for _, e := range l.Exercises {
+		exercises = append(exercises, e)
 	}
-	wg.Wait()
-	if res != nil {
-		return res
+	if err := l.startExercises(ctx, exercises...); err != nil {
+		return err
 	}
 
 	for _, fconf := range l.Frontends {
-		if err := fconf.Vm.Start(ctx); err != nil {
+		if err := fconf.Instance().Start(ctx); err != nil {
 			return err
 		}
 	}
@@ -146,13 +168,13 @@ func (l *Lab) Close() error {
 	var wg sync.WaitGroup
 	for _, lab := range l.Frontends {
 		wg.Add(1)
-		go func(vm *virtual.Vm) {
+		go func(vm virtual.Instance) {
 			// closing VMs....
 			defer wg.Done()
 			if err := vm.Close(); err != nil {
 				log.Error().Msgf("Error on Close function in lab.go %s", err)
 			}
-		}(lab.Vm)
+		}(lab.Instance())
 	}
 	wg.Add(1)
 	go func() {
@@ -171,6 +193,10 @@ func (l *Lab) Close() error {
 			closers = append(closers, l.DnsServer)
 		}
 
+		if l.Prober != nil {
+			closers = append(closers, l.Prober)
+		}
+
 		for _, e := range l.Exercises {
 			closers = append(closers, e)
 		}
@@ -194,6 +220,199 @@ func (l *Lab) Close() error {
 	return nil
 }
 
+// Suspends the frontends and exercise machines in the lab, leaving DNS and DHCP running
+func (l *Lab) Suspend(ctx context.Context) error {
+	var res error
+	var m sync.Mutex
+	var wg sync.WaitGroup
+	for _, fconf := range l.Frontends {
+		wg.Add(1)
+		go func(vm virtual.Instance) {
+			defer wg.Done()
+			if vm.Info().State != virtual.Running {
+				return
+			}
+			if err := vm.Suspend(ctx); err != nil {
+				m.Lock()
+				res = multierror.Append(res, err)
+				m.Unlock()
+			}
+		}(fconf.Instance())
+	}
+	for _, ex := range l.Exercises {
+		wg.Add(1)
+		go func(e *exercise.Exercise) {
+			defer wg.Done()
+			if err := e.Suspend(ctx); err != nil {
+				m.Lock()
+				res = multierror.Append(res, err)
+				m.Unlock()
+			}
+		}(ex)
+	}
+	wg.Wait()
+	return res
+}
+
+// Resumes suspended frontends and the exercises stopped by Suspend
+func (l *Lab) Resume(ctx context.Context) error {
+	var res error
+	var m sync.Mutex
+	var wg sync.WaitGroup
+	for _, fconf := range l.Frontends {
+		wg.Add(1)
+		go func(vm virtual.Instance) {
+			defer wg.Done()
+			if vm.Info().State == virtual.Running {
+				return
+			}
+			// Starting a vm in saved state restores it
+			if err := vm.Start(ctx); err != nil {
+				m.Lock()
+				res = multierror.Append(res, err)
+				m.Unlock()
+			}
+		}(fconf.Instance())
+	}
+	for _, ex := range l.Exercises {
+		// Exercises which were stopped before the lab was suspended stay stopped
+		if !ex.Suspended {
+			continue
+		}
+		wg.Add(1)
+		go func(e *exercise.Exercise) {
+			defer wg.Done()
+			if err := e.Start(ctx); err != nil {
+				m.Lock()
+				res = multierror.Append(res, err)
+				m.Unlock()
+			}
+		}(ex)
+	}
+	wg.Wait()
+	return res
+}
+
+// Suspends the whole lab including the DNS and DHCP servers
+func (l *Lab) SuspendAll(ctx context.Context) error {
+	var res error
+	if err := l.Suspend(ctx); err != nil {
+		res = multierror.Append(res, err)
+	}
+	for _, c := range l.serviceContainers() {
+		if c.Info().State != virtual.Running {
+			continue
+		}
+		if err := c.Suspend(ctx); err != nil {
+			res = multierror.Append(res, err)
+		}
+	}
+	return res
+}
+
+// Resumes the DNS and DHCP servers before resuming the rest of the lab
+func (l *Lab) ResumeAll(ctx context.Context) error {
+	var res error
+	for _, c := range l.serviceContainers() {
+		if c.Info().State == virtual.Running {
+			continue
+		}
+		if err := c.Start(ctx); err != nil {
+			res = multierror.Append(res, err)
+		}
+	}
+	if err := l.Resume(ctx); err != nil {
+		res = multierror.Append(res, err)
+	}
+	return res
+}
+
+// Runs the health checks of the exercises in the lab, starting a prober on the lab network if an exercise needs one.
+// The lab lock is only held while taking a snapshot of the health checks and while recovering unhealthy exercises,
+// so it must not be held by the caller. Probes stop once probeCtx is done.
+// Returns true if the lab has changed and should be saved
+func (l *Lab) CheckExerciseHealth(ctx, probeCtx context.Context) bool {
+	l.M.Lock()
+	changed, err := l.ensureProber(ctx)
+	if err != nil {
+		log.Error().Err(err).Str("labTag", l.Tag).Msg("error starting health check prober")
+	}
+	checks := make(map[*exercise.Exercise][]exercise.HealthCheck)
+	for _, e := range l.Exercises {
+		if hcs := e.HealthChecks(); len(hcs) > 0 {
+			checks[e] = hcs
+		}
+	}
+	l.M.Unlock()
+
+	unhealthy := make(map[*exercise.Exercise][]*virtual.Container)
+	for e, hcs := range checks {
+		for _, hc := range hcs {
+			if probeCtx.Err() != nil {
+				break
+			}
+			if hc.Run(probeCtx) == virtual.HealthUnhealthy {
+				unhealthy[e] = append(unhealthy[e], hc.Container)
+			}
+		}
+	}
+	if len(unhealthy) == 0 {
+		return changed
+	}
+
+	l.M.Lock()
+	defer l.M.Unlock()
+	for e, containers := range unhealthy {
+		// The exercise may have been removed from the lab while it was probed
+		if l.Exercises[e.Tag] != e {
+			continue
+		}
+		if e.RecoverUnhealthy(ctx, containers) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Starts the prober on the lab network if an exercise needs one, and shares it with the exercises.
+// Returns true if the prober was started
+func (l *Lab) ensureProber(ctx context.Context) (bool, error) {
+	started := false
+	if l.Prober == nil {
+		for _, e := range l.Exercises {
+			if !e.NeedsProber() {
+				continue
+			}
+			prober, err := virtual.NewProber(ctx, l.Network)
+			if err != nil {
+				return false, err
+			}
+			l.Prober = prober
+			started = true
+			break
+		}
+	}
+
+	for _, e := range l.Exercises {
+		e.Prober = l.Prober
+	}
+	return started, nil
+}
+
+func (l *Lab) serviceContainers() []*virtual.Container {
+	var containers []*virtual.Container
+	if l.DnsServer != nil {
+		containers = append(containers, l.DnsServer.Container())
+	}
+	if l.DhcpServer != nil {
+		containers = append(containers, l.DhcpServer.Container())
+	}
+	if l.Prober != nil {
+		containers = append(containers, l.Prober)
+	}
+	return containers
+}
+
 func (l *Lab) RefreshDNS(ctx context.Context) error {
 	if l.DnsServer != nil {
 		if err := l.DnsServer.Close(); err != nil {
@@ -261,7 +480,7 @@ func (l *Lab) CreateNetwork(ctx context.Context, isVPN bool) error {
 	return nil
 }
 
-func (l *Lab) addFrontend(ctx context.Context, conf virtual.InstanceConfig, rdpPort uint) (*virtual.Vm, error) {
+func (l *Lab) addFrontend(ctx context.Context, conf virtual.InstanceConfig, rdpPort uint) (virtual.Instance, error) {
 	hostIp, err := l.DockerHost.GetDockerHostIP()
 	if err != nil {
 		return nil, err
@@ -277,11 +496,25 @@ func (l *Lab) addFrontend(ctx context.Context, conf virtual.InstanceConfig, rdpP
 	} else {
 		mem = conf.MemoryMB
 	}
+
+	if conf.Type == virtual.FrontendTypeDocker {
+		c, err := l.addContainerFrontend(ctx, conf, hostIp, rdpPort, mem)
+		if err != nil {
+			return nil, err
+		}
+		l.Frontends[rdpPort] = FrontendConf{
+			Container: c,
+			Conf:      conf,
+		}
+		log.Debug().Msgf("Created lab container frontend on port %d", rdpPort)
+		return c, nil
+	}
+
 	vm, err := l.Vlib.GetCopy(
 		ctx,
 		conf,
 		virtual.SetBridge(l.Network.Interface()),
-		virtual.SetLocalRDP(hostIp, rdpPort),
+		virtual.SetLocalDisplay(conf.Protocol, hostIp, rdpPort),
 		virtual.SetRAM(mem),
 	)
 	if err != nil {
@@ -298,24 +531,81 @@ func (l *Lab) addFrontend(ctx context.Context, conf virtual.InstanceConfig, rdpP
 	return vm, nil
 }
 
+// Creates a docker based frontend connected to the lab network.
+// The remote desktop port of the container is published on the docker host ip, the same way as virtualbox frontends expose RDP.
+func (l *Lab) addContainerFrontend(ctx context.Context, conf virtual.InstanceConfig, hostIp string, hostPort uint, mem uint) (*virtual.Container, error) {
+	guestPort := conf.Port
+	if guestPort == 0 {
+		guestPort = 3389
+		if conf.Protocol == virtual.ProtocolVNC {
+			guestPort = 5900
+		}
+	}
+	portSpec := fmt.Sprintf("%d/tcp", guestPort)
+
+	c := virtual.NewContainer(virtual.ContainerConfig{
+		Image: conf.Image,
+		PortBindings: map[string]string{
+			portSpec: fmt.Sprintf("%s:%d", hostIp, hostPort),
+		},
+		UsedPorts: []string{portSpec},
+		Resources: &virtual.Resources{
+			MemoryMB: mem,
+			CPU:      conf.CPU,
+		},
+		DNS: []string{l.DnsAddress},
+		Labels: map[string]string{
+			"hkn": "lab_frontend",
+		},
+		// The default bridge is needed for the published port
+		UseBridge: true,
+	})
+	if err := c.Create(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := l.Network.Connect(c); err != nil {
+		if err := c.Close(); err != nil {
+			log.Error().Err(err).Msg("error closing container frontend")
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
 func (l *Lab) ResetVm(ctx context.Context, port uint, envTag string) error {
 	frontendConf, ok := l.Frontends[port]
 	if !ok {
 		return errors.New("no vm running in lab on that port")
 	}
-	if err := frontendConf.Vm.Close(); err != nil {
-		return err
+	instance := frontendConf.Instance()
+	broken := instance.Info().State == virtual.Error
+	if err := instance.Close(); err != nil {
+		// Broken frontends may already be partly removed, and are replaced anyway
+		if !broken {
+			return err
+		}
+		log.Warn().Err(err).Str("labTag", l.Tag).Uint("port", port).Msg("error closing broken frontend")
 	}
 
 	vm, err := l.addFrontend(ctx, frontendConf.Conf, port)
 	if err != nil {
 		return err
 	}
+	// Keep the guacamole user and connection of the frontend
+	newConf := l.Frontends[port]
+	newConf.GuacUsername = frontendConf.GuacUsername
+	newConf.GuacPassword = frontendConf.GuacPassword
+	newConf.ConnectionID = frontendConf.ConnectionID
+	newConf.ConnectionNum = frontendConf.ConnectionNum
+	l.Frontends[port] = newConf
 
 	if err := vm.Start(ctx); err != nil {
 		return err
 	}
 
+	if newConf.Vm == nil {
+		return nil
+	}
 	err = virtual.CreateFolderLink(vm.Info().Id, envTag, l.GuacUsername)
 	if err != nil {
 		log.Logger.Debug().Msgf("Error creating shared folder link after vm reset: %s", err)
@@ -324,21 +614,193 @@ func (l *Lab) ResetVm(ctx context.Context, port uint, envTag string) error {
 	return nil
 }
 
-// Get a list of ports for the VMs running in the lab
+// Sets the lab to expire ttl from now. A ttl of zero means that the lab never expires
+func (l *Lab) SetTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		l.ExpiresAt = time.Time{}
+		return
+	}
+	l.ExpiresAt = time.Now().Add(ttl)
+}
+
+// Extends the lifetime of the lab. If the lab has already expired or has no expiry, the extension is counted from now
+func (l *Lab) Extend(d time.Duration) {
+	if l.ExpiresAt.IsZero() || l.ExpiresAt.Before(time.Now()) {
+		l.ExpiresAt = time.Now().Add(d)
+		return
+	}
+	l.ExpiresAt = l.ExpiresAt.Add(d)
+}
+
+func (l *Lab) IsExpired() bool {
+	return !l.ExpiresAt.IsZero() && time.Now().After(l.ExpiresAt)
+}
+
+// Returns the expiry as a unix timestamp to be sent to the daemon, 0 if the lab never expires
+func (l *Lab) ExpiresAtUnix() int64 {
+	if l.ExpiresAt.IsZero() {
+		return 0
+	}
+	return l.ExpiresAt.Unix()
+}
+
+// Get a sorted list of ports for the VMs running in the lab
 func (l *Lab) RdpConnPorts() []uint {
 	var ports []uint
 	for p := range l.Frontends {
 		ports = append(ports, p)
 	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
 
 	return ports
 }
 
+// Returns the guacamole usernames of the lab, including the users of individual team members
+func (l *Lab) GuacUsernames() []string {
+	usernames := []string{l.GuacUsername}
+	for _, port := range l.RdpConnPorts() {
+		if u := l.Frontends[port].GuacUsername; u != "" {
+			usernames = append(usernames, u)
+		}
+	}
+	return usernames
+}
+
+// Clones and starts a new frontend on a running lab, and returns the rdp port of the frontend
+func (l *Lab) AddFrontend(ctx context.Context, conf virtual.InstanceConfig) (uint, error) {
+	if !l.HasFrontends() {
+		return 0, errors.New("cannot add frontend to vpn lab")
+	}
+	if conf.Name != "" {
+		if _, err := l.GetFrontendPortByName(conf.Name); err == nil {
+			return 0, fmt.Errorf("frontend with name already exists: %s", conf.Name)
+		}
+	}
+
+	port := virtual.GetAvailablePort()
+	vm, err := l.addFrontend(ctx, conf, port)
+	if err != nil {
+		return 0, err
+	}
+	if err := vm.Start(ctx); err != nil {
+		// Broken frontends are not left in the lab
+		delete(l.Frontends, port)
+		if err := vm.Close(); err != nil {
+			log.Error().Err(err).Uint("port", port).Msg("error closing frontend which failed to start")
+		}
+		return 0, err
+	}
+	return port, nil
+}
+
+// Closes a frontend and removes it from the lab. Returns the removed frontend so its guacamole connection can be cleaned up
+func (l *Lab) RemoveFrontend(port uint) (FrontendConf, error) {
+	frontend, ok := l.Frontends[port]
+	if !ok {
+		return FrontendConf{}, errors.New("no vm running in lab on that port")
+	}
+	if len(l.Frontends) == 1 {
+		return FrontendConf{}, errors.New("cannot remove the last frontend of a lab")
+	}
+	if err := frontend.Instance().Close(); err != nil {
+		return FrontendConf{}, err
+	}
+	delete(l.Frontends, port)
+	return frontend, nil
+}
+
+// Changes memory and cpu of a frontend. Virtualbox frontends are powered off while resizing and started again afterwards,
+// container frontends are updated while running. Zero values are left unchanged.
+func (l *Lab) ResizeFrontend(ctx context.Context, port uint, memoryMB uint, cpu float64) error {
+	frontend, ok := l.Frontends[port]
+	if !ok {
+		return errors.New("no vm running in lab on that port")
+	}
+	if memoryMB > 0 && memoryMB < defaultImageMEMMB/2 {
+		return fmt.Errorf("memory cannot be less than %d MB", defaultImageMEMMB/2)
+	}
+
+	var resizer virtual.ResourceResizer = frontend.Container
+	wasRunning := false
+	if frontend.Container == nil {
+		resizer = frontend.Vm
+		switch frontend.Vm.Info().State {
+		case virtual.Running:
+			wasRunning = true
+			if err := frontend.Vm.Stop(); err != nil {
+				return err
+			}
+		case virtual.Suspended:
+			return errors.New("cannot resize suspended vm")
+		}
+	}
+
+	var resizeErr error
+	if memoryMB > 0 {
+		if resizeErr = resizer.SetRAM(memoryMB); resizeErr == nil {
+			frontend.Conf.MemoryMB = memoryMB
+		}
+	}
+	if cpu > 0 && resizeErr == nil {
+		if resizeErr = resizer.SetCPU(cpu); resizeErr == nil {
+			frontend.Conf.CPU = cpu
+		}
+	}
+	// Record the size which was applied, also if only part of the resize succeeded
+	l.Frontends[port] = frontend
+
+	// Start the vm again even if resizing failed, so the team is not left without a frontend
+	if wasRunning {
+		if err := frontend.Vm.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return resizeErr
+}
+
+// Returns the lowest connection number not used by any frontend in the lab. Frontends restored without a connection
+// number got theirs from their position among the sorted ports when the lab was created
+func (l *Lab) NextFrontendNum() int {
+	used := make(map[int]bool)
+	for i, port := range l.RdpConnPorts() {
+		num := l.Frontends[port].ConnectionNum
+		if num == 0 {
+			num = i + 1
+		}
+		used[num] = true
+	}
+	num := 1
+	for used[num] {
+		num++
+	}
+	return num
+}
+
+// Returns the port of a frontend by its name
+func (l *Lab) GetFrontendPortByName(name string) (uint, error) {
+	for port, f := range l.Frontends {
+		if f.Conf.Name != "" && f.Conf.Name == name {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no frontend with name: %s", name)
+}
+
+// Returns the port of the frontend owned by a team member
+func (l *Lab) GetFrontendPortByMember(username string) (uint, error) {
+	for port, f := range l.Frontends {
+		if f.GuacUsername != "" && f.GuacUsername == username {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no frontend for team member: %s", username)
+}
+
 // Get a list of instance information for the VMs and exercises running in the lab
 func (l *Lab) InstanceInfo() []virtual.InstanceInfo {
 	var instances []virtual.InstanceInfo
 	for _, fconf := range l.Frontends {
-		instances = append(instances, fconf.Vm.Info())
+		instances = append(instances, fconf.Instance().Info())
 	}
 	for _, e := range l.Exercises {
 		instances = append(instances, e.InstanceInfo()...)
@@ -528,3 +990,28 @@ func pop(alist *[]int) int {
 	*alist = append((*alist)[:f-1])
 	return rv
 }
+
+// Marks the lab as assigned to a team. Returns true if the lab was not assigned before
+func (l *Lab) MarkAssigned() bool {
+	l.M.Lock()
+	defer l.M.Unlock()
+
+	if l.Assigned {
+		return false
+	}
+	l.Assigned = true
+	return true
+}
+
+// Returns the virtual instance backing the frontend, either a virtualbox vm or a docker container
+func (f FrontendConf) Instance() virtual.Instance {
+	if f.Container != nil {
+		return f.Container
+	}
+	return f.Vm
+}
+
+// Returns true if the lab is reachable through guacamole frontends, which is the case for browser and hybrid labs
+func (l *Lab) HasFrontends() bool {
+	return !l.IsVPN || l.IsHybrid
+}
@@ -2,6 +2,7 @@ package lab
 
 import (
 	"sync"
+	"time"
 
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/exercise"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/network/dhcp"
@@ -28,6 +29,23 @@ type Lab struct {
 	GuacUsername      string
 	GuacPassword      string
 	VpnConfs          []string
+	ExpiresAt         time.Time
+	LastActivity      time.Time
+	IdleSuspended     bool
+	// Set when a team has logged in to the lab for the first time
+	Assigned bool
+	// Identifier of the team the lab is assigned to, empty if not assigned through AssignLab
+	TeamID string
+	// Hybrid labs have browser frontends and can have VPN access enabled at the same time
+	IsHybrid bool
+	// Images of exercises added to the lab must be allowed by the policy
+	ImagePolicy virtual.ImagePolicy
+	// Runs tcp and http health checks of exercises on the lab network, started when first needed
+	Prober *virtual.Container
+	// Set once the lab has been sent to the daemon, which may then hand it out to a team
+	Reported bool
+	// Called whenever the status of an exercise in the lab changes
+	OnExerciseStatusChange func(exercise.StatusEvent)
 }
 
 type LabConf struct {
@@ -35,6 +53,12 @@ type LabConf struct {
 	Frontends         []virtual.InstanceConfig
 	ExerciseConfs     []exercise.ExerciseConfig
 	DisabledExercises []string
+	// Labs get both browser frontends and a network which can be reached through VPN
+	Hybrid bool
+	// Restricts the images exercises of the environment may use
+	ImagePolicy virtual.ImagePolicy
+	// Called whenever the status of an exercise in a lab of the environment changes
+	OnExerciseStatusChange func(exercise.StatusEvent)
 }
 
 type DNSRecord struct {
@@ -42,6 +66,23 @@ type DNSRecord struct {
 }
 
 type FrontendConf struct {
-	Vm   *virtual.Vm
-	Conf virtual.InstanceConfig
+	Vm *virtual.Vm
+	// Set instead of Vm for docker based frontends
+	Container *virtual.Container
+	Conf      virtual.InstanceConfig
+	// Snapshots taken by the team, oldest first
+	Snapshots []Snapshot
+	// Guacamole user of the team member owning the frontend, only set for teams with more than one member
+	GuacUsername string
+	GuacPassword string
+	// Identifier of the guacamole connection to the frontend
+	ConnectionID string
+	// Number used in the guacamole connection and team member names of the frontend
+	ConnectionNum int
+}
+
+// Snapshot of a frontend taken by a team, which the frontend can be reverted to
+type Snapshot struct {
+	Name      string
+	CreatedAt time.Time
 }
@@ -14,6 +14,9 @@ import (
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
 )
 
+// Image used for the dhcp server of labs
+const Image = "networkboot/dhcpd:1.2.0"
+
 type Server struct {
 	Cont     *virtual.Container
 	ConfFile string
@@ -52,7 +55,7 @@ func New(format func(n int) string) (*Server, error) {
 		return nil, err
 	}
 	cont := virtual.NewContainer(virtual.ContainerConfig{
-		Image: "networkboot/dhcpd:1.2.0",
+		Image: Image,
 		Mounts: []string{
 			fmt.Sprintf("%s:/data/dhcpd.conf", confFile),
 		},
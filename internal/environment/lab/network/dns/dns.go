@@ -37,6 +37,9 @@ const (
 `
 )
 
+// Image used for the dns server of labs
+const Image = "coredns/coredns:1.6.1"
+
 type Server struct {
 	Cont      *virtual.Container
 	ConfFile  string
@@ -84,7 +87,7 @@ func New(records []RR) (*Server, error) {
 
 	f.Sync()
 	cont := virtual.NewContainer(virtual.ContainerConfig{
-		Image: "coredns/coredns:1.6.1",
+		Image: Image,
 		Mounts: []string{
 			fmt.Sprintf("%s:/Corefile", coreFile),
 			fmt.Sprintf("%s:/zonefile", confFile),
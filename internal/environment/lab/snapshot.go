@@ -0,0 +1,116 @@
+package lab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+)
+
+var ErrSnapshotLimit = errors.New("maximum amount of snapshots reached for lab")
+
+// Returns the amount of snapshots of all frontends in the lab
+func (l *Lab) SnapshotCount() int {
+	count := 0
+	for _, f := range l.Frontends {
+		count += len(f.Snapshots)
+	}
+	return count
+}
+
+// Takes a snapshot of a virtualbox frontend. If no name is given the snapshot is named by the time it was taken.
+// max is the maximum amount of snapshots allowed across all frontends in the lab.
+func (l *Lab) SnapshotFrontend(ctx context.Context, port uint, name string, max int) (Snapshot, error) {
+	frontend, err := l.snapshotFrontend(port)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if l.SnapshotCount() >= max {
+		return Snapshot{}, ErrSnapshotLimit
+	}
+
+	snapshot := Snapshot{
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if snapshot.Name == "" {
+		snapshot.Name = snapshot.CreatedAt.Format("20060102-150405")
+	}
+	if frontend.snapshotIndex(snapshot.Name) >= 0 {
+		return Snapshot{}, fmt.Errorf("snapshot with name already exists: %s", snapshot.Name)
+	}
+
+	if err := frontend.Vm.TakeSnapshot(ctx, snapshot.Name); err != nil {
+		return Snapshot{}, err
+	}
+
+	frontend.Snapshots = append(frontend.Snapshots, snapshot)
+	l.Frontends[port] = frontend
+	return snapshot, nil
+}
+
+// Reverts a virtualbox frontend to one of its snapshots. The vm is powered off while restoring and started again afterwards
+func (l *Lab) RevertFrontend(ctx context.Context, port uint, name string) error {
+	frontend, err := l.snapshotFrontend(port)
+	if err != nil {
+		return err
+	}
+	if frontend.snapshotIndex(name) < 0 {
+		return fmt.Errorf("could not find snapshot with name: %s", name)
+	}
+
+	if frontend.Vm.Info().State != virtual.Stopped {
+		if err := frontend.Vm.Stop(); err != nil {
+			return err
+		}
+	}
+
+	if err := frontend.Vm.RestoreSnapshot(ctx, name); err != nil {
+		return err
+	}
+
+	return frontend.Vm.Start(ctx)
+}
+
+// Deletes a snapshot of a virtualbox frontend
+func (l *Lab) DeleteFrontendSnapshot(ctx context.Context, port uint, name string) error {
+	frontend, err := l.snapshotFrontend(port)
+	if err != nil {
+		return err
+	}
+	i := frontend.snapshotIndex(name)
+	if i < 0 {
+		return fmt.Errorf("could not find snapshot with name: %s", name)
+	}
+
+	if err := frontend.Vm.DeleteSnapshot(ctx, name); err != nil {
+		return err
+	}
+
+	frontend.Snapshots = append(frontend.Snapshots[:i], frontend.Snapshots[i+1:]...)
+	l.Frontends[port] = frontend
+	return nil
+}
+
+// Returns the frontend on the port if it supports snapshots
+func (l *Lab) snapshotFrontend(port uint) (FrontendConf, error) {
+	frontend, ok := l.Frontends[port]
+	if !ok {
+		return FrontendConf{}, errors.New("no vm running in lab on that port")
+	}
+	if frontend.Vm == nil {
+		return FrontendConf{}, errors.New("snapshots are only supported for virtualbox frontends")
+	}
+	return frontend, nil
+}
+
+func (f FrontendConf) snapshotIndex(name string) int {
+	for i, s := range f.Snapshots {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -7,9 +7,9 @@ package virtual
 import (
 	"context"
 	"crypto/md5"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
 	"os"
@@ -29,10 +29,46 @@ import (
 
 // TODO comments and docs
 
+// Subset of the docker client used by the agent, which allows the client to be replaced when simulating
+type DockerClient interface {
+	CreateContainer(opts docker.CreateContainerOptions) (*docker.Container, error)
+	StartContainerWithContext(id string, hostConfig *docker.HostConfig, ctx context.Context) error
+	StopContainer(id string, timeout uint) error
+	PauseContainer(id string) error
+	UnpauseContainer(id string) error
+	RemoveContainer(opts docker.RemoveContainerOptions) error
+	InspectContainer(id string) (*docker.Container, error)
+	UpdateContainer(id string, opts docker.UpdateContainerOptions) error
+	ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error)
+
+	CreateNetwork(opts docker.CreateNetworkOptions) (*docker.Network, error)
+	RemoveNetwork(id string) error
+	NetworkInfo(id string) (*docker.Network, error)
+	ListNetworks() ([]docker.Network, error)
+	ConnectNetwork(id string, opts docker.NetworkConnectionOptions) error
+	DisconnectNetwork(id string, opts docker.NetworkConnectionOptions) error
+
+	InspectImage(name string) (*docker.Image, error)
+	PullImage(opts docker.PullImageOptions, auth docker.AuthConfiguration) error
+	TagImage(name string, opts docker.TagImageOptions) error
+	ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error)
+	RemoveImageExtended(name string, opts docker.RemoveImageOptions) error
+
+	CreateExec(opts docker.CreateExecOptions) (*docker.Exec, error)
+	StartExec(id string, opts docker.StartExecOptions) error
+	InspectExec(id string) (*docker.ExecInspect, error)
+}
+
 var (
-	DefaultClient     *docker.Client
+	DefaultClient     DockerClient
 	DefaultLinkBridge *defaultBridge
 
+	// Client used to look up digests of images in the registries
+	RegistryClient = http.DefaultClient
+
+	// Overrides the ip of the docker0 interface if set
+	dockerHostIP string
+
 	TooLowMemErr              = errors.New("memory needs to be atleast 50mb")
 	InvalidHostBindingErr     = errors.New("hostbing does not have correct format - (ip:)port")
 	InvalidMountErr           = errors.New("incorrect mount format - src:dest")
@@ -41,6 +77,7 @@ var (
 	EmptyDigestErr            = errors.New("empty digest")
 	DigestFormatErr           = errors.New("unexpected digest format")
 	NoRemoteDigestErr         = errors.New("unable to get digest from remote image")
+	OfflineErr                = errors.New("image is not available locally and cannot be pulled while offline")
 	NoAvailableIPsErr         = errors.New("no available IPs")
 	UnexpectedIPErr           = errors.New("unexpected IP range")
 	ContNotCreatedErr         = errors.New("container is not created")
@@ -59,18 +96,33 @@ const (
 )
 
 func init() {
-	var err error
-	DefaultClient, err = docker.NewClient("unix:///var/run/docker.sock")
-	if err != nil {
-		log.Fatal().Err(err).Msg("")
+	rand.Seed(time.Now().Unix())
+}
+
+// Sets up the docker client and the default bridge used for linking containers.
+// If client is nil, a client for the local docker daemon is created
+func InitDocker(client DockerClient) error {
+	if client == nil {
+		c, err := docker.NewClient("unix:///var/run/docker.sock")
+		if err != nil {
+			return err
+		}
+		client = c
 	}
+	DefaultClient = client
 
-	DefaultLinkBridge, err = newDefaultBridge("hkn-bridge")
+	bridge, err := newDefaultBridge("hkn-bridge")
 	if err != nil {
-		log.Fatal().Err(err).Msg("Error creating default bridge")
+		log.Error().Err(err).Msg("Error creating default bridge")
+		return err
 	}
+	DefaultLinkBridge = bridge
+	return nil
+}
 
-	rand.Seed(time.Now().Unix())
+// Sets the ip containers reach the host on instead of looking up the ip of the docker0 interface
+func SetDockerHostIP(ip string) {
+	dockerHostIP = ip
 }
 
 type NoLocalDigestErr struct {
@@ -132,6 +184,20 @@ type ContainerConfig struct {
 	DNS          []string
 	UsedPorts    []string
 	UseBridge    bool
+	// Only set for exercise containers with a health check
+	HealthCheck *HealthCheck
+
+	Entrypoint []string
+	WorkingDir string
+	// Linux capabilities added to and dropped from the default set
+	CapAdd  []string
+	CapDrop []string
+	Sysctls map[string]string
+	// Tmpfs mounts by path, with their mount options
+	Tmpfs          map[string]string
+	ReadOnlyRootfs bool
+	// Extra /etc/hosts entries as hostname:ip
+	ExtraHosts []string
 }
 
 type Resources struct {
@@ -143,9 +209,14 @@ type Image struct {
 	Registry string
 	Repo     string
 	Tag      string
+	// Set if the image is pinned to a digest, ex. sha256:<hex>, in which case the tag is ignored
+	Digest string
 }
 
 func (i Image) String() string {
+	if i.Digest != "" {
+		return i.NameWithReg() + "@" + i.Digest
+	}
 	if i.Registry == "" {
 		return i.Repo + ":" + i.Tag
 	}
@@ -170,6 +241,11 @@ type Container struct {
 	Conf    ContainerConfig
 	Network *docker.Network
 	Linked  []*Container
+	// Digest of the image the container was created from
+	Digest string
+
+	healthM sync.Mutex
+	health  health
 }
 
 func NewContainer(conf ContainerConfig) *Container {
@@ -178,7 +254,7 @@ func NewContainer(conf ContainerConfig) *Container {
 	}
 }
 
-func (c *Container) getCreateConfig() (*docker.CreateContainerOptions, error) {
+func (c *Container) getCreateConfig(ctx context.Context) (*docker.CreateContainerOptions, error) {
 	var env []string
 	for k, v := range c.Conf.EnvVars {
 		env = append(env, fmt.Sprintf("%s=%s", k, v))
@@ -244,9 +320,14 @@ func (c *Container) getCreateConfig() (*docker.CreateContainerOptions, error) {
 
 	var swap int64 = 0
 	hostConf := docker.HostConfig{
-		ExtraHosts:       []string{fmt.Sprintf("host:%s", hostIP)},
+		ExtraHosts:       append([]string{fmt.Sprintf("host:%s", hostIP)}, c.Conf.ExtraHosts...),
 		MemorySwap:       0,
 		MemorySwappiness: &swap,
+		CapAdd:           c.Conf.CapAdd,
+		CapDrop:          c.Conf.CapDrop,
+		Sysctls:          c.Conf.Sysctls,
+		Tmpfs:            c.Conf.Tmpfs,
+		ReadonlyRootfs:   c.Conf.ReadOnlyRootfs,
 	}
 
 	if c.Conf.Resources != nil {
@@ -286,15 +367,9 @@ func (c *Container) getCreateConfig() (*docker.CreateContainerOptions, error) {
 		ports[docker.Port(p)] = struct{}{}
 	}
 
-	img := parseImage(c.Conf.Image)
-	if err := verifyLocalImageVersion(img); err != nil {
-		// we can proceed on several errors
-		switch err.(type) {
-		case NoLocalImageAvailableErr, NoCredentialsErr:
-			return nil, err
-		default:
-			log.Warn().Msgf("failed to update local Docker image: %s", err)
-		}
+	// Only blocks if the image is still being pulled or has not been verified recently
+	if err := ensureImage(ctx, c.Conf.Image, nil); err != nil {
+		return nil, err
 	}
 	name := c.Conf.Name
 	if name == "" {
@@ -305,9 +380,11 @@ func (c *Container) getCreateConfig() (*docker.CreateContainerOptions, error) {
 		Name: name,
 		Config: &docker.Config{
 			User:         c.Conf.User,
-			Image:        c.Conf.Image,
+			Image:        localImageRef(c.Conf.Image),
 			Env:          env,
 			Cmd:          c.Conf.Cmd,
+			Entrypoint:   c.Conf.Entrypoint,
+			WorkingDir:   c.Conf.WorkingDir,
 			Labels:       c.Conf.Labels,
 			ExposedPorts: ports,
 		},
@@ -316,7 +393,7 @@ func (c *Container) getCreateConfig() (*docker.CreateContainerOptions, error) {
 }
 
 func (c *Container) Create(ctx context.Context) error {
-	dconf, err := c.getCreateConfig()
+	dconf, err := c.getCreateConfig(ctx)
 	if err != nil {
 		return err
 	}
@@ -342,6 +419,12 @@ func (c *Container) Create(ctx context.Context) error {
 
 	c.Id = cont.ID
 
+	digest, err := resolveDigest(dconf.Config.Image)
+	if err != nil {
+		log.Warn().Err(err).Str("Image", c.Conf.Image).Msg("failed to resolve digest of image")
+	}
+	c.Digest = digest
+
 	return nil
 }
 
@@ -421,11 +504,15 @@ func (c *Container) state() State {
 }
 
 func (c *Container) Info() InstanceInfo {
+	health, healthErr := c.Health()
 	return InstanceInfo{
-		Image: c.Conf.Image,
-		Type:  "docker",
-		Id:    c.Id[0:12],
-		State: c.state(),
+		Image:       c.Conf.Image,
+		Digest:      c.Digest,
+		Type:        "docker",
+		Id:          c.Id[0:12],
+		State:       c.state(),
+		Health:      health,
+		HealthError: healthErr,
 	}
 }
 
@@ -464,6 +551,47 @@ func (c *Container) Close() error {
 	return nil
 }
 
+// Updates the memory limit of the container while it is running
+func (c *Container) SetRAM(mb uint) error {
+	if mb < 50 {
+		return TooLowMemErr
+	}
+	// Swap is kept at twice the memory which is the docker default when creating the container
+	if err := DefaultClient.UpdateContainer(c.Id, docker.UpdateContainerOptions{
+		Memory:     int(mb) * 1024 * 1024,
+		MemorySwap: int(mb) * 2 * 1024 * 1024,
+	}); err != nil {
+		return err
+	}
+
+	// Resources may be shared with the exercise config, so a copy is updated
+	res := Resources{}
+	if c.Conf.Resources != nil {
+		res = *c.Conf.Resources
+	}
+	res.MemoryMB = mb
+	c.Conf.Resources = &res
+	return nil
+}
+
+// Updates the cpu quota of the container while it is running
+func (c *Container) SetCPU(cpu float64) error {
+	if err := DefaultClient.UpdateContainer(c.Id, docker.UpdateContainerOptions{
+		CPUPeriod: 100000,
+		CPUQuota:  int(100000 * cpu),
+	}); err != nil {
+		return err
+	}
+
+	res := Resources{}
+	if c.Conf.Resources != nil {
+		res = *c.Conf.Resources
+	}
+	res.CPU = cpu
+	c.Conf.Resources = &res
+	return nil
+}
+
 func (c *Container) BridgeAlias(alias string) (string, error) {
 	return DefaultLinkBridge.connect(c.Id, alias)
 }
@@ -836,15 +964,21 @@ func parseImage(img string) Image {
 	tag := "latest"
 	repo := img
 	registry := ""
+	digest := ""
 
-	parts := strings.Split(img, ":")
-	if len(parts) == 2 {
-		repo, tag = parts[0], parts[1]
+	// format: repo(:tag)@digest
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo, digest = repo[:i], repo[i+1:]
+	}
+
+	// The tag follows the last colon, unless the colon is the port of the registry
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:i], repo[i+1:]
 	}
 
 	// format: reg/owner/repo
 	if strings.Count(repo, "/") > 1 {
-		parts = strings.Split(repo, "/")
+		parts := strings.Split(repo, "/")
 
 		registry = parts[0]
 		repo = strings.Join(parts[1:], "/")
@@ -854,10 +988,15 @@ func parseImage(img string) Image {
 		Registry: registry,
 		Repo:     repo,
 		Tag:      tag,
+		Digest:   digest,
 	}
 }
 
 func getDockerHostIP() (string, error) {
+	if dockerHostIP != "" {
+		return dockerHostIP, nil
+	}
+
 	i, err := net.InterfaceByName("docker0")
 	if err != nil {
 		return "", err
@@ -885,148 +1024,96 @@ func getDockerHostIP() (string, error) {
 	return "", nil
 }
 
-func getRemoteDigestForImage(auth docker.AuthConfiguration, img Image) (string, error) {
-	lookupDigest := func(req *http.Request) (string, error) {
-		ctx, cancel := context.WithTimeout(req.Context(), 3*time.Second)
-		defer cancel()
-		req = req.WithContext(ctx)
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return "", err
-		}
-
-		hash := resp.Header.Get("Docker-Content-Digest")
-		if hash == "" {
-			return "", EmptyDigestErr
-		}
+// Pulls the image from src, which is either the image itself or the image in a mirror, in which case the pulled
+// image is tagged with the original name. The json progress messages of the pull are written to progress if it is not nil
+func retrieveImage(auth docker.AuthConfiguration, img Image, src Image, progress io.Writer) error {
+	log.Debug().
+		Str("image", img.String()).
+		Str("source", src.String()).
+		Msg("Attempting to pull image")
 
-		return hash, nil
+	// Docker pulls by digest if it is given as the tag
+	tag := src.Tag
+	if src.Digest != "" {
+		tag = src.Digest
 	}
-
-	digestRequestFromURL := func(URL string) (*http.Request, error) {
-		req, err := http.NewRequest("HEAD", URL, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.v2+json")
-		return req, nil
+	opts := docker.PullImageOptions{
+		Repository: src.NameWithReg(),
+		Tag:        tag,
+	}
+	if progress != nil {
+		opts.OutputStream = progress
+		opts.RawJSONStream = true
+	}
+	if err := DefaultClient.PullImage(opts, auth); err != nil {
+		return err
 	}
-	// digesFromGitlab function is specific to Gitlab Container Registry
-	// it means that if registry will be changed the source code also requires minor
-	// changes
-	digestFromGitlab := func(img Image) (string, error) {
-		REGISTRY_URL := "https://registry.gitlab.com/v2"
-		AUTH_URL := fmt.Sprintf("https://gitlab.com/jwt/auth?client_id=docker&offline_token=true&service=container_registry&scope=repository:%s:pull,list", img.Repo)
-
-		req, err := http.NewRequest("GET", AUTH_URL, nil)
-		if err != nil {
-			log.Warn().Msgf("Failed to perform GET request from gitlab ")
-		}
-		req.SetBasicAuth(auth.Username, auth.Password)
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			log.Error().Msgf("Error %v", err)
-		}
 
-		defer resp.Body.Close()
+	if src != img && img.Digest == "" {
+		return DefaultClient.TagImage(src.String(), docker.TagImageOptions{
+			Repo:  img.NameWithReg(),
+			Tag:   img.Tag,
+			Force: true,
+		})
+	}
+	return nil
+}
 
-		var msg struct {
-			Token string `json:"token"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
-			return "", err
-		}
-		req, err = digestRequestFromURL(fmt.Sprintf("%s/%s/manifests/%s", REGISTRY_URL, img.Repo, img.Tag))
-		if err != nil {
-			return "", err
-		}
-		req.Header.Set("Authorization", "Bearer "+msg.Token)
+// Makes sure the local image is the newest version, pulling it if it is missing or outdated.
+// In offline mode local images are trusted, and missing images can only be pulled from mirrors.
+// Images pinned to a digest cannot change, so they are never compared with the registry
+func verifyLocalImageVersion(img Image, progress io.Writer) error {
+	src, creds, srcErr := pullSource(img)
+	mirrored := src != img
 
-		return lookupDigest(req)
+	local := img.String()
+	if img.Digest != "" {
+		local = src.String()
 	}
-
-	if img.Registry == "" {
-		path := fmt.Sprintf("/v2/%s/manifests/%s", img.Repo, img.Tag)
-		resp, err := http.Get(fmt.Sprintf("https://auth.docker.io/token?service=registry.docker.io&scope=repository:%s:pull", img.Repo))
-		if err != nil {
-			return "", err
+	localImg, err := DefaultClient.InspectImage(local)
+	if err != nil {
+		if err != docker.ErrNoSuchImage {
+			return err
 		}
-		defer resp.Body.Close()
-
-		var msg struct {
-			Token string `json:"token"`
+		if srcErr != nil {
+			return srcErr
 		}
-		if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
-			return "", err
+		if registryConf.Offline && !mirrored {
+			return NoLocalImageAvailableErr{OfflineErr}
 		}
-
-		req, err := digestRequestFromURL("https://registry.hub.docker.com" + path)
-		if err != nil {
-			return "", err
+		if err := retrieveImage(creds, img, src, progress); err != nil {
+			return NoLocalImageAvailableErr{err}
 		}
-		req.Header.Set("Authorization", "Bearer "+msg.Token)
-
-		return lookupDigest(req)
-	}
-
-	return digestFromGitlab(img)
-}
-
-func retrieveImage(auth docker.AuthConfiguration, img Image) error {
-	log.Debug().
-		Str("image", img.String()).
-		Msg("Attempting to pull image")
-
-	if err := DefaultClient.PullImage(docker.PullImageOptions{
-		Repository: img.NameWithReg(),
-		Tag:        img.Tag,
-	}, auth); err != nil {
-		return err
+		return nil
 	}
 
-	return nil
-}
-
-func verifyLocalImageVersion(img Image) error {
-	creds, ok := Registries[img.Registry]
-	if !ok {
-		return NoCredentialsErr{img.Registry}
+	if registryConf.Offline || img.Digest != "" {
+		return nil
 	}
-
-	localImg, err := DefaultClient.InspectImage(img.String())
-	if err != nil {
-		if err == docker.ErrNoSuchImage {
-			if err := retrieveImage(creds, img); err != nil {
-				return NoLocalImageAvailableErr{err}
-			}
-			return nil
-		}
-		return err
+	if srcErr != nil {
+		return srcErr
 	}
 
 	if len(localImg.RepoDigests) == 0 {
 		return NoLocalDigestErr{img}
 	}
 
-	localDigest := localImg.RepoDigests[0]
-	if strings.Contains(localDigest, "@") {
-		localDigest = strings.Split(localDigest, "@")[1]
-	}
-
-	remoteDigest, err := getRemoteDigestForImage(creds, img)
+	remoteDigest, err := getRemoteDigestForImage(creds, src)
 	if err != nil {
 		return err
 	}
 
-	if remoteDigest != localDigest {
-		if err := retrieveImage(creds, img); err != nil {
-			return err
+	// The image may have been pulled from both a mirror and its registry, which both give the same digest
+	for _, repoDigest := range localImg.RepoDigests {
+		if i := strings.Index(repoDigest, "@"); i >= 0 {
+			repoDigest = repoDigest[i+1:]
+		}
+		if repoDigest == remoteDigest {
+			return nil
 		}
 	}
 
-	return nil
+	return retrieveImage(creds, img, src, progress)
 }
 
 // Gets the count of all containers running
@@ -0,0 +1,210 @@
+package virtual
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/rs/zerolog/log"
+	"github.com/shirou/gopsutil/disk"
+)
+
+// Reasons for pruning an image
+const (
+	PruneReasonAge  = "age"
+	PruneReasonDisk = "disk-usage"
+)
+
+type PruneOptions struct {
+	// Images which are referenced and must be kept, by name
+	Referenced []string
+	// Unreferenced images are removed once they have not been referenced for this long, never if zero
+	MaxAge time.Duration
+	// Unreferenced images are removed regardless of age, oldest first, while the usage of the disk
+	// at DiskPath is above DiskThreshold percent. Disabled if the threshold is zero
+	DiskPath      string
+	DiskThreshold float64
+	// Only reports which images would be removed
+	DryRun bool
+}
+
+type PrunedImage struct {
+	Id     string
+	Tags   []string
+	Size   int64
+	Reason string
+}
+
+var (
+	lastReferencedM sync.Mutex
+	// When images were last pulled, used or referenced by an environment, by image id
+	lastReferenced = map[string]time.Time{}
+	// Images which have not been seen since the agent started may have been used right before, so their age starts here
+	startedAt = time.Now()
+)
+
+// Records that the image has been pulled or used, so it is not pruned before it has been unused for the max age
+func markImageUsed(image string) {
+	img, err := DefaultClient.InspectImage(localImageRef(image))
+	if err != nil {
+		return
+	}
+	lastReferencedM.Lock()
+	defer lastReferencedM.Unlock()
+	lastReferenced[img.ID] = time.Now()
+}
+
+// Returns when images were last pulled, used or referenced, by image id, in order to save it in the state
+func ImageUsage() map[string]time.Time {
+	lastReferencedM.Lock()
+	defer lastReferencedM.Unlock()
+	usage := make(map[string]time.Time, len(lastReferenced))
+	for id, t := range lastReferenced {
+		usage[id] = t
+	}
+	return usage
+}
+
+// Restores the image usage saved in the state, keeping the newest time for images already recorded
+func RestoreImageUsage(usage map[string]time.Time) {
+	lastReferencedM.Lock()
+	defer lastReferencedM.Unlock()
+	for id, t := range usage {
+		if t.After(lastReferenced[id]) {
+			lastReferenced[id] = t
+		}
+	}
+}
+
+// Removes docker images which are not referenced and not used by any container, based on their age and the disk usage.
+// The age of an image is the time since it was last pulled, used or referenced. Images which have not been seen
+// since the agent started are aged from the start of the agent, or from their creation if they were created later
+func PruneImages(opts PruneOptions) ([]PrunedImage, error) {
+	images, err := DefaultClient.ListImages(docker.ListImagesOptions{All: false})
+	if err != nil {
+		return nil, err
+	}
+	containers, err := DefaultClient.ListContainers(docker.ListContainersOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool)
+	for _, c := range containers {
+		// The image of a container is listed by the name it was created with
+		img, err := DefaultClient.InspectImage(c.Image)
+		if err != nil {
+			continue
+		}
+		keep[img.ID] = true
+	}
+
+	// Referenced images are matched by their tags as well, so they are kept even if they cannot be inspected by name
+	referenced := make(map[string]bool)
+	for _, ref := range opts.Referenced {
+		referenced[parseImage(ref).String()] = true
+		referenced[localImageRef(ref)] = true
+	}
+	for _, img := range images {
+		for _, name := range append(img.RepoTags, img.RepoDigests...) {
+			if referenced[name] {
+				keep[img.ID] = true
+			}
+		}
+	}
+
+	now := time.Now()
+	lastReferencedM.Lock()
+	for _, ref := range opts.Referenced {
+		img, err := DefaultClient.InspectImage(localImageRef(ref))
+		if err != nil {
+			// Images which have not been pulled yet cannot be removed anyway
+			continue
+		}
+		keep[img.ID] = true
+	}
+	for id := range keep {
+		lastReferenced[id] = now
+	}
+
+	type candidate struct {
+		img     docker.APIImages
+		lastUse time.Time
+	}
+	var candidates []candidate
+	for _, img := range images {
+		if keep[img.ID] {
+			continue
+		}
+		lastUse := time.Unix(img.Created, 0)
+		if lastUse.Before(startedAt) {
+			lastUse = startedAt
+		}
+		if t, ok := lastReferenced[img.ID]; ok && t.After(lastUse) {
+			lastUse = t
+		}
+		candidates = append(candidates, candidate{img: img, lastUse: lastUse})
+	}
+	lastReferencedM.Unlock()
+
+	// Oldest first, so the disk threshold removes the images which have been unused for the longest
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastUse.Before(candidates[j].lastUse)
+	})
+
+	// Usage is estimated from the sizes of the removed images, so dry runs give the same result
+	var used, total uint64
+	if opts.DiskThreshold > 0 {
+		usage, err := disk.Usage(opts.DiskPath)
+		if err != nil {
+			log.Warn().Err(err).Str("path", opts.DiskPath).Msg("failed to get disk usage, only pruning images by age")
+		} else {
+			used, total = usage.Used, usage.Total
+		}
+	}
+	overThreshold := func(reclaimed int64) bool {
+		if total == 0 || uint64(reclaimed) >= used {
+			return false
+		}
+		return float64(used-uint64(reclaimed))/float64(total)*100 > opts.DiskThreshold
+	}
+
+	var pruned []PrunedImage
+	var reclaimed int64
+	for _, c := range candidates {
+		reason := ""
+		switch {
+		case opts.MaxAge > 0 && now.Sub(c.lastUse) > opts.MaxAge:
+			reason = PruneReasonAge
+		case overThreshold(reclaimed):
+			reason = PruneReasonDisk
+		default:
+			continue
+		}
+
+		if !opts.DryRun {
+			if err := DefaultClient.RemoveImageExtended(c.img.ID, docker.RemoveImageOptions{Force: true}); err != nil {
+				log.Warn().Err(err).Str("id", c.img.ID).Strs("tags", c.img.RepoTags).Msg("failed to remove image")
+				continue
+			}
+		}
+		pruned = append(pruned, PrunedImage{
+			Id:     c.img.ID,
+			Tags:   c.img.RepoTags,
+			Size:   c.img.Size,
+			Reason: reason,
+		})
+		reclaimed += c.img.Size
+	}
+
+	if len(pruned) > 0 && !opts.DryRun {
+		lastReferencedM.Lock()
+		for _, p := range pruned {
+			delete(lastReferenced, p.Id)
+		}
+		lastReferencedM.Unlock()
+		forgetPulls()
+	}
+	return pruned, nil
+}
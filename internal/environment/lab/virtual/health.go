@@ -0,0 +1,317 @@
+package virtual
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/rs/zerolog/log"
+)
+
+// Image of the containers running tcp and http health checks on the lab networks
+const ProberImage = "busybox:1.36"
+
+// Types of health checks
+const (
+	HealthCheckTCP  = "tcp"
+	HealthCheckHTTP = "http"
+	HealthCheckExec = "exec"
+)
+
+const (
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 5 * time.Second
+	defaultHealthRetries  = 3
+)
+
+type HealthStatus uint8
+
+const (
+	HealthNone HealthStatus = iota
+	HealthStarting
+	HealthHealthy
+	HealthUnhealthy
+)
+
+var httpStatusRegex = regexp.MustCompile(`HTTP/[0-9.]+ ([0-9]{3})`)
+
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthStarting:
+		return "starting"
+	case HealthHealthy:
+		return "healthy"
+	case HealthUnhealthy:
+		return "unhealthy"
+	}
+	return "none"
+}
+
+// Checks if the service in a container is working
+type HealthCheck struct {
+	// tcp, http or exec
+	Type string `json:"type,omitempty"`
+	// Port of the container connected to by tcp and http checks
+	Port uint `json:"port,omitempty"`
+	// Path requested by http checks, and the status the response must have, 200 if not set
+	Path           string `json:"path,omitempty"`
+	ExpectedStatus int    `json:"expectedStatus,omitempty"`
+	// Command run inside the container by exec checks, which is healthy if the command exits with 0
+	Command []string `json:"command,omitempty"`
+
+	IntervalSeconds uint `json:"interval,omitempty"`
+	TimeoutSeconds  uint `json:"timeout,omitempty"`
+	// Failures are not counted until the container has been running this long
+	StartPeriodSeconds uint `json:"startPeriod,omitempty"`
+	// Consecutive failures before the container is unhealthy, 3 if not set
+	Retries uint `json:"retries,omitempty"`
+	// What to do when the container is unhealthy, restart the container or reset the exercise
+	Action string `json:"action,omitempty"`
+}
+
+// Returns an error if the health check is missing settings needed by its type
+func (hc HealthCheck) Validate() error {
+	switch hc.Type {
+	case HealthCheckTCP, HealthCheckHTTP:
+		if hc.Port == 0 || hc.Port > 65535 {
+			return fmt.Errorf("%s health check needs a valid port", hc.Type)
+		}
+	case HealthCheckExec:
+		if len(hc.Command) == 0 {
+			return errors.New("exec health check needs a command")
+		}
+	default:
+		return fmt.Errorf("unknown health check type: %q", hc.Type)
+	}
+	return nil
+}
+
+// Returns true if the check is run from a prober on the lab network instead of inside the container
+func (hc HealthCheck) NeedsProber() bool {
+	return hc.Type == HealthCheckTCP || hc.Type == HealthCheckHTTP
+}
+
+func (hc HealthCheck) interval() time.Duration {
+	if hc.IntervalSeconds == 0 {
+		return defaultHealthInterval
+	}
+	return time.Duration(hc.IntervalSeconds) * time.Second
+}
+
+func (hc HealthCheck) timeout() time.Duration {
+	if hc.TimeoutSeconds == 0 {
+		return defaultHealthTimeout
+	}
+	return time.Duration(hc.TimeoutSeconds) * time.Second
+}
+
+func (hc HealthCheck) retries() uint {
+	if hc.Retries == 0 {
+		return defaultHealthRetries
+	}
+	return hc.Retries
+}
+
+// Result of the latest health checks of a container
+type health struct {
+	status    HealthStatus
+	failures  uint
+	lastErr   string
+	lastCheck time.Time
+}
+
+// Creates and starts a container on the network which tcp and http health checks are run from
+func NewProber(ctx context.Context, n *Network) (*Container, error) {
+	c := NewContainer(ContainerConfig{
+		Image: ProberImage,
+		Cmd:   []string{"tail", "-f", "/dev/null"},
+		Resources: &Resources{
+			MemoryMB: 50,
+			CPU:      0.1,
+		},
+		Labels: map[string]string{
+			"hkn": "lab_prober",
+		},
+	})
+	if err := c.Run(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := n.Connect(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Returns the health of the container, and the error of the latest failed check if it is not healthy
+func (c *Container) Health() (HealthStatus, string) {
+	if c.Conf.HealthCheck == nil {
+		return HealthNone, ""
+	}
+	c.healthM.Lock()
+	defer c.healthM.Unlock()
+	if c.health.status == HealthNone {
+		return HealthStarting, ""
+	}
+	return c.health.status, c.health.lastErr
+}
+
+// Forgets previous health check results, used when the container has been restarted
+func (c *Container) ResetHealth() {
+	c.healthM.Lock()
+	defer c.healthM.Unlock()
+	c.health = health{status: HealthStarting, lastCheck: time.Now()}
+}
+
+// Runs the health check of the container if it is due, tcp and http checks are run from the prober against ip.
+// Returns the health of the container, which is unhealthy once the check has failed more times in a row than its retries
+func (c *Container) CheckHealth(ctx context.Context, prober *Container, ip string) HealthStatus {
+	hc := c.Conf.HealthCheck
+	if hc == nil {
+		return HealthNone
+	}
+
+	c.healthM.Lock()
+	due := time.Since(c.health.lastCheck) >= hc.interval()
+	c.healthM.Unlock()
+	if !due {
+		status, _ := c.Health()
+		return status
+	}
+
+	cont, err := DefaultClient.InspectContainer(c.Id)
+	if err != nil || !cont.State.Running || cont.State.Paused {
+		// Stopped and suspended containers are not checked
+		status, _ := c.Health()
+		return status
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout())
+	defer cancel()
+	err = c.probe(ctx, prober, ip)
+
+	c.healthM.Lock()
+	defer c.healthM.Unlock()
+	c.health.lastCheck = time.Now()
+	if err == nil {
+		c.health.status, c.health.failures, c.health.lastErr = HealthHealthy, 0, ""
+		return c.health.status
+	}
+
+	c.health.lastErr = err.Error()
+	if time.Since(cont.State.StartedAt) < time.Duration(hc.StartPeriodSeconds)*time.Second {
+		c.health.status = HealthStarting
+		return c.health.status
+	}
+	c.health.failures++
+	log.Debug().Err(err).Str("ID", c.Id[0:8]).Uint("failures", c.health.failures).Msg("health check failed")
+	if c.health.failures >= hc.retries() {
+		c.health.status = HealthUnhealthy
+	} else if c.health.status == HealthNone {
+		c.health.status = HealthStarting
+	}
+	return c.health.status
+}
+
+// Runs the health check of the container once regardless of its interval, used when waiting for the container to be ready.
+// A passing check makes the container healthy, while failures are left to CheckHealth
+func (c *Container) Probe(ctx context.Context, prober *Container, ip string) error {
+	hc := c.Conf.HealthCheck
+	if hc == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hc.timeout())
+	defer cancel()
+	if err := c.probe(ctx, prober, ip); err != nil {
+		return err
+	}
+
+	c.healthM.Lock()
+	defer c.healthM.Unlock()
+	c.health = health{status: HealthHealthy, lastCheck: time.Now()}
+	return nil
+}
+
+func (c *Container) probe(ctx context.Context, prober *Container, ip string) error {
+	hc := c.Conf.HealthCheck
+	if hc.Type == HealthCheckExec {
+		exitCode, output, err := c.exec(ctx, hc.Command)
+		if err != nil {
+			return err
+		}
+		if exitCode != 0 {
+			return fmt.Errorf("health check command exited with %d: %s", exitCode, strings.TrimSpace(output))
+		}
+		return nil
+	}
+
+	if prober == nil {
+		return errors.New("no prober to run health check from")
+	}
+	timeout := strconv.Itoa(int(hc.timeout().Seconds()))
+	switch hc.Type {
+	case HealthCheckTCP:
+		exitCode, _, err := prober.exec(ctx, []string{"nc", "-z", "-w", timeout, ip, strconv.Itoa(int(hc.Port))})
+		if err != nil {
+			return err
+		}
+		if exitCode != 0 {
+			return fmt.Errorf("port %d is not open", hc.Port)
+		}
+	case HealthCheckHTTP:
+		url := fmt.Sprintf("http://%s:%d/%s", ip, hc.Port, strings.TrimPrefix(hc.Path, "/"))
+		// Wget exits with an error for non 2xx responses, so the status is read from the response headers instead
+		_, output, err := prober.exec(ctx, []string{"wget", "-q", "-S", "-O", "/dev/null", "-T", timeout, url})
+		if err != nil {
+			return err
+		}
+		m := httpStatusRegex.FindAllStringSubmatch(output, -1)
+		if len(m) == 0 {
+			return fmt.Errorf("no response from %s", url)
+		}
+		expected := hc.ExpectedStatus
+		if expected == 0 {
+			expected = 200
+		}
+		// The last response is the one after redirects
+		if status, _ := strconv.Atoi(m[len(m)-1][1]); status != expected {
+			return fmt.Errorf("unexpected status from %s: %d", url, status)
+		}
+	}
+	return nil
+}
+
+// Runs a command in the container, returning its exit code and combined output
+func (c *Container) exec(ctx context.Context, cmd []string) (int, string, error) {
+	exec, err := DefaultClient.CreateExec(docker.CreateExecOptions{
+		Container:    c.Id,
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+		Context:      ctx,
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	var output bytes.Buffer
+	if err := DefaultClient.StartExec(exec.ID, docker.StartExecOptions{
+		OutputStream: &output,
+		ErrorStream:  &output,
+		Context:      ctx,
+	}); err != nil {
+		return 0, "", err
+	}
+
+	inspect, err := DefaultClient.InspectExec(exec.ID)
+	if err != nil {
+		return 0, "", err
+	}
+	return inspect.ExitCode, output.String(), nil
+}
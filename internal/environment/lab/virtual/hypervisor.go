@@ -0,0 +1,110 @@
+package virtual
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Hypervisors which virtual machines can be run with
+const (
+	HypervisorVirtualBox = "virtualbox"
+	HypervisorLibvirt    = "libvirt"
+)
+
+// Hypervisor runs the virtual machines of frontends and exercises. Vm delegates all hypervisor specific work to it,
+// so the rest of the agent only has to deal with Vm and the Instance interface.
+type Hypervisor interface {
+	Name() string
+	// Remote desktop protocols the hypervisor can expose, the first one being the default
+	DisplayProtocols() []string
+
+	// Imports the image of the vm into the hypervisor as a base vm which can be cloned
+	Import(ctx context.Context, vm *Vm) error
+	// Creates a linked clone of the base vm from the snapshot, registered with the id of the clone
+	Clone(ctx context.Context, base *Vm, snapshot string, clone *Vm) error
+	Exists(ctx context.Context, id string) bool
+	Start(ctx context.Context, vm *Vm) error
+	Stop(ctx context.Context, vm *Vm) error
+	Suspend(ctx context.Context, vm *Vm) error
+	// Removes the vm and its disks
+	Remove(ctx context.Context, vm *Vm) error
+	State(ctx context.Context, vm *Vm) State
+
+	TakeSnapshot(ctx context.Context, vm *Vm, name string, live bool) error
+	RestoreSnapshot(ctx context.Context, vm *Vm, name string) error
+	DeleteSnapshot(ctx context.Context, vm *Vm, name string) error
+
+	SetRAM(ctx context.Context, vm *Vm, mb uint) error
+	SetCPU(ctx context.Context, vm *Vm, cores uint) error
+	// Replaces the network interfaces of the vm with one bridged to the host interface
+	AttachNIC(ctx context.Context, vm *Vm, iface string) error
+	// Exposes the display of the vm with the remote desktop protocol on the ip and port
+	ExposeDisplay(ctx context.Context, vm *Vm, protocol string, ip string, port uint) error
+	// Shares a folder on the host with the vm
+	ShareFolder(ctx context.Context, vm *Vm, name string, hostPath string) error
+	RunningCount(ctx context.Context) (uint32, error)
+}
+
+var (
+	hypervisorsM      sync.RWMutex
+	hypervisors       = map[string]Hypervisor{}
+	defaultHypervisor = HypervisorVirtualBox
+)
+
+// Makes a hypervisor selectable by its name
+func RegisterHypervisor(h Hypervisor) {
+	hypervisorsM.Lock()
+	defer hypervisorsM.Unlock()
+	hypervisors[h.Name()] = h
+}
+
+// Selects the hypervisor used for new virtual machines. An empty name selects VirtualBox
+func SetHypervisor(name string) error {
+	if name == "" {
+		name = HypervisorVirtualBox
+	}
+	hypervisorsM.Lock()
+	defer hypervisorsM.Unlock()
+	if _, ok := hypervisors[name]; !ok {
+		var names []string
+		for n := range hypervisors {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown hypervisor %s, available hypervisors are: %s", name, strings.Join(names, ", "))
+	}
+	defaultHypervisor = name
+	return nil
+}
+
+// Returns the hypervisor used for new virtual machines
+func DefaultHypervisor() Hypervisor {
+	return getHypervisor("")
+}
+
+// Returns the hypervisor with the name, or the default hypervisor if the name is empty
+func getHypervisor(name string) Hypervisor {
+	hypervisorsM.RLock()
+	defer hypervisorsM.RUnlock()
+	if name == "" {
+		name = defaultHypervisor
+	}
+	h, ok := hypervisors[name]
+	if !ok {
+		return hypervisors[defaultHypervisor]
+	}
+	return h
+}
+
+// Returns true if the default hypervisor can expose vms with the protocol
+func SupportsDisplayProtocol(protocol string) bool {
+	for _, p := range DefaultHypervisor().DisplayProtocols() {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
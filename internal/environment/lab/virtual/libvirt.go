@@ -0,0 +1,414 @@
+package virtual
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	virshBin   = "virsh"
+	qemuImgBin = "qemu-img"
+
+	libvirtDefaultMemoryMB = 2048
+	libvirtDefaultCPUs     = 2
+)
+
+// Domain definition used for imported base vms. Clones are defined from the same template,
+// with the disk pointing to an overlay on top of the disk of the base vm
+const libvirtDomainTemplate = `<domain type='kvm'>
+  <name>%s</name>
+  <memory unit='MiB'>%d</memory>
+  <vcpu>%d</vcpu>
+  <os>
+    <type arch='x86_64'>hvm</type>
+    <boot dev='hd'/>
+  </os>
+  <features>
+    <acpi/>
+    <apic/>
+  </features>
+  <cpu mode='host-passthrough'/>
+  <devices>
+    <disk type='file' device='disk'>
+      <driver name='qemu' type='qcow2'/>
+      <source file='%s'/>
+      <target dev='vda' bus='virtio'/>
+    </disk>
+    <video>
+      <model type='vga' vram='65536'/>
+    </video>
+    <input type='tablet' bus='usb'/>
+  </devices>
+</domain>
+`
+
+func init() {
+	RegisterHypervisor(&libvirt{})
+}
+
+// QEMU/KVM hypervisor, controlled through virsh and qemu-img. OVAs are converted to qcow2 disks when imported,
+// and clones are qcow2 overlays backed by the disk of the base vm
+type libvirt struct{}
+
+type LibvirtErr struct {
+	Action string
+	Output []byte
+}
+
+func (err *LibvirtErr) Error() string {
+	return fmt.Sprintf("LibvirtError [%s]: %s", err.Action, string(err.Output))
+}
+
+func (lv *libvirt) Name() string {
+	return HypervisorLibvirt
+}
+
+func (lv *libvirt) DisplayProtocols() []string {
+	return []string{ProtocolVNC}
+}
+
+// Extracts the disk of the OVA, converts it to qcow2 and defines the base vm with it
+func (lv *libvirt) Import(ctx context.Context, vm *Vm) error {
+	tmpDir, err := ioutil.TempDir(filepath.Dir(vm.Path), ".import-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := libvirtCmd(ctx, "tar", "-xf", vm.Path, "-C", tmpDir, "--wildcards", "*.vmdk"); err != nil {
+		return err
+	}
+	disks, err := filepath.Glob(filepath.Join(tmpDir, "*.vmdk"))
+	if err != nil {
+		return err
+	}
+	if len(disks) == 0 {
+		return fmt.Errorf("no disk found in ova: %s", vm.Path)
+	}
+
+	disk := lv.diskPath(filepath.Dir(vm.Path), vm.Id)
+	if _, err := libvirtCmd(ctx, qemuImgBin, "convert", "-O", "qcow2", disks[0], disk); err != nil {
+		return err
+	}
+
+	return lv.define(ctx, vm.Id, disk)
+}
+
+func (lv *libvirt) Clone(ctx context.Context, base *Vm, snapshot string, clone *Vm) error {
+	baseDisk, err := lv.disk(ctx, base)
+	if err != nil {
+		return err
+	}
+	if snapshot != "" {
+		if baseDisk, err = lv.snapshotDisk(ctx, base, baseDisk, snapshot); err != nil {
+			return err
+		}
+	}
+
+	disk := lv.diskPath(filepath.Dir(baseDisk), clone.Id)
+	if _, err := libvirtCmd(ctx, qemuImgBin, "create", "-f", "qcow2", "-F", "qcow2", "-b", baseDisk, disk); err != nil {
+		return err
+	}
+
+	if err := lv.define(ctx, clone.Id, disk); err != nil {
+		os.Remove(disk)
+		return err
+	}
+	return nil
+}
+
+// Returns a disk holding the contents of an internal snapshot of the base disk, which clones can use as backing file.
+// The disk is exported once and reused by later clones from the same snapshot
+func (lv *libvirt) snapshotDisk(ctx context.Context, base *Vm, baseDisk string, snapshot string) (string, error) {
+	disk := lv.diskPath(filepath.Dir(baseDisk), base.Id+"-"+snapshot)
+	if _, err := os.Stat(disk); err == nil {
+		return disk, nil
+	}
+
+	// Exported to a temporary file first, so a failed export is not mistaken for a finished one
+	tmp := disk + ".tmp"
+	if _, err := libvirtCmd(ctx, qemuImgBin, "convert", "-l", "snapshot.name="+snapshot, "-O", "qcow2", baseDisk, tmp); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("error exporting snapshot %s of vm %s: %v", snapshot, base.Id, err)
+	}
+	if err := os.Rename(tmp, disk); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return disk, nil
+}
+
+func (lv *libvirt) Exists(ctx context.Context, id string) bool {
+	out, err := libvirtCmd(ctx, virshBin, "list", "--all", "--name")
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(name) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Starting a domain with a managed save restores it from the saved state
+func (lv *libvirt) Start(ctx context.Context, vm *Vm) error {
+	_, err := libvirtCmd(ctx, virshBin, "start", vm.Id)
+	return err
+}
+
+func (lv *libvirt) Stop(ctx context.Context, vm *Vm) error {
+	_, err := libvirtCmd(ctx, virshBin, "destroy", vm.Id)
+	return err
+}
+
+func (lv *libvirt) Suspend(ctx context.Context, vm *Vm) error {
+	_, err := libvirtCmd(ctx, virshBin, "managedsave", vm.Id)
+	return err
+}
+
+func (lv *libvirt) Remove(ctx context.Context, vm *Vm) error {
+	disk, err := lv.disk(ctx, vm)
+	if err != nil {
+		return err
+	}
+
+	if lv.State(ctx, vm) == Running {
+		if err := lv.Stop(ctx, vm); err != nil {
+			return err
+		}
+	}
+	if _, err := libvirtCmd(ctx, virshBin, "undefine", vm.Id, "--managed-save", "--snapshots-metadata"); err != nil {
+		return err
+	}
+	if err := os.Remove(disk); err != nil {
+		return err
+	}
+	return lv.removeSnapshotDisks(vm, filepath.Dir(disk))
+}
+
+// Removes the disks exported from the snapshots of a base vm. They are only removed with the base vm,
+// since clones use them as backing file
+func (lv *libvirt) removeSnapshotDisks(vm *Vm, dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	prefix := vm.Id + "-"
+	for _, f := range files {
+		name := f.Name()
+		if !strings.HasPrefix(name, prefix) || !(strings.HasSuffix(name, ".qcow2") || strings.HasSuffix(name, ".qcow2.tmp")) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (lv *libvirt) State(ctx context.Context, vm *Vm) State {
+	out, err := libvirtCmd(ctx, virshBin, "domstate", vm.Id)
+	if err != nil {
+		return Error
+	}
+	switch strings.TrimSpace(string(out)) {
+	case "running":
+		return Running
+	case "paused", "pmsuspended":
+		return Suspended
+	}
+
+	// A domain which has been saved is shut off with a managed save
+	info, err := libvirtCmd(ctx, virshBin, "dominfo", vm.Id)
+	if err != nil {
+		return Error
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(info))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 2)
+		if len(fields) == 2 && strings.TrimSpace(fields[0]) == "Managed save" && strings.TrimSpace(fields[1]) == "yes" {
+			return Suspended
+		}
+	}
+	return Stopped
+}
+
+// Internal qcow2 snapshots include the memory state if the domain is running
+func (lv *libvirt) TakeSnapshot(ctx context.Context, vm *Vm, name string, live bool) error {
+	_, err := libvirtCmd(ctx, virshBin, "snapshot-create-as", vm.Id, name)
+	return err
+}
+
+func (lv *libvirt) RestoreSnapshot(ctx context.Context, vm *Vm, name string) error {
+	_, err := libvirtCmd(ctx, virshBin, "snapshot-revert", vm.Id, name)
+	return err
+}
+
+func (lv *libvirt) DeleteSnapshot(ctx context.Context, vm *Vm, name string) error {
+	_, err := libvirtCmd(ctx, virshBin, "snapshot-delete", vm.Id, name)
+	return err
+}
+
+func (lv *libvirt) SetRAM(ctx context.Context, vm *Vm, mb uint) error {
+	size := fmt.Sprintf("%dM", mb)
+	if _, err := libvirtCmd(ctx, virshBin, "setmaxmem", vm.Id, size, "--config"); err != nil {
+		return err
+	}
+	_, err := libvirtCmd(ctx, virshBin, "setmem", vm.Id, size, "--config")
+	return err
+}
+
+func (lv *libvirt) SetCPU(ctx context.Context, vm *Vm, cores uint) error {
+	count := fmt.Sprintf("%d", cores)
+	if _, err := libvirtCmd(ctx, virshBin, "setvcpus", vm.Id, count, "--config", "--maximum"); err != nil {
+		return err
+	}
+	_, err := libvirtCmd(ctx, virshBin, "setvcpus", vm.Id, count, "--config")
+	return err
+}
+
+// Docker bridge networks are attached as bridges, macvlan networks through a macvtap device on the same parent interface
+func (lv *libvirt) AttachNIC(ctx context.Context, vm *Vm, iface string) error {
+	if err := lv.editDomain(ctx, vm, func(xml string) string {
+		return removeXMLElements(xml, "interface")
+	}); err != nil {
+		return err
+	}
+
+	ifaceType := "direct"
+	if strings.HasPrefix(iface, "br-") {
+		ifaceType = "bridge"
+	}
+	_, err := libvirtCmd(ctx, virshBin, "attach-interface", vm.Id, ifaceType, iface, "--model", "virtio", "--config")
+	return err
+}
+
+func (lv *libvirt) ExposeDisplay(ctx context.Context, vm *Vm, protocol string, ip string, port uint) error {
+	if protocol != ProtocolVNC {
+		return errors.New("libvirt only supports vnc")
+	}
+
+	graphics := fmt.Sprintf("<graphics type='vnc' port='%d' autoport='no' listen='%s'/>", port, ip)
+	// Any existing display is replaced, since a domain with several displays would only be reachable on the first
+	return lv.editDomain(ctx, vm, func(xml string) string {
+		xml = removeXMLElements(xml, "graphics")
+		return strings.Replace(xml, "</devices>", graphics+"\n  </devices>", 1)
+	})
+}
+
+// Removes all elements with the name from the xml, both self-closing elements and elements with children
+func removeXMLElements(xml string, name string) string {
+	for {
+		start := strings.Index(xml, "<"+name+" ")
+		if start < 0 {
+			return xml
+		}
+		tagEnd := strings.Index(xml[start:], ">")
+		if tagEnd < 0 {
+			return xml
+		}
+		end := start + tagEnd + 1
+		if xml[start+tagEnd-1] != '/' {
+			closing := strings.Index(xml[start:], "</"+name+">")
+			if closing < 0 {
+				return xml
+			}
+			end = start + closing + len("</"+name+">")
+		}
+		xml = xml[:start] + xml[end:]
+	}
+}
+
+func (lv *libvirt) ShareFolder(ctx context.Context, vm *Vm, name string, hostPath string) error {
+	return errors.New("shared folders are not supported by libvirt")
+}
+
+func (lv *libvirt) RunningCount(ctx context.Context) (uint32, error) {
+	out, err := libvirtCmd(ctx, virshBin, "list", "--name")
+	if err != nil {
+		return 0, err
+	}
+	var count uint32
+	for _, name := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(name) != "" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (lv *libvirt) diskPath(dir string, id string) string {
+	return filepath.Join(dir, id+".qcow2")
+}
+
+// Returns the path of the disk of the domain
+func (lv *libvirt) disk(ctx context.Context, vm *Vm) (string, error) {
+	out, err := libvirtCmd(ctx, virshBin, "domblklist", vm.Id, "--details")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 4 && fields[0] == "file" && fields[1] == "disk" {
+			return fields[3], nil
+		}
+	}
+	return "", fmt.Errorf("no disk found for vm: %s", vm.Id)
+}
+
+func (lv *libvirt) define(ctx context.Context, id string, disk string) error {
+	xml := fmt.Sprintf(libvirtDomainTemplate, id, libvirtDefaultMemoryMB, libvirtDefaultCPUs, disk)
+	return lv.defineXML(ctx, xml)
+}
+
+// Changes the persistent definition of the domain
+func (lv *libvirt) editDomain(ctx context.Context, vm *Vm, edit func(string) string) error {
+	out, err := libvirtCmd(ctx, virshBin, "dumpxml", vm.Id, "--inactive")
+	if err != nil {
+		return err
+	}
+	return lv.defineXML(ctx, edit(string(out)))
+}
+
+func (lv *libvirt) defineXML(ctx context.Context, xml string) error {
+	f, err := ioutil.TempFile("", "domain-*.xml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(xml); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	_, err = libvirtCmd(ctx, virshBin, "define", f.Name())
+	return err
+}
+
+func libvirtCmd(ctx context.Context, bin string, args ...string) ([]byte, error) {
+	c := exec.CommandContext(ctx, bin, args...)
+	out, err := c.CombinedOutput()
+	if err != nil {
+		log.Debug().Str("output", string(out)).Msgf("error running %s", bin)
+		return nil, &LibvirtErr{
+			Action: bin + " " + strings.Join(args, " "),
+			Output: out,
+		}
+	}
+	return out, nil
+}
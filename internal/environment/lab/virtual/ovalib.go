@@ -0,0 +1,375 @@
+package virtual
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const checksumCacheFile = ".ova-checksums.json"
+
+var ErrChecksumMismatch = errors.New("checksum of uploaded ova does not match")
+
+// Information about an OVA in the library
+type OvaImage struct {
+	Name string
+	Size int64
+	// Empty if the checksum has not been calculated yet
+	Checksum string
+	// True if the base vm of the image has been imported and snapshotted
+	Imported bool
+}
+
+// Cached checksum of an OVA, which is only valid as long as the file has not been changed
+type ovaChecksum struct {
+	Sum     string    `json:"sum"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+type checksumCache struct {
+	m    sync.Mutex
+	path string
+	sums map[string]ovaChecksum
+}
+
+func loadChecksumCache(dir string) *checksumCache {
+	c := &checksumCache{
+		path: filepath.Join(dir, checksumCacheFile),
+		sums: make(map[string]ovaChecksum),
+	}
+	data, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warn().Err(err).Msg("error reading ova checksum cache")
+		}
+		return c
+	}
+	if err := json.Unmarshal(data, &c.sums); err != nil {
+		log.Warn().Err(err).Msg("error parsing ova checksum cache, starting with empty cache")
+		c.sums = make(map[string]ovaChecksum)
+	}
+	return c
+}
+
+// Returns the cached checksum of the file if it has not been modified since the checksum was calculated
+func (c *checksumCache) get(path string, info os.FileInfo) (string, bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	sum, ok := c.sums[filepath.Base(path)]
+	if !ok || sum.Size != info.Size() || !sum.ModTime.Equal(info.ModTime()) {
+		return "", false
+	}
+	return sum.Sum, true
+}
+
+func (c *checksumCache) set(path string, info os.FileInfo, sum string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.sums[filepath.Base(path)] = ovaChecksum{
+		Sum:     sum,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+	c.save()
+}
+
+func (c *checksumCache) remove(path string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	delete(c.sums, filepath.Base(path))
+	c.save()
+}
+
+// Has to be called with the lock held
+func (c *checksumCache) save() {
+	data, err := json.Marshal(c.sums)
+	if err != nil {
+		log.Error().Err(err).Msg("error marshalling ova checksum cache")
+		return
+	}
+	if err := ioutil.WriteFile(c.path, data, 0644); err != nil {
+		log.Error().Err(err).Msg("error writing ova checksum cache")
+	}
+}
+
+// Returns the checksum of an OVA, only reading the whole file if it has changed since last time
+func (lib *VboxLibrary) checksum(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if sum, ok := lib.checksums.get(path, info); ok {
+		return sum, nil
+	}
+
+	sum, err := checksumOfFile(path)
+	if err != nil {
+		return "", err
+	}
+	lib.checksums.set(path, info, sum)
+	return sum, nil
+}
+
+// Returns the lock used to serialize imports and removals of a single OVA
+func (lib *VboxLibrary) pathLock(path string) *sync.Mutex {
+	lib.M.Lock()
+	defer lib.M.Unlock()
+
+	pathLock, ok := lib.Locks[path]
+	if !ok {
+		pathLock = &sync.Mutex{}
+		lib.Locks[path] = pathLock
+	}
+	return pathLock
+}
+
+// Returns the imported base vm of an OVA, importing and snapshotting it if needed. The path lock has to be held
+func (lib *VboxLibrary) baseVm(ctx context.Context, path string) (*Vm, error) {
+	lib.M.Lock()
+	vm, ok := lib.Known[path]
+	lib.M.Unlock()
+	if ok {
+		return vm, nil
+	}
+
+	sum, err := lib.checksum(path)
+	if err != nil {
+		return nil, err
+	}
+
+	n := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	vm, ok = VmExists(n, sum)
+	if !ok {
+		vm = NewVMWithSum(path, n, sum)
+		if err := vm.Create(ctx); err != nil {
+			return nil, err
+		}
+
+		err = vm.Snapshot("origin")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lib.M.Lock()
+	lib.Known[path] = vm
+	lib.M.Unlock()
+
+	return vm, nil
+}
+
+// Lists the OVAs in the library
+func (lib *VboxLibrary) ListImages() ([]OvaImage, error) {
+	files, err := ioutil.ReadDir(lib.Pwd)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []OvaImage
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".ova") {
+			continue
+		}
+		path := filepath.Join(lib.Pwd, f.Name())
+		sum, _ := lib.checksums.get(path, f)
+
+		lib.M.Lock()
+		_, imported := lib.Known[path]
+		lib.M.Unlock()
+		// Base vms imported before the agent was restarted are not known by the library yet
+		if !imported && sum != "" {
+			_, imported = VmExists(strings.TrimSuffix(f.Name(), ".ova"), sum)
+		}
+
+		images = append(images, OvaImage{
+			Name:     strings.TrimSuffix(f.Name(), ".ova"),
+			Size:     f.Size(),
+			Checksum: sum,
+			Imported: imported,
+		})
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Name < images[j].Name
+	})
+	return images, nil
+}
+
+// Imports the base vm of an OVA and takes the snapshot which frontends are cloned from,
+// so the first lab using the image does not have to wait for it
+func (lib *VboxLibrary) PrepareImage(ctx context.Context, name string) (OvaImage, error) {
+	if err := CheckOvaName(name); err != nil {
+		return OvaImage{}, err
+	}
+	path := lib.GetImagePath(name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return OvaImage{}, err
+	}
+
+	pathLock := lib.pathLock(path)
+	pathLock.Lock()
+	defer pathLock.Unlock()
+
+	if _, err := lib.baseVm(ctx, path); err != nil {
+		return OvaImage{}, err
+	}
+	sum, err := lib.checksum(path)
+	if err != nil {
+		return OvaImage{}, err
+	}
+
+	return OvaImage{
+		Name:     strings.TrimSuffix(filepath.Base(path), ".ova"),
+		Size:     info.Size(),
+		Checksum: sum,
+		Imported: true,
+	}, nil
+}
+
+// Removes an OVA and its base vm from the library. The base vm cannot be removed while clones of it exist
+func (lib *VboxLibrary) RemoveImage(ctx context.Context, name string) error {
+	if err := CheckOvaName(name); err != nil {
+		return err
+	}
+	path := lib.GetImagePath(name)
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+
+	pathLock := lib.pathLock(path)
+	pathLock.Lock()
+	defer pathLock.Unlock()
+
+	lib.M.Lock()
+	vm, ok := lib.Known[path]
+	lib.M.Unlock()
+	if !ok {
+		if sum, err := lib.checksum(path); err == nil {
+			vm, ok = VmExists(strings.TrimSuffix(filepath.Base(path), ".ova"), sum)
+		}
+	}
+	if ok {
+		if err := vm.hypervisor().Remove(ctx, vm); err != nil {
+			return err
+		}
+	}
+
+	lib.M.Lock()
+	delete(lib.Known, path)
+	lib.M.Unlock()
+
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	lib.checksums.remove(path)
+	return nil
+}
+
+// Upload of an OVA in chunks. The OVA is written to a temporary file which is only moved into the library
+// once the checksum has been verified
+type OvaUpload struct {
+	lib  *VboxLibrary
+	path string
+	file *os.File
+	hash hash.Hash32
+	size int64
+}
+
+// Returns an error if the name is empty or contains path separators, so names cannot point outside the library
+func CheckOvaName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid ova name: %s", name)
+	}
+	return nil
+}
+
+func (lib *VboxLibrary) NewUpload(name string) (*OvaUpload, error) {
+	if err := CheckOvaName(name); err != nil {
+		return nil, err
+	}
+	path := lib.GetImagePath(name)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("ova already exists: %s", name)
+	}
+
+	file, err := ioutil.TempFile(lib.Pwd, filepath.Base(path)+".*.part")
+	if err != nil {
+		return nil, err
+	}
+	return &OvaUpload{
+		lib:  lib,
+		path: path,
+		file: file,
+		hash: crc32.NewIEEE(),
+	}, nil
+}
+
+func (u *OvaUpload) Write(p []byte) (int, error) {
+	n, err := u.file.Write(p)
+	u.hash.Write(p[:n])
+	u.size += int64(n)
+	return n, err
+}
+
+// Verifies the checksum of the uploaded data and moves the OVA into the library.
+// The checksum is the hex encoded crc32 used by the library, and is not verified if empty
+func (u *OvaUpload) Commit(checksum string) (OvaImage, error) {
+	if err := u.file.Close(); err != nil {
+		u.Abort()
+		return OvaImage{}, err
+	}
+
+	sum := hex.EncodeToString(u.hash.Sum(nil))
+	if checksum != "" && !strings.EqualFold(checksum, sum) {
+		u.Abort()
+		return OvaImage{}, ErrChecksumMismatch
+	}
+
+	pathLock := u.lib.pathLock(u.path)
+	pathLock.Lock()
+	defer pathLock.Unlock()
+
+	if _, err := os.Stat(u.path); err == nil {
+		u.Abort()
+		return OvaImage{}, errors.New("ova was created while uploading")
+	}
+	if err := os.Rename(u.file.Name(), u.path); err != nil {
+		u.Abort()
+		return OvaImage{}, err
+	}
+
+	info, err := os.Stat(u.path)
+	if err != nil {
+		return OvaImage{}, err
+	}
+	u.lib.checksums.set(u.path, info, sum)
+
+	return OvaImage{
+		Name:     strings.TrimSuffix(filepath.Base(u.path), ".ova"),
+		Size:     u.size,
+		Checksum: sum,
+	}, nil
+}
+
+// Removes the temporary file of the upload
+func (u *OvaUpload) Abort() {
+	u.file.Close()
+	if err := os.Remove(u.file.Name()); err != nil && !os.IsNotExist(err) {
+		log.Error().Err(err).Str("file", u.file.Name()).Msg("error removing partial ova upload")
+	}
+}
@@ -0,0 +1,280 @@
+package virtual
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog/log"
+)
+
+// Statuses reported while pulling images
+const (
+	PullStatusWaiting  = "waiting"
+	PullStatusPulling  = "pulling"
+	PullStatusPulled   = "pulled"
+	PullStatusUpToDate = "up-to-date"
+	PullStatusFailed   = "failed"
+)
+
+// Images which have been verified within this duration are not checked against the registry again when creating containers
+const pulledImageValidity = 10 * time.Minute
+
+// Minimum time between progress reports of the same image
+const pullProgressInterval = 500 * time.Millisecond
+
+// Progress of pulling an image
+type PullProgress struct {
+	Image  string
+	Status string
+	// Bytes downloaded and total bytes of the layers being downloaded
+	Current int64
+	Total   int64
+	Err     error
+}
+
+// Pull or verification of an image, shared between everyone needing the image at the same time
+type imagePull struct {
+	done     chan struct{}
+	err      error
+	pulled   bool
+	size     int64
+	finished time.Time
+
+	m         sync.Mutex
+	listeners map[int]func(PullProgress)
+	nextId    int
+}
+
+var (
+	pullsM sync.Mutex
+	pulls  = map[string]*imagePull{}
+)
+
+// Pulls the images in parallel, making sure the newest versions are available locally.
+// Progress is reported for each image, and calls to progress are never made concurrently.
+func PullImages(ctx context.Context, images []string, progress func(PullProgress)) error {
+	var progressM sync.Mutex
+	report := func(p PullProgress) {
+		if progress == nil {
+			return
+		}
+		progressM.Lock()
+		defer progressM.Unlock()
+		progress(p)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errM sync.Mutex
+		errs error
+	)
+	seen := make(map[string]bool)
+	for _, image := range images {
+		if image == "" || seen[image] {
+			continue
+		}
+		seen[image] = true
+
+		wg.Add(1)
+		go func(image string) {
+			defer wg.Done()
+			if err := ensureImage(ctx, image, report); err != nil {
+				errM.Lock()
+				errs = multierror.Append(errs, fmt.Errorf("%s: %w", image, err))
+				errM.Unlock()
+			}
+		}(image)
+	}
+	wg.Wait()
+	return errs
+}
+
+// Makes sure the newest version of the image is available locally. If the image is already being pulled,
+// the pull is waited for instead of starting a new one, and images verified recently are not verified again.
+func ensureImage(ctx context.Context, image string, progress func(PullProgress)) error {
+	pullsM.Lock()
+	pull, ok := pulls[image]
+	if ok {
+		select {
+		case <-pull.done:
+			// Pulls which failed or are too old are retried
+			if pull.err != nil || time.Since(pull.finished) > pulledImageValidity {
+				ok = false
+			}
+		default:
+		}
+	}
+	if !ok {
+		pull = &imagePull{done: make(chan struct{}), listeners: make(map[int]func(PullProgress))}
+		pulls[image] = pull
+		go pull.run(image)
+	}
+	pullsM.Unlock()
+
+	if progress != nil {
+		unsubscribe := pull.subscribe(progress)
+		defer unsubscribe()
+		progress(PullProgress{Image: image, Status: PullStatusWaiting})
+	}
+
+	select {
+	case <-pull.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if progress != nil {
+		final := PullProgress{Image: image, Status: PullStatusUpToDate}
+		switch {
+		case pull.err != nil:
+			final = PullProgress{Image: image, Status: PullStatusFailed, Err: pull.err}
+		case pull.pulled:
+			final = PullProgress{Image: image, Status: PullStatusPulled, Current: pull.size, Total: pull.size}
+		}
+		progress(final)
+	}
+	if pull.err == nil {
+		markImageUsed(image)
+	}
+	return pull.err
+}
+
+// Forgets finished pulls, so images are verified again before being used. Used when images have been removed
+func forgetPulls() {
+	pullsM.Lock()
+	defer pullsM.Unlock()
+	for image, pull := range pulls {
+		select {
+		case <-pull.done:
+			delete(pulls, image)
+		default:
+		}
+	}
+}
+
+// Verifies the image against the registry and pulls it if needed. The pull is not cancelled if the ones waiting for it are
+func (pull *imagePull) run(image string) {
+	w := newPullWriter(image, pull.report)
+	err := verifyLocalImageVersion(parseImage(image), w)
+	w.Close()
+
+	switch err.(type) {
+	case nil:
+	case NoLocalImageAvailableErr, NoCredentialsErr:
+		pull.err = err
+	default:
+		// The local image can still be used
+		log.Warn().Msgf("failed to update local Docker image: %s", err)
+	}
+
+	pull.pulled, pull.size = w.pulled, w.total
+	pull.finished = time.Now()
+	close(pull.done)
+}
+
+// Adds a listener for the progress of the pull. The returned function removes it again, so progress is not
+// reported to callers which have stopped waiting for the pull
+func (pull *imagePull) subscribe(progress func(PullProgress)) func() {
+	pull.m.Lock()
+	defer pull.m.Unlock()
+	id := pull.nextId
+	pull.nextId++
+	pull.listeners[id] = progress
+	return func() {
+		pull.m.Lock()
+		defer pull.m.Unlock()
+		delete(pull.listeners, id)
+	}
+}
+
+func (pull *imagePull) report(p PullProgress) {
+	pull.m.Lock()
+	defer pull.m.Unlock()
+	for _, l := range pull.listeners {
+		l(p)
+	}
+}
+
+// Decodes the json progress messages of docker pulls and reports the combined progress of the layers
+type pullWriter struct {
+	image  string
+	report func(PullProgress)
+	pw     *io.PipeWriter
+	done   chan struct{}
+
+	layers         map[string][2]int64
+	current, total int64
+	pulled         bool
+	lastReport     time.Time
+}
+
+func newPullWriter(image string, report func(PullProgress)) *pullWriter {
+	pr, pw := io.Pipe()
+	w := &pullWriter{
+		image:  image,
+		report: report,
+		pw:     pw,
+		done:   make(chan struct{}),
+		layers: make(map[string][2]int64),
+	}
+	go w.decode(pr)
+	return w
+}
+
+func (w *pullWriter) Write(p []byte) (int, error) {
+	return w.pw.Write(p)
+}
+
+// Waits for all written messages to be decoded
+func (w *pullWriter) Close() error {
+	err := w.pw.Close()
+	<-w.done
+	return err
+}
+
+func (w *pullWriter) decode(r *io.PipeReader) {
+	defer close(w.done)
+	dec := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Id             string `json:"id"`
+			Status         string `json:"status"`
+			ProgressDetail struct {
+				Current int64 `json:"current"`
+				Total   int64 `json:"total"`
+			} `json:"progressDetail"`
+		}
+		if err := dec.Decode(&msg); err != nil {
+			// Drain the pipe so docker is never blocked on writing progress
+			io.Copy(io.Discard, r)
+			return
+		}
+		w.pulled = true
+
+		layer := w.layers[msg.Id]
+		switch msg.Status {
+		case "Downloading":
+			layer = [2]int64{msg.ProgressDetail.Current, msg.ProgressDetail.Total}
+		case "Download complete", "Pull complete", "Already exists":
+			layer[0] = layer[1]
+		}
+		if msg.Id != "" {
+			w.layers[msg.Id] = layer
+		}
+
+		w.current, w.total = 0, 0
+		for _, l := range w.layers {
+			w.current += l[0]
+			w.total += l[1]
+		}
+		if time.Since(w.lastReport) >= pullProgressInterval {
+			w.lastReport = time.Now()
+			w.report(PullProgress{Image: w.image, Status: PullStatusPulling, Current: w.current, Total: w.total})
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package virtual
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+const (
+	// Name images without a registry are pulled from, used when configuring mirrors
+	dockerHubRegistry = "docker.io"
+	dockerHubAPI      = "https://registry-1.docker.io"
+
+	registryTimeout = 5 * time.Second
+)
+
+// Manifest types accepted when looking up digests, both single platform images and multi platform indexes
+var manifestTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+var (
+	challengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+	digestRegex         = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Configures how images are resolved against the registries
+type RegistryConfig struct {
+	// Trusts local images without comparing their digests with the registries.
+	// Missing images can still be pulled if the registry has a mirror.
+	Offline bool `yaml:"offline"`
+	// Pull-through mirrors by the registry they mirror, docker.io for Docker Hub.
+	// Mirrors are reached over https unless the address starts with http://
+	Mirrors map[string]string `yaml:"mirrors"`
+}
+
+var (
+	registryConf RegistryConfig
+	// Mirrors reached over plain http
+	insecureRegistries = map[string]bool{}
+)
+
+func SetRegistryConfig(conf RegistryConfig) {
+	mirrors := make(map[string]string)
+	insecure := make(map[string]bool)
+	for registry, mirror := range conf.Mirrors {
+		host := registryHost(mirror)
+		if strings.HasPrefix(mirror, "http://") {
+			insecure[host] = true
+		}
+		mirrors[registry] = host
+	}
+	conf.Mirrors = mirrors
+	registryConf = conf
+	insecureRegistries = insecure
+}
+
+// Restricts the images exercises of an environment may use
+type ImagePolicy struct {
+	// Refuses images which are not pinned to a digest
+	RequireDigest bool
+	// Registries images may come from, docker.io for Docker Hub. Any registry is allowed if empty
+	AllowedRegistries []string
+}
+
+// Returns an error if the image is not allowed by the policy
+func (p ImagePolicy) Check(image string) error {
+	img := parseImage(image)
+	if img.Digest != "" && !digestRegex.MatchString(img.Digest) {
+		return fmt.Errorf("invalid digest of image %s", image)
+	}
+	if p.RequireDigest && img.Digest == "" {
+		return fmt.Errorf("image %s is not pinned to a digest", image)
+	}
+	if len(p.AllowedRegistries) == 0 {
+		return nil
+	}
+
+	registry := img.Registry
+	if isDockerHub(registry) {
+		registry = dockerHubRegistry
+	}
+	for _, allowed := range p.AllowedRegistries {
+		if allowed == registry {
+			return nil
+		}
+	}
+	return fmt.Errorf("registry %s of image %s is not allowed", registry, image)
+}
+
+func isDockerHub(registry string) bool {
+	return registry == "" || registry == dockerHubRegistry
+}
+
+// Returns the credentials configured for the registry, Docker Hub can be configured both with and without its name
+func registryCredentials(registry string) (docker.AuthConfiguration, bool) {
+	if creds, ok := Registries[registry]; ok {
+		return creds, true
+	}
+	if isDockerHub(registry) {
+		if creds, ok := Registries[""]; ok {
+			return creds, true
+		}
+		creds, ok := Registries[dockerHubRegistry]
+		return creds, ok
+	}
+	return docker.AuthConfiguration{}, false
+}
+
+// Returns the image in the registry it should be pulled from, which is the mirror of its registry if one is configured
+func pullSource(img Image) (Image, docker.AuthConfiguration, error) {
+	key := img.Registry
+	if isDockerHub(key) {
+		key = dockerHubRegistry
+	}
+	mirror, ok := registryConf.Mirrors[key]
+	if !ok {
+		creds, ok := registryCredentials(img.Registry)
+		if !ok {
+			return img, creds, NoCredentialsErr{img.Registry}
+		}
+		return img, creds, nil
+	}
+
+	// Mirrors use their own credentials if configured, otherwise the credentials of the mirrored registry
+	creds, ok := Registries[mirror]
+	if !ok {
+		creds, _ = registryCredentials(img.Registry)
+	}
+	return Image{
+		Registry: mirror,
+		Repo:     registryRepo(img),
+		Tag:      img.Tag,
+		Digest:   img.Digest,
+	}, creds, nil
+}
+
+// Returns the name the image is known by locally. Pinned images pulled from a mirror cannot be tagged
+// with their original name, so they keep the name of the mirror
+func localImageRef(image string) string {
+	img := parseImage(image)
+	if img.Digest == "" {
+		return image
+	}
+	src, _, err := pullSource(img)
+	if err != nil {
+		return img.String()
+	}
+	return src.String()
+}
+
+// Returns the digest of the local image as known by the registry it was pulled from
+func resolveDigest(image string) (string, error) {
+	img := parseImage(image)
+	if img.Digest != "" {
+		return img.Digest, nil
+	}
+
+	localImg, err := DefaultClient.InspectImage(image)
+	if err != nil {
+		return "", err
+	}
+	if len(localImg.RepoDigests) == 0 {
+		return "", NoLocalDigestErr{img}
+	}
+
+	// Prefer the digest of the repository itself over the digest of a mirror, although they should be the same
+	repoDigest := localImg.RepoDigests[0]
+	for _, d := range localImg.RepoDigests {
+		if strings.HasPrefix(d, img.NameWithReg()+"@") {
+			repoDigest = d
+			break
+		}
+	}
+	if i := strings.Index(repoDigest, "@"); i >= 0 {
+		repoDigest = repoDigest[i+1:]
+	}
+	return repoDigest, nil
+}
+
+// Returns the base url of the registry API
+func registryURL(registry string) string {
+	if isDockerHub(registry) {
+		return dockerHubAPI
+	}
+	if insecureRegistries[registry] {
+		return "http://" + registry
+	}
+	return "https://" + registry
+}
+
+// Returns the registry without scheme, as docker expects it in image names
+func registryHost(registry string) string {
+	if i := strings.Index(registry, "://"); i >= 0 {
+		registry = registry[i+3:]
+	}
+	return strings.TrimSuffix(registry, "/")
+}
+
+// Returns the repository as named in the registry API, where official Docker Hub images are under library/
+func registryRepo(img Image) string {
+	if isDockerHub(img.Registry) && !strings.Contains(img.Repo, "/") {
+		return "library/" + img.Repo
+	}
+	return img.Repo
+}
+
+// Looks up the digest of the image in its registry, using the token authentication of the OCI distribution spec
+// if the registry asks for it
+func getRemoteDigestForImage(auth docker.AuthConfiguration, img Image) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), registryTimeout)
+	defer cancel()
+
+	repo := registryRepo(img)
+	manifestURL := fmt.Sprintf("%s/v2/%s/manifests/%s", registryURL(img.Registry), repo, img.Tag)
+
+	resp, err := headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err := authorizeRegistry(ctx, resp.Header.Get("WWW-Authenticate"), auth, repo)
+		if err != nil {
+			return "", err
+		}
+		resp, err = headManifest(ctx, manifestURL, authorization)
+		if err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status from registry when looking up %s: %s", img.String(), resp.Status)
+	}
+
+	hash := resp.Header.Get("Docker-Content-Digest")
+	if hash == "" {
+		return "", EmptyDigestErr
+	}
+	return hash, nil
+}
+
+func headManifest(ctx context.Context, manifestURL string, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := RegistryClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// Returns the authorization header answering the challenge of the registry, fetching a bearer token from
+// the realm of the challenge if needed
+func authorizeRegistry(ctx context.Context, challenge string, auth docker.AuthConfiguration, repo string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("unsupported registry authentication challenge: %q", challenge)
+	}
+
+	values := make(map[string]string)
+	for _, m := range challengeParamRegex.FindAllStringSubmatch(params, -1) {
+		values[m[1]] = m[2]
+	}
+	if values["realm"] == "" {
+		return "", fmt.Errorf("registry authentication challenge without realm: %q", challenge)
+	}
+
+	query := url.Values{}
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repo))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, values["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if auth.Username != "" {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+
+	resp, err := RegistryClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status from registry token endpoint: %s", resp.Status)
+	}
+
+	var msg struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&msg); err != nil {
+		return "", err
+	}
+	if msg.Token == "" {
+		msg.Token = msg.AccessToken
+	}
+	if msg.Token == "" {
+		return "", fmt.Errorf("registry token endpoint returned no token")
+	}
+	return "Bearer " + msg.Token, nil
+}
@@ -5,7 +5,6 @@
 package virtual
 
 import (
-	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -15,13 +14,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"regexp"
-
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -32,6 +28,14 @@ type InstanceConfig struct {
 	Image    string  `yaml:"image"`
 	MemoryMB uint    `yaml:"memoryMB"`
 	CPU      float64 `yaml:"cpu"`
+	// Optional display name of a frontend, shown in guacamole
+	Name string `yaml:"name"`
+	// Frontend type, either a virtualbox ova (default) or a docker image
+	Type string `yaml:"type"`
+	// Remote desktop protocol exposed by the frontend, rdp (default) or vnc
+	Protocol string `yaml:"protocol"`
+	// Port of the remote desktop server inside a docker frontend, defaults to the standard port of the protocol
+	Port uint `yaml:"port"`
 }
 
 const (
@@ -79,6 +83,8 @@ type VboxLibrary struct {
 	Pwd   string
 	Known map[string]*Vm
 	Locks map[string]*sync.Mutex
+	// Checksums of the OVAs, persisted in the library directory
+	checksums *checksumCache
 }
 
 // VM information is stored in a struct
@@ -88,21 +94,30 @@ type Vm struct {
 	Image   string
 	opts    []VMOpt
 	Running bool
+	// Name of the hypervisor running the vm, empty for vms created before hypervisors were selectable which are run by VirtualBox
+	Hypervisor string
 }
 
 func NewVMWithSum(path, image string, checksum string, vmOpts ...VMOpt) *Vm {
 	return &Vm{
-		Path:  path,
-		Image: image,
-		opts:  vmOpts,
-		Id:    fmt.Sprintf("%s{%s}", image, checksum),
+		Path:       path,
+		Image:      image,
+		opts:       vmOpts,
+		Id:         fmt.Sprintf("%s{%s}", image, checksum),
+		Hypervisor: DefaultHypervisor().Name(),
+	}
+}
+
+func (vm *Vm) hypervisor() Hypervisor {
+	if vm.Hypervisor == "" {
+		return getHypervisor(HypervisorVirtualBox)
 	}
+	return getHypervisor(vm.Hypervisor)
 }
 
 // Creating VM
 func (vm *Vm) Create(ctx context.Context) error {
-	_, err := VBoxCmdContext(ctx, "import", vm.Path, "--vsys", "0", "--eula", "accept", "--vmname", vm.Id)
-	if err != nil {
+	if err := vm.hypervisor().Import(ctx, vm); err != nil {
 		return err
 	}
 
@@ -125,8 +140,7 @@ func (vm *Vm) Run(ctx context.Context) error {
 }
 
 func (vm *Vm) Start(ctx context.Context) error {
-	_, err := VBoxCmdContext(ctx, vboxStartVM, vm.Id, "--type", "headless")
-	if err != nil {
+	if err := vm.hypervisor().Start(ctx, vm); err != nil {
 		return err
 	}
 
@@ -136,19 +150,11 @@ func (vm *Vm) Start(ctx context.Context) error {
 		Str("ID", vm.Id).
 		Msg("Started VM")
 
-	log.Debug().
-		Str("ID", vm.Id).
-		Msg("Setting resolution for VM")
-	_, err = VBoxCmdContext(ctx, vboxCtrlVM, vm.Id, "setvideomodehint", "1920", "1080", "16")
-	if err != nil {
-		log.Error().Str("ID", vm.Id).Msgf("Error setting resolution, VM may require reset on after connecting: %s", err.Error())
-	}
-
 	return nil
 }
 
 func (vm *Vm) Stop() error {
-	_, err := VBoxCmdContext(context.Background(), vboxCtrlVM, vm.Id, "poweroff")
+	err := vm.hypervisor().Stop(context.Background(), vm)
 	if err != nil {
 		log.Error().Msgf("Error while shutting down VM %s", err)
 		return err
@@ -163,9 +169,9 @@ func (vm *Vm) Stop() error {
 	return nil
 }
 
-// Will call savestate on vm
+// Saves the state of the vm, so it can be resumed by Start
 func (vm *Vm) Suspend(ctx context.Context) error {
-	_, err := VBoxCmdContext(ctx, vboxCtrlVM, vm.Id, "savestate")
+	err := vm.hypervisor().Suspend(ctx, vm)
 	if err != nil {
 		log.Error().
 			Str("ID", vm.Id).
@@ -191,7 +197,7 @@ func (vm *Vm) Close() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	_, err = VBoxCmdContext(ctx, vboxUnregisterVM, vm.Id, "--delete")
+	err = vm.hypervisor().Remove(ctx, vm)
 	if err != nil {
 		return err
 	}
@@ -205,98 +211,53 @@ func (vm *Vm) Close() error {
 
 type VMOpt func(context.Context, *Vm) error
 
-func removeAllNICs(ctx context.Context, vm *Vm) error {
-	result, err := VBoxCmdContext(ctx, vboxShowVMInfo, vm.Id)
-	if err != nil {
-		return err
-	}
-	re := regexp.MustCompile(nicRegex)
-	numberOfNICs := re.FindAll(result, -1)
-	for i := 1; i <= len(numberOfNICs); i++ {
-		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--nic"+strconv.Itoa(i), "none")
-		if err != nil {
-			return err
-		}
+func SetBridge(nic string) VMOpt {
+	return func(ctx context.Context, vm *Vm) error {
+		return vm.hypervisor().AttachNIC(ctx, vm, nic)
 	}
-	return nil
 }
 
-func SetBridge(nic string) VMOpt {
+// Exposes the display of the vm with the remote desktop protocol. An empty protocol selects the default of the hypervisor
+func SetLocalDisplay(protocol string, ip string, port uint) VMOpt {
 	return func(ctx context.Context, vm *Vm) error {
-		// Removes all NIC cards from importing VMs
-		if err := removeAllNICs(ctx, vm); err != nil {
-			return err
+		h := vm.hypervisor()
+		if protocol == "" {
+			protocol = h.DisplayProtocols()[0]
 		}
-		// enables specified NIC card in purpose
-		_, err := VBoxCmdContext(ctx, vboxModVM, vm.Id, "--nic1", "bridged", "--bridgeadapter1", nic)
-		if err != nil {
-			return err
-		}
-		// allows promiscuous mode
-		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--nicpromisc1", "allow-all")
-		if err != nil {
-			return err
-		}
-
-		return nil
+		return h.ExposeDisplay(ctx, vm, protocol, ip, port)
 	}
 }
 
 func SetLocalRDP(ip string, port uint) VMOpt {
-	return func(ctx context.Context, vm *Vm) error {
-		_, err := VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrde", "on")
-		if err != nil {
-			return err
-		}
-
-		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrdeproperty", fmt.Sprintf("TCP/Address=%s", ip))
-		if err != nil {
-			return err
-		}
-
-		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrdeproperty", fmt.Sprintf("TCP/Ports=%d", port))
-		if err != nil {
-			return err
-		}
-
-		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrdeauthtype", "null")
-		if err != nil {
-			return err
-		}
-
-		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vram", "128")
-		if err != nil {
-			return err
-		}
-
-		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--clipboard", "bidirectional")
-		if err != nil {
-			return err
-		}
-
-		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrdemulticon", "on")
-		if err != nil {
-			return err
-		}
-
-		return nil
-	}
+	return SetLocalDisplay(ProtocolRDP, ip, port)
 }
 
 func SetCPU(cores uint) VMOpt {
 	return func(ctx context.Context, vm *Vm) error {
-		_, err := VBoxCmdContext(ctx, vboxModVM, vm.Id, "--cpus", fmt.Sprintf("%d", cores))
-		return err
+		return vm.hypervisor().SetCPU(ctx, vm, cores)
 	}
 }
 
 func SetRAM(mb uint) VMOpt {
 	return func(ctx context.Context, vm *Vm) error {
-		_, err := VBoxCmdContext(ctx, vboxModVM, vm.Id, "--memory", fmt.Sprintf("%d", mb))
-		return err
+		return vm.hypervisor().SetRAM(ctx, vm, mb)
 	}
 }
 
+// Changes the memory of the vm, which has to be powered off
+func (vm *Vm) SetRAM(mb uint) error {
+	return SetRAM(mb)(context.Background(), vm)
+}
+
+// Changes the amount of cpus of the vm, which has to be powered off. Fractional cpus are rounded up
+func (vm *Vm) SetCPU(cpu float64) error {
+	cores := uint(math.Ceil(cpu))
+	if cores == 0 {
+		cores = 1
+	}
+	return SetCPU(cores)(context.Background(), vm)
+}
+
 func (vm *Vm) ensureStopped(ctx context.Context) (func(), error) {
 	log.Debug().Msgf("vm: %v", vm)
 	wasRunning := vm.Running
@@ -316,7 +277,7 @@ func (vm *Vm) Snapshot(name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	_, err := VBoxCmdContext(ctx, "snapshot", vm.Id, "take", name)
+	err := vm.hypervisor().TakeSnapshot(ctx, vm, name, false)
 	if err != nil {
 		return err
 	}
@@ -324,17 +285,31 @@ func (vm *Vm) Snapshot(name string) error {
 	return nil
 }
 
+// Takes a snapshot of the vm, including the memory state if the vm is running
+func (vm *Vm) TakeSnapshot(ctx context.Context, name string) error {
+	return vm.hypervisor().TakeSnapshot(ctx, vm, name, vm.Running)
+}
+
+// Restores a snapshot of the vm. The vm has to be powered off
+func (vm *Vm) RestoreSnapshot(ctx context.Context, name string) error {
+	return vm.hypervisor().RestoreSnapshot(ctx, vm, name)
+}
+
+func (vm *Vm) DeleteSnapshot(ctx context.Context, name string) error {
+	return vm.hypervisor().DeleteSnapshot(ctx, vm, name)
+}
+
 func (v *Vm) LinkedClone(ctx context.Context, snapshot string, vmOpts ...VMOpt) (*Vm, error) {
 	newID := strings.Replace(uuid.New().String(), "-", "", -1)
-	_, err := VBoxCmdContext(ctx, "clonevm", v.Id, "--snapshot", snapshot, "--options", "link", "--name", newID, "--register")
-	if err != nil {
+	vm := &Vm{
+		Image:      v.Image,
+		Id:         newID,
+		Hypervisor: v.Hypervisor,
+	}
+	if err := v.hypervisor().Clone(ctx, v, snapshot, vm); err != nil {
 		return nil, err
 	}
 
-	vm := &Vm{
-		Image: v.Image,
-		Id:    newID,
-	}
 	for _, opt := range vmOpts {
 		if err := opt(ctx, vm); err != nil {
 			return nil, err
@@ -348,40 +323,28 @@ func (v *Vm) state() State {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	raw, err := VBoxCmdContext(ctx, vboxShowVMInfo, v.Id)
-	if err != nil {
-		return Error
-	}
-
-	r := regexp.MustCompile(stateRegex)
-	matched := r.FindSubmatch(raw)
-	if len(matched) == 0 {
-		return Error
-	}
-	if strings.Contains(string(matched[0]), "running") {
-		return Running
-	}
-	if strings.Contains(string(matched[0]), "saved") {
-		return Suspended
-	}
-
-	return Stopped
+	return v.hypervisor().State(ctx, v)
 }
 
 func (v *Vm) Info() InstanceInfo {
-	return InstanceInfo{
+	info := InstanceInfo{
 		Image: v.Image,
 		Type:  "vbox",
 		Id:    v.Id,
 		State: v.state(),
 	}
+	if v.Hypervisor != "" && v.Hypervisor != HypervisorVirtualBox {
+		info.Type = v.Hypervisor
+	}
+	return info
 }
 
 func NewLibrary(pwd string) *VboxLibrary {
 	return &VboxLibrary{
-		Pwd:   pwd,
-		Known: make(map[string]*Vm),
-		Locks: make(map[string]*sync.Mutex),
+		Pwd:       pwd,
+		Known:     make(map[string]*Vm),
+		Locks:     make(map[string]*sync.Mutex),
+		checksums: loadChecksumCache(pwd),
 	}
 }
 
@@ -400,54 +363,28 @@ func (lib *VboxLibrary) GetImagePath(file string) string {
 func (lib *VboxLibrary) GetCopy(ctx context.Context, conf InstanceConfig, vmOpts ...VMOpt) (*Vm, error) {
 	path := lib.GetImagePath(conf.Image)
 
-	lib.M.Lock()
-
-	pathLock, ok := lib.Locks[path]
-	if !ok {
-		pathLock = &sync.Mutex{}
-		lib.Locks[path] = pathLock
-	}
+	pathLock := lib.pathLock(path)
 
 	log.Debug().
 		Str("path", path).
-		Bool("first_time", ok == false).
 		Msg("getting path lock")
 
-	lib.M.Unlock()
-
 	pathLock.Lock()
 	defer pathLock.Unlock()
 
+	lib.M.Lock()
 	vm, ok := lib.Known[path]
+	lib.M.Unlock()
 	if ok {
 		return vm.LinkedClone(ctx, "origin", vmOpts...) // if ok==true then VM will be linked without the ram value which is exist on configuration file
 		// vbox.SetRAM(conf.memoryMB) on addFrontend function in lab.go fixes the problem...
 	}
 	// if ok==false, then following codes will be run, in that case there will be no problem because at the end instance returns with specified VMOpts parameter.
-	sum, err := checksumOfFile(path)
+	vm, err := lib.baseVm(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 
-	n := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
-
-	vm, ok = VmExists(n, sum)
-	if !ok {
-		vm = NewVMWithSum(path, n, sum)
-		if err := vm.Create(ctx); err != nil {
-			return nil, err
-		}
-
-		err = vm.Snapshot("origin")
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	lib.M.Lock()
-	lib.Known[path] = vm
-	lib.M.Unlock()
-
 	if conf.CPU != 0 {
 		vmOpts = append(vmOpts, SetCPU(uint(math.Ceil(conf.CPU))))
 	}
@@ -490,21 +427,19 @@ func checksumOfFile(filepath string) (string, error) {
 	return hex.EncodeToString(checksum), nil
 }
 
+// Returns the base vm of the image if it has been imported into the default hypervisor
 func VmExists(image string, checksum string) (*Vm, bool) {
 	name := fmt.Sprintf("%s{%s}", image, checksum)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	out, err := VBoxCmdContext(ctx, "list", "vms")
-	if err != nil {
-		return nil, false
-	}
-
-	if bytes.Contains(out, []byte("\""+name+"\"")) {
+	h := DefaultHypervisor()
+	if h.Exists(ctx, name) {
 		return &Vm{
-			Image: image,
-			Id:    name,
+			Image:      image,
+			Id:         name,
+			Hypervisor: h.Name(),
 		}, true
 	}
 
@@ -612,7 +547,8 @@ func CreateUserFolder(teamId string, eventTag string) error {
 func CreateFolderLink(vm string, envTag string, guacUsername string) error {
 	log.Debug().Msgf("Trying to link shared folder to vm: %s", vm)
 	//todo Figure out a way to add the new folder and general setup of filetransfer folder and how to manage its content.
-	_, err := VBoxCmdContext(context.Background(), "sharedfolder", "add", vm, "--name", "filetransfer", "-hostpath", FileTransferRoot+"/"+envTag+"/"+guacUsername, "-transient", "-automount")
+	v := &Vm{Id: vm, Hypervisor: DefaultHypervisor().Name()}
+	err := v.hypervisor().ShareFolder(context.Background(), v, "filetransfer", FileTransferRoot+"/"+envTag+"/"+guacUsername)
 	if err != nil {
 		log.Warn().Msgf("Error creating shared folder link: %s", err)
 		return err
@@ -622,15 +558,8 @@ func CreateFolderLink(vm string, envTag string, guacUsername string) error {
 
 // Gets the count of running Virtual machines
 func GetRunningVmCount() (uint32, error) {
-	cmd := "vboxmanage list runningvms | wc -l"
-	out, err := exec.Command("bash", "-c", cmd).Output()
-	if err != nil {
-		return 0, err
-	}
-	out = out[:len(out)-1]
-	vmCount, err := strconv.ParseUint(string(out), 10, 32)
-	if err != nil {
-		return 0, err
-	}
-	return uint32(vmCount), nil
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return DefaultHypervisor().RunningCount(ctx)
 }
@@ -21,6 +21,18 @@ const (
 
 type State int
 
+// Frontend types, vbox frontends are virtual machines run by the configured hypervisor
+const (
+	FrontendTypeVbox   = "vbox"
+	FrontendTypeDocker = "docker"
+)
+
+// Remote desktop protocols supported by frontends
+const (
+	ProtocolRDP = "rdp"
+	ProtocolVNC = "vnc"
+)
+
 func (s State) String() string {
     switch s {
 	case Running:
@@ -39,6 +51,11 @@ type InstanceInfo struct {
 	Type  string
 	Id    string
 	State State
+	// Digest of the image, only set for containers
+	Digest string
+	// Health of containers with a health check, and the error of the latest failed check
+	Health      HealthStatus
+	HealthError string
 }
 
 type Instance interface {
@@ -53,7 +70,7 @@ type Instance interface {
 
 type ResourceResizer interface {
 	SetRAM(uint) error
-	SetCPU(uint) error
+	SetCPU(float64) error
 }
 
 func GetAvailablePort() uint {
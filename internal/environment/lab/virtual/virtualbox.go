@@ -0,0 +1,219 @@
+package virtual
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+func init() {
+	RegisterHypervisor(&virtualBox{})
+}
+
+// VirtualBox hypervisor, controlled through VBoxManage
+type virtualBox struct{}
+
+func (vb *virtualBox) Name() string {
+	return HypervisorVirtualBox
+}
+
+func (vb *virtualBox) DisplayProtocols() []string {
+	return []string{ProtocolRDP}
+}
+
+func (vb *virtualBox) Import(ctx context.Context, vm *Vm) error {
+	_, err := VBoxCmdContext(ctx, "import", vm.Path, "--vsys", "0", "--eula", "accept", "--vmname", vm.Id)
+	return err
+}
+
+func (vb *virtualBox) Clone(ctx context.Context, base *Vm, snapshot string, clone *Vm) error {
+	_, err := VBoxCmdContext(ctx, "clonevm", base.Id, "--snapshot", snapshot, "--options", "link", "--name", clone.Id, "--register")
+	return err
+}
+
+func (vb *virtualBox) Exists(ctx context.Context, id string) bool {
+	out, err := VBoxCmdContext(ctx, "list", "vms")
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(out, []byte("\""+id+"\""))
+}
+
+func (vb *virtualBox) Start(ctx context.Context, vm *Vm) error {
+	_, err := VBoxCmdContext(ctx, vboxStartVM, vm.Id, "--type", "headless")
+	if err != nil {
+		return err
+	}
+
+	log.Debug().
+		Str("ID", vm.Id).
+		Msg("Setting resolution for VM")
+	_, err = VBoxCmdContext(ctx, vboxCtrlVM, vm.Id, "setvideomodehint", "1920", "1080", "16")
+	if err != nil {
+		log.Error().Str("ID", vm.Id).Msgf("Error setting resolution, VM may require reset on after connecting: %s", err.Error())
+	}
+	return nil
+}
+
+func (vb *virtualBox) Stop(ctx context.Context, vm *Vm) error {
+	_, err := VBoxCmdContext(ctx, vboxCtrlVM, vm.Id, "poweroff")
+	return err
+}
+
+// Will call savestate on vm
+func (vb *virtualBox) Suspend(ctx context.Context, vm *Vm) error {
+	_, err := VBoxCmdContext(ctx, vboxCtrlVM, vm.Id, "savestate")
+	return err
+}
+
+func (vb *virtualBox) Remove(ctx context.Context, vm *Vm) error {
+	_, err := VBoxCmdContext(ctx, vboxUnregisterVM, vm.Id, "--delete")
+	return err
+}
+
+func (vb *virtualBox) State(ctx context.Context, vm *Vm) State {
+	raw, err := VBoxCmdContext(ctx, vboxShowVMInfo, vm.Id)
+	if err != nil {
+		return Error
+	}
+
+	r := regexp.MustCompile(stateRegex)
+	matched := r.FindSubmatch(raw)
+	if len(matched) == 0 {
+		return Error
+	}
+	if bytes.Contains(matched[0], []byte("running")) {
+		return Running
+	}
+	if bytes.Contains(matched[0], []byte("saved")) {
+		return Suspended
+	}
+
+	return Stopped
+}
+
+func (vb *virtualBox) TakeSnapshot(ctx context.Context, vm *Vm, name string, live bool) error {
+	args := []string{vm.Id, "take", name}
+	if live {
+		args = append(args, "--live")
+	}
+	_, err := VBoxCmdContext(ctx, "snapshot", args...)
+	return err
+}
+
+func (vb *virtualBox) RestoreSnapshot(ctx context.Context, vm *Vm, name string) error {
+	_, err := VBoxCmdContext(ctx, "snapshot", vm.Id, "restore", name)
+	return err
+}
+
+func (vb *virtualBox) DeleteSnapshot(ctx context.Context, vm *Vm, name string) error {
+	_, err := VBoxCmdContext(ctx, "snapshot", vm.Id, "delete", name)
+	return err
+}
+
+func (vb *virtualBox) SetRAM(ctx context.Context, vm *Vm, mb uint) error {
+	_, err := VBoxCmdContext(ctx, vboxModVM, vm.Id, "--memory", fmt.Sprintf("%d", mb))
+	return err
+}
+
+func (vb *virtualBox) SetCPU(ctx context.Context, vm *Vm, cores uint) error {
+	_, err := VBoxCmdContext(ctx, vboxModVM, vm.Id, "--cpus", fmt.Sprintf("%d", cores))
+	return err
+}
+
+func (vb *virtualBox) AttachNIC(ctx context.Context, vm *Vm, iface string) error {
+	// Removes all NIC cards from importing VMs
+	if err := removeAllNICs(ctx, vm); err != nil {
+		return err
+	}
+	// enables specified NIC card in purpose
+	_, err := VBoxCmdContext(ctx, vboxModVM, vm.Id, "--nic1", "bridged", "--bridgeadapter1", iface)
+	if err != nil {
+		return err
+	}
+	// allows promiscuous mode
+	_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--nicpromisc1", "allow-all")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func removeAllNICs(ctx context.Context, vm *Vm) error {
+	result, err := VBoxCmdContext(ctx, vboxShowVMInfo, vm.Id)
+	if err != nil {
+		return err
+	}
+	re := regexp.MustCompile(nicRegex)
+	numberOfNICs := re.FindAll(result, -1)
+	for i := 1; i <= len(numberOfNICs); i++ {
+		_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--nic"+strconv.Itoa(i), "none")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (vb *virtualBox) ExposeDisplay(ctx context.Context, vm *Vm, protocol string, ip string, port uint) error {
+	if protocol != ProtocolRDP {
+		return errors.New("virtualbox only supports rdp")
+	}
+
+	_, err := VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrde", "on")
+	if err != nil {
+		return err
+	}
+
+	_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrdeproperty", fmt.Sprintf("TCP/Address=%s", ip))
+	if err != nil {
+		return err
+	}
+
+	_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrdeproperty", fmt.Sprintf("TCP/Ports=%d", port))
+	if err != nil {
+		return err
+	}
+
+	_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrdeauthtype", "null")
+	if err != nil {
+		return err
+	}
+
+	_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vram", "128")
+	if err != nil {
+		return err
+	}
+
+	_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--clipboard", "bidirectional")
+	if err != nil {
+		return err
+	}
+
+	_, err = VBoxCmdContext(ctx, vboxModVM, vm.Id, "--vrdemulticon", "on")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (vb *virtualBox) ShareFolder(ctx context.Context, vm *Vm, name string, hostPath string) error {
+	_, err := VBoxCmdContext(ctx, "sharedfolder", "add", vm.Id, "--name", name, "-hostpath", hostPath, "-transient", "-automount")
+	return err
+}
+
+// Gets the count of running Virtual machines
+func (vb *virtualBox) RunningCount(ctx context.Context) (uint32, error) {
+	out, err := VBoxCmdContext(ctx, "list", "runningvms")
+	if err != nil {
+		return 0, err
+	}
+	return uint32(bytes.Count(out, []byte("\n"))), nil
+}
@@ -0,0 +1,132 @@
+package environment
+
+import (
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+)
+
+// Takes a lab from the warm lab pool and returns it.
+// Returns nil if the pool is empty.
+func (env *Environment) ClaimWarmLab() *lab.Lab {
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	for tag, l := range env.WarmLabs {
+		delete(env.WarmLabs, tag)
+		return l
+	}
+	return nil
+}
+
+// Adds a newly built lab to the warm lab pool
+func (env *Environment) AddWarmLab(l *lab.Lab) {
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	env.WarmLabs[l.Tag] = l
+}
+
+// Reserves and returns the amount of warm labs that needs to be built to reach the target size of the pool.
+// Every reserved lab must be released with WarmLabBuilt once it has been built or has failed.
+func (env *Environment) ReserveWarmLabs() int {
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	needed := env.EnvConfig.WarmLabs - len(env.WarmLabs) - env.warmLabsBuilding
+	if needed < 0 {
+		return 0
+	}
+	env.warmLabsBuilding += needed
+	return needed
+}
+
+func (env *Environment) WarmLabBuilt() {
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	if env.warmLabsBuilding > 0 {
+		env.warmLabsBuilding--
+	}
+}
+
+// Returns the amount of ready and building labs in the warm lab pool
+func (env *Environment) WarmPoolStatus() (ready, building int) {
+	env.M.RLock()
+	defer env.M.RUnlock()
+
+	return len(env.WarmLabs), env.warmLabsBuilding
+}
+
+// Removes a lab from the environment if it has neither been assigned to a team nor sent to the daemon.
+// Returns false if the lab is in use, in which case it must not be closed.
+func (env *Environment) RemoveUnusedLab(l *lab.Lab) bool {
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	l.M.RLock()
+	unused := !l.Assigned && !l.Reported && l.TeamID == ""
+	l.M.RUnlock()
+	if !unused {
+		return false
+	}
+	delete(env.Labs, l.Tag)
+	return true
+}
+
+// Returns the labs in the environment which has not yet been assigned to a team
+func (env *Environment) UnassignedLabs() []*lab.Lab {
+	var labs []*lab.Lab
+	for _, l := range env.getLabs() {
+		l.M.RLock()
+		if !l.Assigned {
+			labs = append(labs, l)
+		}
+		l.M.RUnlock()
+	}
+	return labs
+}
+
+// Reserves and returns the amount of ready labs that needs to be built to reach the minimum amount of unassigned labs.
+// The amount is capped at limit. Every reserved lab must be released with ReadyLabBuilt.
+func (env *Environment) ReserveReadyLabs(limit int) int {
+	unassigned := len(env.UnassignedLabs())
+
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	needed := env.EnvConfig.MinReadyLabs - unassigned - env.readyLabsBuilding
+	if needed > limit {
+		needed = limit
+	}
+	if needed < 0 {
+		return 0
+	}
+	env.readyLabsBuilding += needed
+	return needed
+}
+
+// Marks an amount of ready labs as being built outside of ReserveReadyLabs, ex. the initial labs of an environment
+func (env *Environment) AddReadyLabsBuilding(n int) {
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	env.readyLabsBuilding += n
+}
+
+func (env *Environment) ReadyLabBuilt() {
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	if env.readyLabsBuilding > 0 {
+		env.readyLabsBuilding--
+	}
+}
+
+// Returns the amount of unassigned and building labs in a beginner environment
+func (env *Environment) ReadyPoolStatus() (ready, building int) {
+	ready = len(env.UnassignedLabs())
+
+	env.M.RLock()
+	defer env.M.RUnlock()
+
+	return ready, env.readyLabsBuilding
+}
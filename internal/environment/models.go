@@ -3,6 +3,7 @@ package environment
 import (
 	"net/http"
 	"sync"
+	"time"
 
 	wgproto "github.com/aau-network-security/gwireguard/proto" //v1.0.3
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
@@ -18,6 +19,21 @@ type EnvPool struct {
 	Envs         map[string]*Environment
 	StartingEnvs map[string]bool
 	ClosingEnvs  map[string]bool
+	// Map of scheduled start, freeze and teardown times with eventTag as key
+	Schedules map[string]*Schedule
+}
+
+// Schedule for an environment, executed by the agent so the daemon does not need to be online at the given times.
+// A zero time means the step is not scheduled.
+type Schedule struct {
+	EventTag   string
+	StartAt    time.Time
+	FreezeAt   time.Time
+	TeardownAt time.Time
+	Started    bool
+	Frozen     bool
+	// Serialized create environment request used to create the environment at StartAt
+	Request []byte
 }
 
 type Environment struct {
@@ -31,7 +47,16 @@ type Environment struct {
 	GuacUserStore *GuacUserStore
 	Dockerhost    virtual.Host
 	Labs          map[string]*lab.Lab
+	// Pre-built labs waiting to be claimed by CreateLabForEnv
+	WarmLabs map[string]*lab.Lab
 	// Fill out rest when starting to make labs
+
+	// Cache of wireguard public keys for the peers of each VPN lab
+	vpnPeerKeys map[string][]string
+	// Amount of warm labs currently being built
+	warmLabsBuilding int
+	// Amount of ready labs for beginner environments currently being built
+	readyLabsBuilding int
 }
 
 type Status uint8
@@ -41,6 +66,7 @@ const (
 	StatusUpdating
 	StatusClosing
 	StatusClosed
+	StatusSuspended
 )
 
 type EnvConfig struct {
@@ -53,6 +79,17 @@ type EnvConfig struct {
 	WorkerPool      worker.WorkerPool
 	LabConf         lab.LabConf
 	Status          Status
+	LabTTL          time.Duration
+	IdleTimeout     time.Duration
+	// Target size of the warm lab pool, only used for advanced environments
+	WarmLabs int
+	// Minimum and maximum amount of unassigned labs kept ready, only used for beginner environments
+	MinReadyLabs int
+	MaxReadyLabs int
+	// Allows team members to access the frontends of their teammates
+	SharedScreens bool
+	// Set once the environment has been frozen, exercises are not started again afterwards
+	Frozen bool
 }
 
 type Category struct {
@@ -166,6 +203,7 @@ type CreateRDPConnOpts struct {
 	Host             string
 	Port             uint
 	Name             string
+	Protocol         string // rdp (default) or vnc
 	GuacUser         string
 	Username         *string
 	Password         *string
@@ -0,0 +1,93 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
+)
+
+var ErrNoReadyLab = errors.New("no ready lab available")
+
+// Returns the lab assigned to a team
+func (env *Environment) GetLabByTeam(teamID string) (*lab.Lab, error) {
+	env.M.RLock()
+	defer env.M.RUnlock()
+	return env.labByTeam(teamID)
+}
+
+// The caller must hold the environment lock
+func (env *Environment) labByTeam(teamID string) (*lab.Lab, error) {
+	for _, l := range env.Labs {
+		l.M.RLock()
+		owner := l.TeamID
+		l.M.RUnlock()
+		if owner == teamID {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find lab for team: %s", teamID)
+}
+
+// Binds a team to a lab. If labTag is empty an unassigned lab is claimed, otherwise the specific lab is used.
+// If the team already has a lab, that lab is returned. The returned bool is true if the team was newly assigned.
+// Returns ErrNoReadyLab if labTag is empty and there are no unassigned labs.
+func (env *Environment) AssignLab(teamID, labTag string) (*lab.Lab, bool, error) {
+	if teamID == "" {
+		return nil, false, errors.New("team id cannot be empty")
+	}
+	// Looking up and binding under the same lock, so concurrent calls for a team cannot bind two labs
+	env.M.Lock()
+	defer env.M.Unlock()
+
+	if l, err := env.labByTeam(teamID); err == nil {
+		return l, false, nil
+	}
+
+	var target *lab.Lab
+	if labTag != "" {
+		l, ok := env.Labs[labTag]
+		if !ok {
+			return nil, false, fmt.Errorf("could not find lab with tag: %s", labTag)
+		}
+		l.M.RLock()
+		owner := l.TeamID
+		l.M.RUnlock()
+		if owner != "" {
+			return nil, false, fmt.Errorf("lab %s is already assigned to team: %s", labTag, owner)
+		}
+		target = l
+	} else {
+		for _, l := range env.Labs {
+			l.M.RLock()
+			ready := l.TeamID == "" && !l.Assigned
+			l.M.RUnlock()
+			if ready {
+				target = l
+				break
+			}
+		}
+	}
+	if target == nil {
+		return nil, false, ErrNoReadyLab
+	}
+
+	target.M.Lock()
+	target.TeamID = teamID
+	target.Assigned = true
+	target.M.Unlock()
+	return target, true, nil
+}
+
+// Removes the binding between a team and its lab.
+// The lab is still marked as assigned, so it will not be handed out to another team as a fresh lab.
+func (env *Environment) ReleaseLab(teamID string) (*lab.Lab, error) {
+	l, err := env.GetLabByTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+	l.M.Lock()
+	l.TeamID = ""
+	l.M.Unlock()
+	return l, nil
+}
@@ -0,0 +1,723 @@
+package simulation
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// Docker is an in-memory docker daemon, which keeps track of containers, networks and pulled images
+type Docker struct {
+	sim        *Simulator
+	m          sync.Mutex
+	containers map[string]*docker.Container
+	networks   map[string]*docker.Network
+	images     map[string]*docker.Image
+	execs      map[string]*docker.ExecInspect
+	// Containers whose services fail health checks
+	unhealthy map[string]bool
+}
+
+func newDocker(sim *Simulator) *Docker {
+	d := &Docker{
+		sim:        sim,
+		containers: make(map[string]*docker.Container),
+		networks:   make(map[string]*docker.Network),
+		images:     make(map[string]*docker.Image),
+		execs:      make(map[string]*docker.ExecInspect),
+		unhealthy:  make(map[string]bool),
+	}
+	// Containers are connected to the default bridge network when created, like with a real daemon
+	bridge := &docker.Network{
+		Name:       "bridge",
+		ID:         sim.randomId(32),
+		Driver:     "bridge",
+		Containers: make(map[string]docker.Endpoint),
+	}
+	d.networks[bridge.ID] = bridge
+	return d
+}
+
+// Returns the amount of containers which currently exist
+func (d *Docker) ContainerCount() int {
+	d.m.Lock()
+	defer d.m.Unlock()
+	return len(d.containers)
+}
+
+// Returns the amount of networks which currently exist, including the default bridge network
+func (d *Docker) NetworkCount() int {
+	d.m.Lock()
+	defer d.m.Unlock()
+	return len(d.networks)
+}
+
+func (d *Docker) CreateContainer(opts docker.CreateContainerOptions) (*docker.Container, error) {
+	if err := d.sim.step("docker.CreateContainer"); err != nil {
+		return nil, err
+	}
+	if opts.Config == nil {
+		return nil, fmt.Errorf("missing container config")
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+	img, ok := d.images[withTag(opts.Config.Image)]
+	if !ok {
+		return nil, docker.ErrNoSuchImage
+	}
+	for _, c := range d.containers {
+		if opts.Name != "" && c.Name == opts.Name {
+			return nil, docker.ErrContainerAlreadyExists
+		}
+	}
+
+	c := &docker.Container{
+		ID:              d.sim.randomId(32),
+		Name:            opts.Name,
+		Created:         time.Now(),
+		Image:           img.ID,
+		Config:          opts.Config,
+		HostConfig:      opts.HostConfig,
+		State:           docker.State{Status: "created"},
+		NetworkSettings: &docker.NetworkSettings{Networks: make(map[string]docker.ContainerNetwork)},
+	}
+	d.containers[c.ID] = c
+	d.connect(d.networkByName("bridge"), c, nil)
+
+	return copyContainer(c), nil
+}
+
+func (d *Docker) StartContainerWithContext(id string, hostConfig *docker.HostConfig, ctx context.Context) error {
+	if err := d.sim.step("docker.StartContainer"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	c, ok := d.containers[id]
+	if !ok {
+		return &docker.NoSuchContainer{ID: id}
+	}
+	if c.State.Running {
+		return &docker.ContainerAlreadyRunning{ID: id}
+	}
+	c.State = docker.State{Status: "running", Running: true, StartedAt: time.Now()}
+	return nil
+}
+
+func (d *Docker) StopContainer(id string, timeout uint) error {
+	if err := d.sim.step("docker.StopContainer"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	c, ok := d.containers[id]
+	if !ok {
+		return &docker.NoSuchContainer{ID: id}
+	}
+	if !c.State.Running {
+		return &docker.ContainerNotRunning{ID: id}
+	}
+	c.State = docker.State{Status: "exited", StartedAt: c.State.StartedAt, FinishedAt: time.Now()}
+	return nil
+}
+
+func (d *Docker) PauseContainer(id string) error {
+	if err := d.sim.step("docker.PauseContainer"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	c, ok := d.containers[id]
+	if !ok {
+		return &docker.NoSuchContainer{ID: id}
+	}
+	if !c.State.Running || c.State.Paused {
+		return &docker.ContainerNotRunning{ID: id}
+	}
+	c.State.Paused = true
+	c.State.Status = "paused"
+	return nil
+}
+
+func (d *Docker) UnpauseContainer(id string) error {
+	if err := d.sim.step("docker.UnpauseContainer"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	c, ok := d.containers[id]
+	if !ok {
+		return &docker.NoSuchContainer{ID: id}
+	}
+	if !c.State.Paused {
+		return fmt.Errorf("container %s is not paused", id)
+	}
+	c.State.Paused = false
+	c.State.Status = "running"
+	return nil
+}
+
+func (d *Docker) RemoveContainer(opts docker.RemoveContainerOptions) error {
+	if err := d.sim.step("docker.RemoveContainer"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	c, ok := d.containers[opts.ID]
+	if !ok {
+		return &docker.NoSuchContainer{ID: opts.ID}
+	}
+	if c.State.Running && !opts.Force {
+		return fmt.Errorf("cannot remove running container %s, stop the container before removing or force remove", opts.ID)
+	}
+	for _, n := range d.networks {
+		delete(n.Containers, c.ID)
+	}
+	for id, exec := range d.execs {
+		if exec.ContainerID == c.ID {
+			delete(d.execs, id)
+		}
+	}
+	delete(d.unhealthy, c.ID)
+	delete(d.containers, c.ID)
+	return nil
+}
+
+func (d *Docker) InspectContainer(id string) (*docker.Container, error) {
+	if err := d.sim.step("docker.InspectContainer"); err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	c, ok := d.containers[id]
+	if !ok {
+		return nil, &docker.NoSuchContainer{ID: id}
+	}
+	return copyContainer(c), nil
+}
+
+func (d *Docker) UpdateContainer(id string, opts docker.UpdateContainerOptions) error {
+	if err := d.sim.step("docker.UpdateContainer"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	c, ok := d.containers[id]
+	if !ok {
+		return &docker.NoSuchContainer{ID: id}
+	}
+	hostConf := docker.HostConfig{}
+	if c.HostConfig != nil {
+		hostConf = *c.HostConfig
+	}
+	if opts.Memory != 0 {
+		hostConf.Memory = int64(opts.Memory)
+		hostConf.MemorySwap = int64(opts.MemorySwap)
+	}
+	if opts.CPUQuota != 0 {
+		hostConf.CPUPeriod = int64(opts.CPUPeriod)
+		hostConf.CPUQuota = int64(opts.CPUQuota)
+	}
+	c.HostConfig = &hostConf
+	return nil
+}
+
+// Only the status and label filters are supported
+func (d *Docker) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	if err := d.sim.step("docker.ListContainers"); err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	var containers []docker.APIContainers
+	for _, c := range d.containers {
+		if !opts.All && len(opts.Filters["status"]) == 0 && !c.State.Running {
+			continue
+		}
+		if !matchesFilter(opts.Filters["status"], c.State.Status) || !matchesLabels(opts.Filters["label"], c.Config.Labels) {
+			continue
+		}
+		containers = append(containers, docker.APIContainers{
+			ID:      c.ID,
+			Image:   c.Config.Image,
+			Created: c.Created.Unix(),
+			State:   c.State.Status,
+			Names:   []string{"/" + c.Name},
+			Labels:  c.Config.Labels,
+		})
+	}
+	return containers, nil
+}
+
+func (d *Docker) CreateNetwork(opts docker.CreateNetworkOptions) (*docker.Network, error) {
+	if err := d.sim.step("docker.CreateNetwork"); err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	n := &docker.Network{
+		Name:       opts.Name,
+		ID:         d.sim.randomId(32),
+		Driver:     opts.Driver,
+		Internal:   opts.Internal,
+		Labels:     opts.Labels,
+		Containers: make(map[string]docker.Endpoint),
+	}
+	if opts.IPAM != nil {
+		for _, conf := range opts.IPAM.Config {
+			for _, other := range d.networks {
+				for _, otherConf := range other.IPAM.Config {
+					if conf.Subnet != "" && conf.Subnet == otherConf.Subnet {
+						return nil, fmt.Errorf("pool overlaps with other one on this address space")
+					}
+				}
+			}
+		}
+		n.IPAM = *opts.IPAM
+	}
+	d.networks[n.ID] = n
+	return copyNetwork(n), nil
+}
+
+func (d *Docker) RemoveNetwork(id string) error {
+	if err := d.sim.step("docker.RemoveNetwork"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	n := d.network(id)
+	if n == nil {
+		return &docker.NoSuchNetwork{ID: id}
+	}
+	if len(n.Containers) > 0 {
+		return fmt.Errorf("error while removing network: network %s has active endpoints", id)
+	}
+	delete(d.networks, n.ID)
+	return nil
+}
+
+func (d *Docker) NetworkInfo(id string) (*docker.Network, error) {
+	if err := d.sim.step("docker.NetworkInfo"); err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	n := d.network(id)
+	if n == nil {
+		return nil, &docker.NoSuchNetwork{ID: id}
+	}
+	return copyNetwork(n), nil
+}
+
+func (d *Docker) ListNetworks() ([]docker.Network, error) {
+	if err := d.sim.step("docker.ListNetworks"); err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	var networks []docker.Network
+	for _, n := range d.networks {
+		networks = append(networks, *copyNetwork(n))
+	}
+	return networks, nil
+}
+
+func (d *Docker) ConnectNetwork(id string, opts docker.NetworkConnectionOptions) error {
+	if err := d.sim.step("docker.ConnectNetwork"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	n := d.network(id)
+	c, ok := d.containers[opts.Container]
+	if n == nil || !ok {
+		return &docker.NoSuchNetworkOrContainer{NetworkID: id, ContainerID: opts.Container}
+	}
+	if _, ok := n.Containers[c.ID]; ok {
+		return fmt.Errorf("endpoint with name %s already exists in network %s", c.Name, n.Name)
+	}
+	d.connect(n, c, opts.EndpointConfig)
+	return nil
+}
+
+func (d *Docker) DisconnectNetwork(id string, opts docker.NetworkConnectionOptions) error {
+	if err := d.sim.step("docker.DisconnectNetwork"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	n := d.network(id)
+	c, ok := d.containers[opts.Container]
+	if n == nil || !ok {
+		return &docker.NoSuchNetworkOrContainer{NetworkID: id, ContainerID: opts.Container}
+	}
+	if _, ok := n.Containers[c.ID]; !ok {
+		return fmt.Errorf("container %s is not connected to network %s", c.ID, n.Name)
+	}
+	delete(n.Containers, c.ID)
+	delete(c.NetworkSettings.Networks, n.Name)
+	return nil
+}
+
+func (d *Docker) InspectImage(name string) (*docker.Image, error) {
+	if err := d.sim.step("docker.InspectImage"); err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	img, ok := d.images[withTag(name)]
+	if !ok {
+		return nil, docker.ErrNoSuchImage
+	}
+	cp := *img
+	return &cp, nil
+}
+
+// Every image can be pulled, also by digest. The digest of the image is the same as the one returned by the simulated registry
+func (d *Docker) PullImage(opts docker.PullImageOptions, auth docker.AuthConfiguration) error {
+	if err := d.sim.step("docker.PullImage"); err != nil {
+		return err
+	}
+	tag := opts.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	name := opts.Repository + ":" + tag
+	digest := imageDigest(registryRepo(opts.Repository), tag)
+	// Pulls by digest are given the digest as tag
+	if strings.HasPrefix(tag, "sha256:") {
+		name, digest = opts.Repository+"@"+tag, tag
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.images[name] = &docker.Image{
+		ID:          digest,
+		RepoTags:    []string{name},
+		RepoDigests: []string{opts.Repository + "@" + digest},
+		Created:     time.Now(),
+		Size:        simulatedImageSize,
+	}
+	if opts.OutputStream != nil {
+		if opts.RawJSONStream {
+			fmt.Fprintf(opts.OutputStream, "{\"status\":\"Pulling from %s\",\"id\":\"%s\"}\n", opts.Repository, tag)
+			fmt.Fprintf(opts.OutputStream, "{\"status\":\"Digest: %s\"}\n", digest)
+		} else {
+			fmt.Fprintf(opts.OutputStream, "%s: Pulling from %s\nDigest: %s\n", tag, opts.Repository, digest)
+		}
+	}
+	return nil
+}
+
+func (d *Docker) TagImage(name string, opts docker.TagImageOptions) error {
+	if err := d.sim.step("docker.TagImage"); err != nil {
+		return err
+	}
+	tag := opts.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	target := opts.Repo + ":" + tag
+
+	d.m.Lock()
+	defer d.m.Unlock()
+	img, ok := d.images[withTag(name)]
+	if !ok {
+		return docker.ErrNoSuchImage
+	}
+	if _, ok := d.images[target]; ok && !opts.Force {
+		return fmt.Errorf("conflict: tag %s is already in use", target)
+	}
+	img.RepoTags = append(img.RepoTags, target)
+	d.images[target] = img
+	return nil
+}
+
+// Lists the pulled images, with all names of an image combined
+func (d *Docker) ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error) {
+	if err := d.sim.step("docker.ListImages"); err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	byId := make(map[string]*docker.APIImages)
+	var ids []string
+	for name, img := range d.images {
+		apiImg, ok := byId[img.ID]
+		if !ok {
+			apiImg = &docker.APIImages{
+				ID:      img.ID,
+				Created: img.Created.Unix(),
+				Size:    img.Size,
+			}
+			byId[img.ID] = apiImg
+			ids = append(ids, img.ID)
+		}
+		if strings.Contains(name, "@") {
+			apiImg.RepoDigests = append(apiImg.RepoDigests, name)
+		} else {
+			apiImg.RepoTags = append(apiImg.RepoTags, name)
+		}
+	}
+	sort.Strings(ids)
+
+	var images []docker.APIImages
+	for _, id := range ids {
+		sort.Strings(byId[id].RepoTags)
+		images = append(images, *byId[id])
+	}
+	return images, nil
+}
+
+// Removes an image by id, or a single name of an image. Images used by containers can only be removed
+// with force, and never if the container is running
+func (d *Docker) RemoveImageExtended(name string, opts docker.RemoveImageOptions) error {
+	if err := d.sim.step("docker.RemoveImage"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	var id string
+	if img, ok := d.images[withTag(name)]; ok {
+		id = img.ID
+	} else {
+		for _, img := range d.images {
+			if img.ID == name {
+				id = img.ID
+			}
+		}
+	}
+	if id == "" {
+		return docker.ErrNoSuchImage
+	}
+
+	for _, c := range d.containers {
+		if c.Image != id {
+			continue
+		}
+		if c.State.Running || !opts.Force {
+			return fmt.Errorf("conflict: unable to remove image %s, it is being used by container %s", name, c.ID[:12])
+		}
+	}
+
+	if id != name {
+		delete(d.images, withTag(name))
+		return nil
+	}
+	for n, img := range d.images {
+		if img.ID == id {
+			delete(d.images, n)
+		}
+	}
+	return nil
+}
+
+// Makes health checks of the container fail while it is running, like a crashed service would
+func (d *Docker) SetUnhealthy(id string, unhealthy bool) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.unhealthy[id] = unhealthy
+}
+
+func (d *Docker) CreateExec(opts docker.CreateExecOptions) (*docker.Exec, error) {
+	if err := d.sim.step("docker.CreateExec"); err != nil {
+		return nil, err
+	}
+	if len(opts.Cmd) == 0 {
+		return nil, fmt.Errorf("no exec command specified")
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	c, ok := d.containers[opts.Container]
+	if !ok {
+		return nil, &docker.NoSuchContainer{ID: opts.Container}
+	}
+	if !c.State.Running || c.State.Paused {
+		return nil, &docker.ContainerNotRunning{ID: opts.Container}
+	}
+
+	exec := &docker.ExecInspect{
+		ID:          d.sim.randomId(32),
+		ContainerID: c.ID,
+		ProcessConfig: docker.ExecProcessConfig{
+			EntryPoint: opts.Cmd[0],
+			Arguments:  opts.Cmd[1:],
+		},
+	}
+	d.execs[exec.ID] = exec
+	return &docker.Exec{ID: exec.ID}, nil
+}
+
+// Runs an exec by checking the container it targets, which is the container with an ip given in the arguments
+// or else the container the exec was created in. Fails if the target is not running or has been made unhealthy
+func (d *Docker) StartExec(id string, opts docker.StartExecOptions) error {
+	if err := d.sim.step("docker.StartExec"); err != nil {
+		return err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	exec, ok := d.execs[id]
+	if !ok {
+		return &docker.NoSuchExec{ID: id}
+	}
+
+	target := exec.ContainerID
+	for _, n := range d.networks {
+		for cid, endpoint := range n.Containers {
+			if endpoint.IPv4Address == "" {
+				continue
+			}
+			for _, arg := range exec.ProcessConfig.Arguments {
+				if arg == endpoint.IPv4Address || strings.Contains(arg, "//"+endpoint.IPv4Address+":") {
+					target = cid
+				}
+			}
+		}
+	}
+
+	c, ok := d.containers[target]
+	healthy := ok && c.State.Running && !c.State.Paused && !d.unhealthy[target]
+	exec.Running = false
+	if !healthy {
+		exec.ExitCode = 1
+		if opts.ErrorStream != nil {
+			fmt.Fprintln(opts.ErrorStream, "simulated failure")
+		}
+		return nil
+	}
+	// Wget prints the response headers with -S, which the http health checks read the status from
+	if exec.ProcessConfig.EntryPoint == "wget" && opts.ErrorStream != nil {
+		fmt.Fprintln(opts.ErrorStream, "  HTTP/1.1 200 OK")
+	}
+	return nil
+}
+
+func (d *Docker) InspectExec(id string) (*docker.ExecInspect, error) {
+	if err := d.sim.step("docker.InspectExec"); err != nil {
+		return nil, err
+	}
+	d.m.Lock()
+	defer d.m.Unlock()
+	exec, ok := d.execs[id]
+	if !ok {
+		return nil, &docker.NoSuchExec{ID: id}
+	}
+	cp := *exec
+	return &cp, nil
+}
+
+// Has to be called with the lock held
+func (d *Docker) connect(n *docker.Network, c *docker.Container, conf *docker.EndpointConfig) {
+	endpoint := docker.ContainerNetwork{
+		NetworkID:  n.ID,
+		EndpointID: d.sim.randomId(32),
+	}
+	if conf != nil {
+		endpoint.Aliases = conf.Aliases
+		if conf.IPAMConfig != nil {
+			endpoint.IPAddress = conf.IPAMConfig.IPv4Address
+		}
+	}
+	n.Containers[c.ID] = docker.Endpoint{
+		Name:        c.Name,
+		ID:          endpoint.EndpointID,
+		IPv4Address: endpoint.IPAddress,
+	}
+	c.NetworkSettings.Networks[n.Name] = endpoint
+}
+
+// Looks up a network by id or name, like the docker daemon does. Has to be called with the lock held
+func (d *Docker) network(id string) *docker.Network {
+	if n, ok := d.networks[id]; ok {
+		return n
+	}
+	return d.networkByName(id)
+}
+
+func (d *Docker) networkByName(name string) *docker.Network {
+	for _, n := range d.networks {
+		if n.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+func copyContainer(c *docker.Container) *docker.Container {
+	cp := *c
+	settings := *c.NetworkSettings
+	settings.Networks = make(map[string]docker.ContainerNetwork)
+	for k, v := range c.NetworkSettings.Networks {
+		settings.Networks[k] = v
+	}
+	cp.NetworkSettings = &settings
+	return &cp
+}
+
+func copyNetwork(n *docker.Network) *docker.Network {
+	cp := *n
+	cp.Containers = make(map[string]docker.Endpoint)
+	for k, v := range n.Containers {
+		cp.Containers[k] = v
+	}
+	return &cp
+}
+
+func matchesFilter(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Labels are matched as key or key=value
+func matchesLabels(filters []string, labels map[string]string) bool {
+	for _, f := range filters {
+		parts := strings.SplitN(f, "=", 2)
+		v, ok := labels[parts[0]]
+		if !ok || (len(parts) == 2 && v != parts[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes the registry from a repository the same way the agent does when parsing images, and the library/
+// prefix of official Docker Hub images
+func registryRepo(repo string) string {
+	if strings.Count(repo, "/") > 1 {
+		repo = strings.Join(strings.Split(repo, "/")[1:], "/")
+	}
+	return strings.TrimPrefix(repo, "library/")
+}
+
+// Adds the latest tag to an image without a tag
+func withTag(image string) string {
+	if strings.Contains(image[strings.LastIndex(image, "/")+1:], ":") {
+		return image
+	}
+	return image + ":latest"
+}
+
+// Size of every pulled image
+const simulatedImageSize = 100 << 20
+
+func imageDigest(repo string, tag string) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(repo+":"+tag)))
+}
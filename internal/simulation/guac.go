@@ -0,0 +1,250 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+)
+
+const guacPrefix = "/guacamole/api"
+
+// Guacamole simulates the REST API of the guacamole instances of environments.
+// Instances are told apart by the host and port the requests are sent to.
+type Guacamole struct {
+	sim       *Simulator
+	m         sync.Mutex
+	instances map[string]*guacInstance
+	active    map[string]bool
+}
+
+type guacInstance struct {
+	adminPass   string
+	tokens      map[string]bool
+	users       map[string]string
+	connections map[string]json.RawMessage
+	permissions map[string]map[string]bool
+	nextConnId  int
+}
+
+func newGuacamole(sim *Simulator) *Guacamole {
+	return &Guacamole{
+		sim:       sim,
+		instances: make(map[string]*guacInstance),
+		active:    make(map[string]bool),
+	}
+}
+
+// Marks a user as having an active connection in all instances the user exists in
+func (g *Guacamole) SetActive(username string, active bool) {
+	g.m.Lock()
+	defer g.m.Unlock()
+	if active {
+		g.active[username] = true
+		return
+	}
+	delete(g.active, username)
+}
+
+// Returns the amount of users and connections across all instances
+func (g *Guacamole) Counts() (users int, connections int) {
+	g.m.Lock()
+	defer g.m.Unlock()
+	for _, inst := range g.instances {
+		users += len(inst.users)
+		connections += len(inst.connections)
+	}
+	return users, connections
+}
+
+func (g *Guacamole) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := strings.TrimPrefix(req.URL.Path, guacPrefix)
+	if err := g.sim.step("guacamole." + req.Method + " " + guacOperation(path)); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	g.m.Lock()
+	defer g.m.Unlock()
+	inst, ok := g.instances[req.URL.Host]
+	if !ok {
+		inst = &guacInstance{
+			adminPass:   environment.DefaultAdminPass,
+			tokens:      make(map[string]bool),
+			users:       make(map[string]string),
+			connections: make(map[string]json.RawMessage),
+			permissions: make(map[string]map[string]bool),
+		}
+		g.instances[req.URL.Host] = inst
+	}
+
+	if path == "/tokens" && req.Method == http.MethodPost {
+		return g.login(req, inst, body), nil
+	}
+	if !inst.tokens[req.URL.Query().Get("token")] {
+		return guacResponse(req, http.StatusForbidden, map[string]string{"message": "Permission Denied."}), nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, "/session/data/mysql/"), "/")
+	switch {
+	case req.Method == http.MethodPost && len(parts) == 1 && parts[0] == "users":
+		var user struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		if err := json.Unmarshal(body, &user); err != nil {
+			return guacResponse(req, http.StatusBadRequest, map[string]string{"message": err.Error()}), nil
+		}
+		if _, ok := inst.users[user.Username]; ok || user.Username == environment.DefaultAdminUser {
+			return guacResponse(req, http.StatusBadRequest, map[string]string{"message": fmt.Sprintf("User %q already exists.", user.Username)}), nil
+		}
+		inst.users[user.Username] = user.Password
+		return guacResponse(req, http.StatusOK, user), nil
+
+	case req.Method == http.MethodDelete && len(parts) == 2 && parts[0] == "users":
+		if _, ok := inst.users[parts[1]]; !ok {
+			return guacResponse(req, http.StatusNotFound, map[string]string{"message": "No such user."}), nil
+		}
+		delete(inst.users, parts[1])
+		delete(inst.permissions, parts[1])
+		return guacResponse(req, http.StatusNoContent, nil), nil
+
+	case req.Method == http.MethodPut && len(parts) == 3 && parts[0] == "users" && parts[2] == "password":
+		var passwords struct {
+			OldPassword string `json:"oldPassword"`
+			NewPassword string `json:"newPassword"`
+		}
+		if err := json.Unmarshal(body, &passwords); err != nil {
+			return guacResponse(req, http.StatusBadRequest, map[string]string{"message": err.Error()}), nil
+		}
+		if parts[1] == environment.DefaultAdminUser {
+			inst.adminPass = passwords.NewPassword
+		} else {
+			inst.users[parts[1]] = passwords.NewPassword
+		}
+		return guacResponse(req, http.StatusNoContent, nil), nil
+
+	case req.Method == http.MethodPatch && len(parts) == 3 && parts[0] == "users" && parts[2] == "permissions":
+		var patches []struct {
+			Operation string `json:"op"`
+			Path      string `json:"path"`
+		}
+		if err := json.Unmarshal(body, &patches); err != nil {
+			return guacResponse(req, http.StatusBadRequest, map[string]string{"message": err.Error()}), nil
+		}
+		for _, p := range patches {
+			id := strings.TrimPrefix(p.Path, "/connectionPermissions/")
+			if _, ok := inst.connections[id]; !ok {
+				return guacResponse(req, http.StatusNotFound, map[string]string{"message": "No such connection."}), nil
+			}
+			if inst.permissions[parts[1]] == nil {
+				inst.permissions[parts[1]] = make(map[string]bool)
+			}
+			if p.Operation == "remove" {
+				delete(inst.permissions[parts[1]], id)
+				continue
+			}
+			inst.permissions[parts[1]][id] = true
+		}
+		return guacResponse(req, http.StatusNoContent, nil), nil
+
+	case req.Method == http.MethodPost && len(parts) == 1 && parts[0] == "connections":
+		var conn struct {
+			Parameters json.RawMessage `json:"parameters"`
+		}
+		if err := json.Unmarshal(body, &conn); err != nil {
+			return guacResponse(req, http.StatusBadRequest, map[string]string{"message": err.Error()}), nil
+		}
+		inst.nextConnId++
+		id := strconv.Itoa(inst.nextConnId)
+		inst.connections[id] = conn.Parameters
+		return guacResponse(req, http.StatusOK, map[string]string{"identifier": id}), nil
+
+	case req.Method == http.MethodDelete && len(parts) == 2 && parts[0] == "connections":
+		if _, ok := inst.connections[parts[1]]; !ok {
+			return guacResponse(req, http.StatusNotFound, map[string]string{"message": "No such connection."}), nil
+		}
+		delete(inst.connections, parts[1])
+		for _, perms := range inst.permissions {
+			delete(perms, parts[1])
+		}
+		return guacResponse(req, http.StatusNoContent, nil), nil
+
+	case req.Method == http.MethodGet && len(parts) == 3 && parts[0] == "connections" && parts[2] == "parameters":
+		params, ok := inst.connections[parts[1]]
+		if !ok {
+			return guacResponse(req, http.StatusNotFound, map[string]string{"message": "No such connection."}), nil
+		}
+		return guacResponse(req, http.StatusOK, params), nil
+
+	case req.Method == http.MethodGet && len(parts) == 1 && parts[0] == "activeConnections":
+		conns := make(map[string]map[string]string)
+		for user := range inst.users {
+			if g.active[user] {
+				conns[fmt.Sprintf("%s-%d", user, len(conns))] = map[string]string{"username": user}
+			}
+		}
+		return guacResponse(req, http.StatusOK, conns), nil
+	}
+
+	return guacResponse(req, http.StatusNotFound, map[string]string{"message": "Not found."}), nil
+}
+
+// Has to be called with the lock held
+func (g *Guacamole) login(req *http.Request, inst *guacInstance, body []byte) *http.Response {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return guacResponse(req, http.StatusBadRequest, map[string]string{"message": err.Error()})
+	}
+	username, password := form.Get("username"), form.Get("password")
+
+	expected, ok := inst.users[username]
+	if username == environment.DefaultAdminUser {
+		expected, ok = inst.adminPass, true
+	}
+	if !ok || expected != password {
+		return guacResponse(req, http.StatusForbidden, map[string]string{"message": "Invalid login."})
+	}
+
+	token := strings.ToUpper(g.sim.randomId(32))
+	inst.tokens[token] = true
+	return guacResponse(req, http.StatusOK, map[string]string{
+		"authToken":  token,
+		"username":   username,
+		"dataSource": "mysql",
+	})
+}
+
+// Name of the api endpoint without identifiers, used for naming the simulated operation
+func guacOperation(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, "/session/data/mysql/"), "/")
+	if len(parts) > 1 {
+		parts[1] = "*"
+	}
+	return strings.Join(parts, "/")
+}
+
+func guacResponse(req *http.Request, status int, v interface{}) *http.Response {
+	header := make(http.Header)
+	if v == nil {
+		return response(req, status, header, "")
+	}
+	header.Set("Content-Type", "application/json")
+	body, _ := json.Marshal(v)
+	return response(req, status, header, string(body))
+}
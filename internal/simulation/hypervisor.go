@@ -0,0 +1,276 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+)
+
+// Name the simulated hypervisor is registered with
+const HypervisorName = "simulation"
+
+// Hypervisor keeps track of simulated virtual machines instead of running them
+type Hypervisor struct {
+	sim *Simulator
+	m   sync.Mutex
+	vms map[string]*simVm
+}
+
+type simVm struct {
+	state     virtual.State
+	base      string
+	snapshots map[string]bool
+	memoryMB  uint
+	cpus      uint
+	nic       string
+	display   string
+	folders   map[string]string
+}
+
+func newHypervisor(sim *Simulator) *Hypervisor {
+	return &Hypervisor{
+		sim: sim,
+		vms: make(map[string]*simVm),
+	}
+}
+
+// Returns the amount of vms which currently exist, including imported base vms
+func (h *Hypervisor) VmCount() int {
+	h.m.Lock()
+	defer h.m.Unlock()
+	return len(h.vms)
+}
+
+func (h *Hypervisor) Name() string {
+	return HypervisorName
+}
+
+func (h *Hypervisor) DisplayProtocols() []string {
+	return []string{virtual.ProtocolRDP, virtual.ProtocolVNC}
+}
+
+func (h *Hypervisor) Import(ctx context.Context, vm *virtual.Vm) error {
+	if err := h.sim.step("hypervisor.Import"); err != nil {
+		return err
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+	if _, ok := h.vms[vm.Id]; ok {
+		return fmt.Errorf("vm already exists: %s", vm.Id)
+	}
+	h.vms[vm.Id] = newSimVm("")
+	return nil
+}
+
+func (h *Hypervisor) Clone(ctx context.Context, base *virtual.Vm, snapshot string, clone *virtual.Vm) error {
+	if err := h.sim.step("hypervisor.Clone"); err != nil {
+		return err
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+	b, err := h.vm(base.Id)
+	if err != nil {
+		return err
+	}
+	if !b.snapshots[snapshot] {
+		return fmt.Errorf("could not find snapshot %s of vm %s", snapshot, base.Id)
+	}
+	if _, ok := h.vms[clone.Id]; ok {
+		return fmt.Errorf("vm already exists: %s", clone.Id)
+	}
+	c := newSimVm(base.Id)
+	c.memoryMB, c.cpus = b.memoryMB, b.cpus
+	h.vms[clone.Id] = c
+	return nil
+}
+
+func (h *Hypervisor) Exists(ctx context.Context, id string) bool {
+	if err := h.sim.step("hypervisor.Exists"); err != nil {
+		return false
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+	_, ok := h.vms[id]
+	return ok
+}
+
+func (h *Hypervisor) Start(ctx context.Context, vm *virtual.Vm) error {
+	return h.transition("hypervisor.Start", vm, func(v *simVm) error {
+		if v.state == virtual.Running {
+			return fmt.Errorf("vm is already running: %s", vm.Id)
+		}
+		v.state = virtual.Running
+		return nil
+	})
+}
+
+func (h *Hypervisor) Stop(ctx context.Context, vm *virtual.Vm) error {
+	return h.transition("hypervisor.Stop", vm, func(v *simVm) error {
+		if v.state != virtual.Running {
+			return fmt.Errorf("vm is not running: %s", vm.Id)
+		}
+		v.state = virtual.Stopped
+		return nil
+	})
+}
+
+func (h *Hypervisor) Suspend(ctx context.Context, vm *virtual.Vm) error {
+	return h.transition("hypervisor.Suspend", vm, func(v *simVm) error {
+		if v.state != virtual.Running {
+			return fmt.Errorf("vm is not running: %s", vm.Id)
+		}
+		v.state = virtual.Suspended
+		return nil
+	})
+}
+
+// Base vms cannot be removed while clones of them exist
+func (h *Hypervisor) Remove(ctx context.Context, vm *virtual.Vm) error {
+	if err := h.sim.step("hypervisor.Remove"); err != nil {
+		return err
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+	if _, err := h.vm(vm.Id); err != nil {
+		return err
+	}
+	for id, v := range h.vms {
+		if v.base == vm.Id {
+			return fmt.Errorf("vm %s has linked clone %s", vm.Id, id)
+		}
+	}
+	delete(h.vms, vm.Id)
+	return nil
+}
+
+func (h *Hypervisor) State(ctx context.Context, vm *virtual.Vm) virtual.State {
+	if err := h.sim.step("hypervisor.State"); err != nil {
+		return virtual.Error
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+	v, err := h.vm(vm.Id)
+	if err != nil {
+		return virtual.Error
+	}
+	return v.state
+}
+
+func (h *Hypervisor) TakeSnapshot(ctx context.Context, vm *virtual.Vm, name string, live bool) error {
+	return h.transition("hypervisor.TakeSnapshot", vm, func(v *simVm) error {
+		if v.snapshots[name] {
+			return fmt.Errorf("snapshot already exists: %s", name)
+		}
+		v.snapshots[name] = true
+		return nil
+	})
+}
+
+func (h *Hypervisor) RestoreSnapshot(ctx context.Context, vm *virtual.Vm, name string) error {
+	return h.transition("hypervisor.RestoreSnapshot", vm, func(v *simVm) error {
+		if !v.snapshots[name] {
+			return fmt.Errorf("could not find snapshot %s of vm %s", name, vm.Id)
+		}
+		if v.state == virtual.Running {
+			return fmt.Errorf("cannot restore snapshot of running vm: %s", vm.Id)
+		}
+		return nil
+	})
+}
+
+func (h *Hypervisor) DeleteSnapshot(ctx context.Context, vm *virtual.Vm, name string) error {
+	return h.transition("hypervisor.DeleteSnapshot", vm, func(v *simVm) error {
+		if !v.snapshots[name] {
+			return fmt.Errorf("could not find snapshot %s of vm %s", name, vm.Id)
+		}
+		delete(v.snapshots, name)
+		return nil
+	})
+}
+
+func (h *Hypervisor) SetRAM(ctx context.Context, vm *virtual.Vm, mb uint) error {
+	return h.transition("hypervisor.SetRAM", vm, func(v *simVm) error {
+		v.memoryMB = mb
+		return nil
+	})
+}
+
+func (h *Hypervisor) SetCPU(ctx context.Context, vm *virtual.Vm, cores uint) error {
+	return h.transition("hypervisor.SetCPU", vm, func(v *simVm) error {
+		v.cpus = cores
+		return nil
+	})
+}
+
+func (h *Hypervisor) AttachNIC(ctx context.Context, vm *virtual.Vm, iface string) error {
+	return h.transition("hypervisor.AttachNIC", vm, func(v *simVm) error {
+		v.nic = iface
+		return nil
+	})
+}
+
+func (h *Hypervisor) ExposeDisplay(ctx context.Context, vm *virtual.Vm, protocol string, ip string, port uint) error {
+	return h.transition("hypervisor.ExposeDisplay", vm, func(v *simVm) error {
+		if protocol != virtual.ProtocolRDP && protocol != virtual.ProtocolVNC {
+			return fmt.Errorf("unsupported display protocol: %s", protocol)
+		}
+		v.display = fmt.Sprintf("%s://%s:%d", protocol, ip, port)
+		return nil
+	})
+}
+
+func (h *Hypervisor) ShareFolder(ctx context.Context, vm *virtual.Vm, name string, hostPath string) error {
+	return h.transition("hypervisor.ShareFolder", vm, func(v *simVm) error {
+		v.folders[name] = hostPath
+		return nil
+	})
+}
+
+func (h *Hypervisor) RunningCount(ctx context.Context) (uint32, error) {
+	if err := h.sim.step("hypervisor.RunningCount"); err != nil {
+		return 0, err
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+	var count uint32
+	for _, v := range h.vms {
+		if v.state == virtual.Running {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Runs a simulated operation on an existing vm
+func (h *Hypervisor) transition(op string, vm *virtual.Vm, f func(*simVm) error) error {
+	if err := h.sim.step(op); err != nil {
+		return err
+	}
+	h.m.Lock()
+	defer h.m.Unlock()
+	v, err := h.vm(vm.Id)
+	if err != nil {
+		return err
+	}
+	return f(v)
+}
+
+// Has to be called with the lock held
+func (h *Hypervisor) vm(id string) (*simVm, error) {
+	v, ok := h.vms[id]
+	if !ok {
+		return nil, fmt.Errorf("could not find vm: %s", id)
+	}
+	return v, nil
+}
+
+func newSimVm(base string) *simVm {
+	return &simVm{
+		state:     virtual.Stopped,
+		base:      base,
+		snapshots: make(map[string]bool),
+		folders:   make(map[string]string),
+	}
+}
@@ -0,0 +1,74 @@
+package simulation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// IPTables keeps the rules which would have been inserted by iptables, in the order iptables would list them
+type IPTables struct {
+	sim   *Simulator
+	m     sync.Mutex
+	rules map[string][]string
+}
+
+func newIPTables(sim *Simulator) *IPTables {
+	return &IPTables{
+		sim:   sim,
+		rules: make(map[string][]string),
+	}
+}
+
+// Returns the rules of a chain
+func (ipt *IPTables) Rules(chain string) []string {
+	ipt.m.Lock()
+	defer ipt.m.Unlock()
+	return append([]string{}, ipt.rules[chain]...)
+}
+
+// Implements environment.ExecFunc. Only inserting, appending and deleting rules is supported
+func (ipt *IPTables) Exec(cmd string, args ...string) ([]byte, error) {
+	if cmd == "sudo" && len(args) > 0 {
+		cmd, args = args[0], args[1:]
+	}
+	if cmd != "iptables" {
+		return nil, fmt.Errorf("simulated command not supported: %s", cmd)
+	}
+	if len(args) < 2 {
+		return []byte("iptables: not enough arguments"), fmt.Errorf("exit status 2")
+	}
+
+	actions := map[string]string{
+		"-I": "insert", "--insert": "insert",
+		"-A": "append", "--append": "append",
+		"-D": "delete", "--delete": "delete",
+	}
+	action, ok := actions[args[0]]
+	if !ok {
+		return []byte("iptables: unknown action " + args[0]), fmt.Errorf("exit status 2")
+	}
+	if err := ipt.sim.step("iptables." + action); err != nil {
+		return nil, err
+	}
+
+	chain, rule := args[1], strings.Join(args[2:], " ")
+
+	ipt.m.Lock()
+	defer ipt.m.Unlock()
+	switch action {
+	case "insert":
+		ipt.rules[chain] = append([]string{rule}, ipt.rules[chain]...)
+	case "append":
+		ipt.rules[chain] = append(ipt.rules[chain], rule)
+	case "delete":
+		for i, r := range ipt.rules[chain] {
+			if r == rule {
+				ipt.rules[chain] = append(ipt.rules[chain][:i], ipt.rules[chain][i+1:]...)
+				return nil, nil
+			}
+		}
+		return []byte("iptables: Bad rule (does a matching rule exist in that chain?)."), fmt.Errorf("exit status 1")
+	}
+	return nil, nil
+}
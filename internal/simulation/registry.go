@@ -0,0 +1,52 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Answers the token and manifest requests the agent makes when comparing local images with the registries
+type registryTransport struct {
+	sim *Simulator
+}
+
+func (t registryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.sim.step("registry." + req.Method); err != nil {
+		return nil, err
+	}
+
+	header := make(http.Header)
+	switch {
+	case req.Method == http.MethodGet && req.URL.Path == "/token":
+		header.Set("Content-Type", "application/json")
+		return response(req, http.StatusOK, header, `{"token":"simulated"}`), nil
+	case req.Method == http.MethodHead && strings.Contains(req.URL.Path, "/manifests/"):
+		// Like most registries, manifests can only be read with a token
+		if req.Header.Get("Authorization") != "Bearer simulated" {
+			header.Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="%s"`, req.URL.Host, req.URL.Host))
+			return response(req, http.StatusUnauthorized, header, ""), nil
+		}
+		// Path is /v2/<repo>/manifests/<tag>
+		parts := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/v2/"), "/manifests/", 2)
+		header.Set("Docker-Content-Digest", imageDigest(strings.TrimPrefix(parts[0], "library/"), parts[1]))
+		return response(req, http.StatusOK, header, ""), nil
+	}
+	return response(req, http.StatusNotFound, header, ""), nil
+}
+
+func response(req *http.Request, status int, header http.Header, body string) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewBufferString(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,154 @@
+// Package simulation replaces docker, the hypervisor, iptables, guacamole and the wireguard service
+// with in-memory fakes, so the full lifecycle of environments and labs can be run without any of them installed.
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	wgproto "github.com/aau-network-security/gwireguard/proto"
+	"github.com/aau-network-security/haaukins-agent/internal/environment"
+	wg "github.com/aau-network-security/haaukins-agent/internal/environment/lab/network/vpn"
+	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
+	"github.com/rs/zerolog/log"
+)
+
+type Config struct {
+	Enabled bool `yaml:"enabled"`
+	// Added to every simulated operation
+	Latency time.Duration `yaml:"latency"`
+	// Probability between 0 and 1 that a simulated operation fails
+	FailureRate float64 `yaml:"failure-rate"`
+	// Operations which always fail, ex. "docker.CreateContainer", or "hypervisor" for all hypervisor operations
+	FailOperations []string `yaml:"fail-operations"`
+}
+
+// InjectedErr is returned by simulated operations which have been set to fail
+type InjectedErr struct {
+	Op string
+}
+
+func (err *InjectedErr) Error() string {
+	return fmt.Sprintf("simulated failure of %s", err.Op)
+}
+
+// Simulator holds the fakes installed by Enable. Latency and failures can be changed while the agent is running
+type Simulator struct {
+	m           sync.Mutex
+	latency     time.Duration
+	failureRate float64
+	failOps     map[string]bool
+	rand        *rand.Rand
+
+	Docker     *Docker
+	Hypervisor *Hypervisor
+	IPTables   *IPTables
+	Guacamole  *Guacamole
+	Wireguard  *Wireguard
+}
+
+func New(conf Config) *Simulator {
+	sim := &Simulator{
+		latency:     conf.Latency,
+		failureRate: conf.FailureRate,
+		failOps:     make(map[string]bool),
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, op := range conf.FailOperations {
+		sim.failOps[op] = true
+	}
+
+	sim.Docker = newDocker(sim)
+	sim.Hypervisor = newHypervisor(sim)
+	sim.IPTables = newIPTables(sim)
+	sim.Guacamole = newGuacamole(sim)
+	sim.Wireguard = newWireguard(sim)
+	return sim
+}
+
+// Creates a simulator from the config and installs its fakes in place of the real backends
+func Enable(conf Config) (*Simulator, error) {
+	sim := New(conf)
+
+	virtual.RegisterHypervisor(sim.Hypervisor)
+	if err := virtual.SetHypervisor(HypervisorName); err != nil {
+		return nil, err
+	}
+	virtual.SetDockerHostIP("127.0.0.1")
+	virtual.RegistryClient = &http.Client{Transport: registryTransport{sim: sim}}
+	if err := virtual.InitDocker(sim.Docker); err != nil {
+		return nil, err
+	}
+
+	environment.DefaultExecFunc = sim.IPTables.Exec
+	environment.GuacTransport = sim.Guacamole
+	environment.NewVPNClient = func(wg.WireGuardConfig) (wgproto.WireguardClient, error) {
+		if err := sim.step("wireguard.Connect"); err != nil {
+			return nil, err
+		}
+		return sim.Wireguard, nil
+	}
+
+	log.Warn().
+		Dur("latency", conf.Latency).
+		Float64("failureRate", conf.FailureRate).
+		Strs("failOperations", conf.FailOperations).
+		Msg("running in simulation mode, no containers, vms or vpn peers will be created")
+	return sim, nil
+}
+
+func (sim *Simulator) SetLatency(latency time.Duration) {
+	sim.m.Lock()
+	defer sim.m.Unlock()
+	sim.latency = latency
+}
+
+func (sim *Simulator) SetFailureRate(rate float64) {
+	sim.m.Lock()
+	defer sim.m.Unlock()
+	sim.failureRate = rate
+}
+
+// Makes an operation, or all operations of a backend, fail until it is reset
+func (sim *Simulator) FailOperation(op string, fail bool) {
+	sim.m.Lock()
+	defer sim.m.Unlock()
+	if fail {
+		sim.failOps[op] = true
+		return
+	}
+	delete(sim.failOps, op)
+}
+
+// Waits for the simulated latency and returns an error if the operation should fail
+func (sim *Simulator) step(op string) error {
+	sim.m.Lock()
+	latency := sim.latency
+	fail := sim.failOps[op] || sim.failOps[strings.SplitN(op, ".", 2)[0]]
+	if !fail && sim.failureRate > 0 {
+		fail = sim.rand.Float64() < sim.failureRate
+	}
+	sim.m.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if fail {
+		log.Debug().Str("op", op).Msg("injecting simulated failure")
+		return &InjectedErr{Op: op}
+	}
+	return nil
+}
+
+// Returns a random hex encoded id of n bytes
+func (sim *Simulator) randomId(n int) string {
+	sim.m.Lock()
+	defer sim.m.Unlock()
+	b := make([]byte, n)
+	sim.rand.Read(b)
+	return fmt.Sprintf("%x", b)
+}
@@ -0,0 +1,253 @@
+package simulation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	wgproto "github.com/aau-network-security/gwireguard/proto"
+	"google.golang.org/grpc"
+)
+
+// Wireguard simulates the wireguard service, keeping track of interfaces, keys and peers
+type Wireguard struct {
+	sim        *Simulator
+	m          sync.Mutex
+	interfaces map[string]*wgInterface
+	privKeys   map[string]string
+	pubKeys    map[string]string
+	handshakes map[string]time.Time
+}
+
+type wgInterface struct {
+	address string
+	port    uint32
+	up      bool
+	// Allowed ips of the peers by public key
+	peers map[string]string
+}
+
+func newWireguard(sim *Simulator) *Wireguard {
+	return &Wireguard{
+		sim:        sim,
+		interfaces: make(map[string]*wgInterface),
+		privKeys:   make(map[string]string),
+		pubKeys:    make(map[string]string),
+		handshakes: make(map[string]time.Time),
+	}
+}
+
+// Records a handshake from the peer with the public key, making the peer count as active
+func (w *Wireguard) Handshake(publicKey string) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.handshakes[publicKey] = time.Now()
+}
+
+// Returns the amount of peers of an interface
+func (w *Wireguard) PeerCount(nic string) int {
+	w.m.Lock()
+	defer w.m.Unlock()
+	i, ok := w.interfaces[nic]
+	if !ok {
+		return 0
+	}
+	return len(i.peers)
+}
+
+func (w *Wireguard) InitializeI(ctx context.Context, in *wgproto.IReq, opts ...grpc.CallOption) (*wgproto.IResp, error) {
+	if err := w.sim.step("wireguard.InitializeI"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	for name, i := range w.interfaces {
+		if i.port == in.ListenPort && name != in.IName {
+			return nil, fmt.Errorf("port %d is already used by interface %s", in.ListenPort, name)
+		}
+	}
+	w.interfaces[in.IName] = &wgInterface{
+		address: in.Address,
+		port:    in.ListenPort,
+		up:      true,
+		peers:   make(map[string]string),
+	}
+	w.genKeys(in.IName)
+	return &wgproto.IResp{Message: fmt.Sprintf("interface %s is up", in.IName)}, nil
+}
+
+func (w *Wireguard) AddPeer(ctx context.Context, in *wgproto.AddPReq, opts ...grpc.CallOption) (*wgproto.AddPResp, error) {
+	if err := w.sim.step("wireguard.AddPeer"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	i, ok := w.interfaces[in.Nic]
+	if !ok {
+		return nil, fmt.Errorf("no such interface: %s", in.Nic)
+	}
+	i.peers[in.PublicKey] = in.AllowedIPs
+	return &wgproto.AddPResp{Message: "peer added"}, nil
+}
+
+func (w *Wireguard) DelPeer(ctx context.Context, in *wgproto.DelPReq, opts ...grpc.CallOption) (*wgproto.DelPResp, error) {
+	if err := w.sim.step("wireguard.DelPeer"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	i, ok := w.interfaces[in.Nic]
+	if !ok {
+		return nil, fmt.Errorf("no such interface: %s", in.Nic)
+	}
+	delete(i.peers, in.PeerPublicKey)
+	delete(w.handshakes, in.PeerPublicKey)
+	return &wgproto.DelPResp{Message: "peer deleted"}, nil
+}
+
+// Lists the peers of the interface in the same format as wg show
+func (w *Wireguard) ListPeers(ctx context.Context, in *wgproto.ListPeersReq, opts ...grpc.CallOption) (*wgproto.ListPeersResp, error) {
+	if err := w.sim.step("wireguard.ListPeers"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	i, ok := w.interfaces[in.Nicname]
+	if !ok {
+		return nil, fmt.Errorf("no such interface: %s", in.Nicname)
+	}
+
+	var keys []string
+	for key := range i.peers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "interface: %s\n  public key: %s\n  listening port: %d\n", in.Nicname, w.pubKeys[in.Nicname], i.port)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\npeer: %s\n  allowed ips: %s\n", key, i.peers[key])
+		if t, ok := w.handshakes[key]; ok {
+			fmt.Fprintf(&b, "  latest handshake: %d seconds ago\n", int(time.Since(t).Seconds()))
+		}
+	}
+	return &wgproto.ListPeersResp{Response: b.String()}, nil
+}
+
+func (w *Wireguard) ManageNIC(ctx context.Context, in *wgproto.ManageNICReq, opts ...grpc.CallOption) (*wgproto.ManageNICResp, error) {
+	if err := w.sim.step("wireguard.ManageNIC"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	i, ok := w.interfaces[in.Nic]
+	if !ok {
+		return nil, fmt.Errorf("no such interface: %s", in.Nic)
+	}
+	switch in.Cmd {
+	case "up":
+		i.up = true
+	case "down":
+		i.up = false
+	default:
+		return nil, fmt.Errorf("unknown command: %s", in.Cmd)
+	}
+	return &wgproto.ManageNICResp{Message: fmt.Sprintf("interface %s is %s", in.Nic, in.Cmd)}, nil
+}
+
+func (w *Wireguard) GetPeerStatus(ctx context.Context, in *wgproto.PeerStatusReq, opts ...grpc.CallOption) (*wgproto.PeerStatusResp, error) {
+	if err := w.sim.step("wireguard.GetPeerStatus"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	_, ok := w.handshakes[in.PublicKey]
+	return &wgproto.PeerStatusResp{Status: ok}, nil
+}
+
+func (w *Wireguard) GetNICInfo(ctx context.Context, in *wgproto.NICInfoReq, opts ...grpc.CallOption) (*wgproto.NICInfoResp, error) {
+	if err := w.sim.step("wireguard.GetNICInfo"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	i, ok := w.interfaces[in.Interface]
+	if !ok {
+		return nil, fmt.Errorf("no such interface: %s", in.Interface)
+	}
+	return &wgproto.NICInfoResp{Message: fmt.Sprintf("%s %s %d", in.Interface, i.address, i.port)}, nil
+}
+
+func (w *Wireguard) GenPublicKey(ctx context.Context, in *wgproto.PubKeyReq, opts ...grpc.CallOption) (*wgproto.PubKeyResp, error) {
+	if err := w.sim.step("wireguard.GenPublicKey"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	priv, ok := w.privKeys[in.PrivKeyName]
+	if !ok {
+		return nil, fmt.Errorf("no private key with name: %s", in.PrivKeyName)
+	}
+	w.pubKeys[in.PubKeyName] = publicKey(priv)
+	return &wgproto.PubKeyResp{Message: "public key generated"}, nil
+}
+
+func (w *Wireguard) GenPrivateKey(ctx context.Context, in *wgproto.PrivKeyReq, opts ...grpc.CallOption) (*wgproto.PrivKeyResp, error) {
+	if err := w.sim.step("wireguard.GenPrivateKey"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.privKeys[in.PrivateKeyName] = w.newKey()
+	return &wgproto.PrivKeyResp{Message: "private key generated"}, nil
+}
+
+func (w *Wireguard) GetPrivateKey(ctx context.Context, in *wgproto.PrivKeyReq, opts ...grpc.CallOption) (*wgproto.PrivKeyResp, error) {
+	if err := w.sim.step("wireguard.GetPrivateKey"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	priv, ok := w.privKeys[in.PrivateKeyName]
+	if !ok {
+		return nil, fmt.Errorf("no private key with name: %s", in.PrivateKeyName)
+	}
+	return &wgproto.PrivKeyResp{Message: priv}, nil
+}
+
+func (w *Wireguard) GetPublicKey(ctx context.Context, in *wgproto.PubKeyReq, opts ...grpc.CallOption) (*wgproto.PubKeyResp, error) {
+	if err := w.sim.step("wireguard.GetPublicKey"); err != nil {
+		return nil, err
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	pub, ok := w.pubKeys[in.PubKeyName]
+	if !ok {
+		return nil, fmt.Errorf("no public key with name: %s", in.PubKeyName)
+	}
+	return &wgproto.PubKeyResp{Message: pub}, nil
+}
+
+// Generates the key pair of an interface. Has to be called with the lock held
+func (w *Wireguard) genKeys(name string) {
+	if _, ok := w.privKeys[name]; ok {
+		return
+	}
+	w.privKeys[name] = w.newKey()
+	w.pubKeys[name] = publicKey(w.privKeys[name])
+}
+
+func (w *Wireguard) newKey() string {
+	return base64.StdEncoding.EncodeToString([]byte(w.sim.randomId(16)))
+}
+
+// Derives a stable public key from the private key. The keys only look like wireguard keys
+func publicKey(priv string) string {
+	sum := sha256.Sum256([]byte(priv))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"time"
+
 	env "github.com/aau-network-security/haaukins-agent/internal/environment"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/exercise"
@@ -21,6 +23,7 @@ type Environment struct {
 	IpRules   map[string]env.IpRules
 	IpAddrs   [][]int
 	Labs      map[string]Lab
+	WarmLabs  map[string]Lab
 }
 
 type EnvConfig struct {
@@ -32,6 +35,13 @@ type EnvConfig struct {
 	LabConf         LabConf
 	Status          env.Status
 	TeamSize        int
+	LabTTL          time.Duration
+	IdleTimeout     time.Duration
+	WarmLabs        int
+	MinReadyLabs    int
+	MaxReadyLabs    int
+	SharedScreens   bool
+	Frozen          bool
 }
 
 type Lab struct {
@@ -45,17 +55,28 @@ type Lab struct {
 	Network           *virtual.Network
 	DnsServer         *dns.Server
 	DhcpServer        *dhcp.Server
+	Prober            *virtual.Container
 	DnsAddress        string
 	IsVPN             bool
 	GuacUsername      string
 	GuacPassword      string
 	VpnConfs          []string
+	ExpiresAt         time.Time
+	LastActivity      time.Time
+	IdleSuspended     bool
+	Assigned          bool
+	TeamID            string
+	IsHybrid          bool
+	Reported          bool
+	ImagePolicy       virtual.ImagePolicy
 }
 
 type LabConf struct {
 	Frontends         []virtual.InstanceConfig
 	ExerciseConfs     []exercise.ExerciseConfig
 	DisabledExercises []string
+	Hybrid            bool
+	ImagePolicy       virtual.ImagePolicy
 }
 
 type Exercise struct {
@@ -68,6 +89,11 @@ type Exercise struct {
 	Ips           []int
 	Containers    []*virtual.Container
 	Vms           []*virtual.Vm
+	Status        exercise.Status
+	StatusReason  string
+	StatusChanged time.Time
+	DependsOn     []string
+	Suspended     bool
 }
 
 type Network struct {
@@ -105,4 +131,7 @@ type IPTables struct {
 
 type State struct {
 	Environments map[string]Environment `json:"environments`
+	Schedules    map[string]env.Schedule
+	// When images were last pulled or used, by image id, so images are not pruned right after a restart
+	ImagesLastUsed map[string]time.Time
 }
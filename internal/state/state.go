@@ -1,8 +1,6 @@
 package state
 
 import (
-	"net/http"
-	"net/http/cookiejar"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -11,7 +9,6 @@ import (
 	environment "github.com/aau-network-security/haaukins-agent/internal/environment"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/exercise"
-	wg "github.com/aau-network-security/haaukins-agent/internal/environment/lab/network/vpn"
 	"github.com/aau-network-security/haaukins-agent/internal/environment/lab/virtual"
 	"github.com/aau-network-security/haaukins-agent/internal/worker"
 	"github.com/goccy/go-json"
@@ -24,7 +21,12 @@ func SaveState(envPool *environment.EnvPool, statePath string) error {
 	envPool.M.RLock()
 	defer envPool.M.RUnlock()
 	state := State{
-		Environments: make(map[string]Environment),
+		Environments:   make(map[string]Environment),
+		Schedules:      make(map[string]environment.Schedule),
+		ImagesLastUsed: virtual.ImageUsage(),
+	}
+	for k, s := range envPool.Schedules {
+		state.Schedules[k] = *s
 	}
 	for k, env := range envPool.Envs {
 		env.M.RLock()
@@ -47,8 +49,9 @@ func SaveState(envPool *environment.EnvPool, statePath string) error {
 	return nil
 }
 
-// Resumes from a saves state, which means it reasembles the environment pool in order to restore it across ex. restarts
-func ResumeState(vlib *virtual.VboxLibrary, workerPool worker.WorkerPool, statePath string) (*environment.EnvPool, error) {
+// Resumes from a saves state, which means it reasembles the environment pool in order to restore it across ex. restarts.
+// onExerciseStatusChange is called whenever the status of an exercise in a resumed lab changes
+func ResumeState(vlib *virtual.VboxLibrary, workerPool worker.WorkerPool, statePath string, onExerciseStatusChange func(exercise.StatusEvent)) (*environment.EnvPool, error) {
 	state := State{}
 
 	path := filepath.Join(statePath, "state.json")
@@ -71,15 +74,21 @@ func ResumeState(vlib *virtual.VboxLibrary, workerPool worker.WorkerPool, stateP
 		Envs:         make(map[string]*environment.Environment),
 		StartingEnvs: make(map[string]bool),
 		ClosingEnvs:  make(map[string]bool),
+		Schedules:    make(map[string]*environment.Schedule),
 	}
 	for k, envState := range state.Environments {
-		env, err := convertEnvState(envState, vlib, workerPool)
+		env, err := convertEnvState(envState, vlib, workerPool, onExerciseStatusChange)
 		if err != nil {
 			log.Error().Err(err).Msg("error converting env")
 			return nil, err
 		}
 		envPool.Envs[k] = env
 	}
+	for k, s := range state.Schedules {
+		schedule := s
+		envPool.Schedules[k] = &schedule
+	}
+	virtual.RestoreImageUsage(state.ImagesLastUsed)
 
 	jsonState, err := json.Marshal(state)
 	if err != nil {
@@ -93,11 +102,12 @@ func ResumeState(vlib *virtual.VboxLibrary, workerPool worker.WorkerPool, stateP
 }
 
 // Converts the environment state (state.Environment) from the state.json file into the type environment.Environment to be inserted to the environment pool
-func convertEnvState(envState Environment, vlib *virtual.VboxLibrary, workerPool worker.WorkerPool) (*environment.Environment, error) {
+func convertEnvState(envState Environment, vlib *virtual.VboxLibrary, workerPool worker.WorkerPool, onExerciseStatusChange func(exercise.StatusEvent)) (*environment.Environment, error) {
 	env := &environment.Environment{
-		M:       &sync.RWMutex{},
-		IpRules: envState.IpRules,
-		Labs:    make(map[string]*lab.Lab),
+		M:        &sync.RWMutex{},
+		IpRules:  envState.IpRules,
+		Labs:     make(map[string]*lab.Lab),
+		WarmLabs: make(map[string]*lab.Lab),
 	}
 	env.EnvConfig = &environment.EnvConfig{
 		Tag:             envState.EnvConfig.Tag,
@@ -106,25 +116,32 @@ func convertEnvState(envState Environment, vlib *virtual.VboxLibrary, workerPool
 		VPNEndpointPort: envState.EnvConfig.VPNEndpointPort,
 		VpnConfig:       envState.EnvConfig.VpnConfig,
 		TeamSize:        envState.EnvConfig.TeamSize,
+		LabTTL:          envState.EnvConfig.LabTTL,
+		IdleTimeout:     envState.EnvConfig.IdleTimeout,
+		WarmLabs:        envState.EnvConfig.WarmLabs,
+		MinReadyLabs:    envState.EnvConfig.MinReadyLabs,
+		MaxReadyLabs:    envState.EnvConfig.MaxReadyLabs,
+		SharedScreens:   envState.EnvConfig.SharedScreens,
+		Frozen:          envState.EnvConfig.Frozen,
 		WorkerPool:      workerPool,
 		LabConf: lab.LabConf{
 			Vlib:              vlib,
 			Frontends:         envState.EnvConfig.LabConf.Frontends,
 			ExerciseConfs:     envState.EnvConfig.LabConf.ExerciseConfs,
 			DisabledExercises: envState.EnvConfig.LabConf.DisabledExercises,
+			Hybrid:            envState.EnvConfig.LabConf.Hybrid,
+			ImagePolicy:       envState.EnvConfig.LabConf.ImagePolicy,
+
+			OnExerciseStatusChange: onExerciseStatusChange,
 		},
 		Status: envState.EnvConfig.Status,
 	}
 
-	jar, err := cookiejar.New(nil)
+	client, err := environment.NewGuacClient()
 	if err != nil {
 		return nil, err
 	}
 
-	client := &http.Client{
-		Jar: jar,
-	}
-
 	env.Guac = environment.Guacamole{
 		Client:     client,
 		Token:      envState.Guac.Token,
@@ -137,13 +154,13 @@ func convertEnvState(envState Environment, vlib *virtual.VboxLibrary, workerPool
 		Sudo:     envState.IpT.Sudo,
 		Flags:    envState.IpT.Flags,
 		Debug:    env.IpT.Debug,
-		ExecFunc: environment.ShellExec,
+		ExecFunc: environment.DefaultExecFunc,
 	}
 
 	env.IpRules = envState.IpRules
 	env.IpAddrs = envState.IpAddrs
 
-	wgClient, err := wg.NewGRPCVPNClient(env.EnvConfig.VpnConfig)
+	wgClient, err := environment.NewVPNClient(env.EnvConfig.VpnConfig)
 	if err != nil {
 		log.Error().Err(err).Msg("error connecting to wg server")
 		return &environment.Environment{}, err
@@ -155,7 +172,7 @@ func convertEnvState(envState Environment, vlib *virtual.VboxLibrary, workerPool
 	env.Dockerhost = virtual.NewHost()
 
 	for k, l := range envState.Labs {
-		ll, err := convertLabState(l, vlib)
+		ll, err := convertLabState(l, vlib, onExerciseStatusChange)
 		if err != nil {
 			log.Error().Err(err).Msg("error converting lab")
 			return nil, err
@@ -163,11 +180,20 @@ func convertEnvState(envState Environment, vlib *virtual.VboxLibrary, workerPool
 		env.Labs[k] = ll
 	}
 
+	for k, l := range envState.WarmLabs {
+		ll, err := convertLabState(l, vlib, onExerciseStatusChange)
+		if err != nil {
+			log.Error().Err(err).Msg("error converting warm lab")
+			return nil, err
+		}
+		env.WarmLabs[k] = ll
+	}
+
 	return env, nil
 }
 
 // For each lab in the environment state, it converts from state.Lab to lab.Lab type
-func convertLabState(l Lab, vlib *virtual.VboxLibrary) (*lab.Lab, error) {
+func convertLabState(l Lab, vlib *virtual.VboxLibrary, onExerciseStatusChange func(exercise.StatusEvent)) (*lab.Lab, error) {
 	resumedLab := &lab.Lab{
 		M:         &sync.RWMutex{},
 		Frontends: make(map[uint]lab.FrontendConf),
@@ -192,6 +218,15 @@ func convertLabState(l Lab, vlib *virtual.VboxLibrary) (*lab.Lab, error) {
 			DnsAddr:       ex.DnsAddr,
 			DnsRecords:    ex.DnsRecords,
 			Ips:           ex.Ips,
+			LabTag:        l.Tag,
+			Status:        ex.Status,
+			StatusReason:  ex.StatusReason,
+			StatusChanged: ex.StatusChanged,
+			DependsOn:     ex.DependsOn,
+			Prober:        l.Prober,
+			Suspended:     ex.Suspended,
+
+			OnStatusChange: onExerciseStatusChange,
 		}
 		for _, c := range ex.Containers {
 			exTag.Machines = append(exTag.Machines, c)
@@ -209,12 +244,22 @@ func convertLabState(l Lab, vlib *virtual.VboxLibrary) (*lab.Lab, error) {
 	resumedLab.Network = l.Network
 	resumedLab.DnsServer = l.DnsServer
 	resumedLab.DhcpServer = l.DhcpServer
+	resumedLab.Prober = l.Prober
 	resumedLab.DnsAddress = l.DnsAddress
 	resumedLab.Vlib = vlib
 	resumedLab.IsVPN = l.IsVPN
+	resumedLab.IsHybrid = l.IsHybrid
+	resumedLab.ImagePolicy = l.ImagePolicy
 	resumedLab.GuacUsername = l.GuacUsername
 	resumedLab.GuacPassword = l.GuacPassword
 	resumedLab.VpnConfs = l.VpnConfs
+	resumedLab.ExpiresAt = l.ExpiresAt
+	resumedLab.LastActivity = l.LastActivity
+	resumedLab.IdleSuspended = l.IdleSuspended
+	resumedLab.Assigned = l.Assigned
+	resumedLab.Reported = l.Reported
+	resumedLab.TeamID = l.TeamID
+	resumedLab.OnExerciseStatusChange = onExerciseStatusChange
 
 	return resumedLab, nil
 }
@@ -222,8 +267,9 @@ func convertLabState(l Lab, vlib *virtual.VboxLibrary) (*lab.Lab, error) {
 // Takes an environment from the environment pool and makes it into a serializable state.Environment object
 func makeEnvState(env *environment.Environment) Environment {
 	envState := Environment{
-		IpRules: make(map[string]environment.IpRules),
-		Labs:    make(map[string]Lab),
+		IpRules:  make(map[string]environment.IpRules),
+		Labs:     make(map[string]Lab),
+		WarmLabs: make(map[string]Lab),
 	}
 	envState.EnvConfig = EnvConfig{
 		Tag:             env.EnvConfig.Tag,
@@ -232,10 +278,19 @@ func makeEnvState(env *environment.Environment) Environment {
 		VPNEndpointPort: env.EnvConfig.VPNEndpointPort,
 		VpnConfig:       env.EnvConfig.VpnConfig,
 		TeamSize:        env.EnvConfig.TeamSize,
+		LabTTL:          env.EnvConfig.LabTTL,
+		IdleTimeout:     env.EnvConfig.IdleTimeout,
+		WarmLabs:        env.EnvConfig.WarmLabs,
+		MinReadyLabs:    env.EnvConfig.MinReadyLabs,
+		MaxReadyLabs:    env.EnvConfig.MaxReadyLabs,
+		SharedScreens:   env.EnvConfig.SharedScreens,
+		Frozen:          env.EnvConfig.Frozen,
 		LabConf: LabConf{
 			Frontends:         env.EnvConfig.LabConf.Frontends,
 			ExerciseConfs:     env.EnvConfig.LabConf.ExerciseConfs,
 			DisabledExercises: env.EnvConfig.LabConf.DisabledExercises,
+			Hybrid:            env.EnvConfig.LabConf.Hybrid,
+			ImagePolicy:       env.EnvConfig.LabConf.ImagePolicy,
 		},
 		Status: env.EnvConfig.Status,
 	}
@@ -264,6 +319,12 @@ func makeEnvState(env *environment.Environment) Environment {
 		l.M.RUnlock()
 		envState.Labs[k] = labState
 	}
+	for k, l := range env.WarmLabs {
+		l.M.RLock()
+		labState := makeLabState(l)
+		l.M.RUnlock()
+		envState.WarmLabs[k] = labState
+	}
 	return envState
 }
 
@@ -289,7 +350,10 @@ func makeLabState(l *lab.Lab) Lab {
 			DnsAddr:       ex.DnsAddr,
 			DnsRecords:    ex.DnsRecords,
 			Ips:           ex.Ips,
+			DependsOn:     ex.DependsOn,
+			Suspended:     ex.Suspended,
 		}
+		exTag.Status, exTag.StatusReason, exTag.StatusChanged = ex.GetStatus()
 		for _, m := range ex.Machines {
 			c, cok := m.(*virtual.Container)
 			vm, vmok := m.(*virtual.Vm)
@@ -308,11 +372,20 @@ func makeLabState(l *lab.Lab) Lab {
 	labState.Network = l.Network
 	labState.DnsServer = l.DnsServer
 	labState.DhcpServer = l.DhcpServer
+	labState.Prober = l.Prober
 	labState.DnsAddress = l.DnsAddress
 	labState.IsVPN = l.IsVPN
+	labState.IsHybrid = l.IsHybrid
+	labState.ImagePolicy = l.ImagePolicy
 	labState.GuacUsername = l.GuacUsername
 	labState.GuacPassword = l.GuacPassword
 	labState.VpnConfs = l.VpnConfs
+	labState.ExpiresAt = l.ExpiresAt
+	labState.LastActivity = l.LastActivity
+	labState.IdleSuspended = l.IdleSuspended
+	labState.Assigned = l.Assigned
+	labState.Reported = l.Reported
+	labState.TeamID = l.TeamID
 
 	return labState
 }
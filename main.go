@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/aau-network-security/haaukins-agent/internal/agent"
 	pb "github.com/aau-network-security/haaukins-agent/pkg/proto"
@@ -57,6 +59,17 @@ func main() {
 
 	gRPCServer := a.NewGRPCServer(opts...)
 	pb.RegisterAgentServer(gRPCServer, a)
+
+	// Stopping the server and the background loops of the agent on shutdown
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		log.Info().Msg("shutting down agent")
+		gRPCServer.GracefulStop()
+		a.Close()
+	}()
+
 	log.Info().Msg("server is waiting for clients")
 	if err := gRPCServer.Serve(lis); err != nil {
 		log.Fatal().Err(err).Msg("failed to serve")
@@ -58,6 +58,7 @@ func (*Empty) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{0}
 }
 
+// Lab scoped requests can address a lab either by its tag or by the team assigned to it within an event
 type VmRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -65,6 +66,12 @@ type VmRequest struct {
 
 	LabTag               string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
 	ConnectionIdentifier string `protobuf:"bytes,2,opt,name=connectionIdentifier,proto3" json:"connectionIdentifier,omitempty"`
+	EventTag             string `protobuf:"bytes,3,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId               string `protobuf:"bytes,4,opt,name=teamId,proto3" json:"teamId,omitempty"`
+	// Guacamole username of the team member whose frontend should be reset
+	MemberUsername string `protobuf:"bytes,5,opt,name=memberUsername,proto3" json:"memberUsername,omitempty"`
+	// Name of the frontend which should be reset
+	FrontendName string `protobuf:"bytes,6,opt,name=frontendName,proto3" json:"frontendName,omitempty"`
 }
 
 func (x *VmRequest) Reset() {
@@ -113,16 +120,52 @@ func (x *VmRequest) GetConnectionIdentifier() string {
 	return ""
 }
 
-type ResetLabRequest struct {
+func (x *VmRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *VmRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *VmRequest) GetMemberUsername() string {
+	if x != nil {
+		return x.MemberUsername
+	}
+	return ""
+}
+
+func (x *VmRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+type ResizeVmRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabTag string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
+	// Frontend to resize, can be left out if the lab only has one frontend
+	MemberUsername string `protobuf:"bytes,4,opt,name=memberUsername,proto3" json:"memberUsername,omitempty"`
+	FrontendName   string `protobuf:"bytes,5,opt,name=frontendName,proto3" json:"frontendName,omitempty"`
+	// Zero values are left unchanged
+	MemoryMB uint32  `protobuf:"varint,6,opt,name=memoryMB,proto3" json:"memoryMB,omitempty"`
+	Cpu      float64 `protobuf:"fixed64,7,opt,name=cpu,proto3" json:"cpu,omitempty"`
 }
 
-func (x *ResetLabRequest) Reset() {
-	*x = ResetLabRequest{}
+func (x *ResizeVmRequest) Reset() {
+	*x = ResizeVmRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -130,13 +173,13 @@ func (x *ResetLabRequest) Reset() {
 	}
 }
 
-func (x *ResetLabRequest) String() string {
+func (x *ResizeVmRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResetLabRequest) ProtoMessage() {}
+func (*ResizeVmRequest) ProtoMessage() {}
 
-func (x *ResetLabRequest) ProtoReflect() protoreflect.Message {
+func (x *ResizeVmRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -148,28 +191,77 @@ func (x *ResetLabRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResetLabRequest.ProtoReflect.Descriptor instead.
-func (*ResetLabRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResizeVmRequest.ProtoReflect.Descriptor instead.
+func (*ResizeVmRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ResetLabRequest) GetLabTag() string {
+func (x *ResizeVmRequest) GetLabTag() string {
 	if x != nil {
 		return x.LabTag
 	}
 	return ""
 }
 
-type GetLabRequest struct {
+func (x *ResizeVmRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *ResizeVmRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *ResizeVmRequest) GetMemberUsername() string {
+	if x != nil {
+		return x.MemberUsername
+	}
+	return ""
+}
+
+func (x *ResizeVmRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *ResizeVmRequest) GetMemoryMB() uint32 {
+	if x != nil {
+		return x.MemoryMB
+	}
+	return 0
+}
+
+func (x *ResizeVmRequest) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+type SnapshotRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabTag string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
+	// Frontend of the snapshot, can be left out if the lab only has one frontend
+	MemberUsername string `protobuf:"bytes,4,opt,name=memberUsername,proto3" json:"memberUsername,omitempty"`
+	FrontendName   string `protobuf:"bytes,5,opt,name=frontendName,proto3" json:"frontendName,omitempty"`
+	// Name of the snapshot, generated from the current time if left out when taking a snapshot
+	Name string `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *GetLabRequest) Reset() {
-	*x = GetLabRequest{}
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -177,13 +269,13 @@ func (x *GetLabRequest) Reset() {
 	}
 }
 
-func (x *GetLabRequest) String() string {
+func (x *SnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLabRequest) ProtoMessage() {}
+func (*SnapshotRequest) ProtoMessage() {}
 
-func (x *GetLabRequest) ProtoReflect() protoreflect.Message {
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -195,28 +287,66 @@ func (x *GetLabRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLabRequest.ProtoReflect.Descriptor instead.
-func (*GetLabRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetLabRequest) GetLabTag() string {
+func (x *SnapshotRequest) GetLabTag() string {
 	if x != nil {
 		return x.LabTag
 	}
 	return ""
 }
 
-type GetLabResponse struct {
+func (x *SnapshotRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *SnapshotRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *SnapshotRequest) GetMemberUsername() string {
+	if x != nil {
+		return x.MemberUsername
+	}
+	return ""
+}
+
+func (x *SnapshotRequest) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *SnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type VmSnapshot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Lab *Lab `protobuf:"bytes,1,opt,name=lab,proto3" json:"lab,omitempty"`
+	Name           string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FrontendName   string `protobuf:"bytes,2,opt,name=frontendName,proto3" json:"frontendName,omitempty"`
+	MemberUsername string `protobuf:"bytes,3,opt,name=memberUsername,proto3" json:"memberUsername,omitempty"`
+	CreatedAt      int64  `protobuf:"varint,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 }
 
-func (x *GetLabResponse) Reset() {
-	*x = GetLabResponse{}
+func (x *VmSnapshot) Reset() {
+	*x = VmSnapshot{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -224,13 +354,13 @@ func (x *GetLabResponse) Reset() {
 	}
 }
 
-func (x *GetLabResponse) String() string {
+func (x *VmSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLabResponse) ProtoMessage() {}
+func (*VmSnapshot) ProtoMessage() {}
 
-func (x *GetLabResponse) ProtoReflect() protoreflect.Message {
+func (x *VmSnapshot) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -242,28 +372,51 @@ func (x *GetLabResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLabResponse.ProtoReflect.Descriptor instead.
-func (*GetLabResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VmSnapshot.ProtoReflect.Descriptor instead.
+func (*VmSnapshot) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetLabResponse) GetLab() *Lab {
+func (x *VmSnapshot) GetName() string {
 	if x != nil {
-		return x.Lab
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-type GetHostsRequest struct {
+func (x *VmSnapshot) GetFrontendName() string {
+	if x != nil {
+		return x.FrontendName
+	}
+	return ""
+}
+
+func (x *VmSnapshot) GetMemberUsername() string {
+	if x != nil {
+		return x.MemberUsername
+	}
+	return ""
+}
+
+func (x *VmSnapshot) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListVmSnapshotsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabTag string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	Snapshots []*VmSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	// Maximum amount of snapshots for the lab
+	MaxSnapshots uint32 `protobuf:"varint,2,opt,name=maxSnapshots,proto3" json:"maxSnapshots,omitempty"`
 }
 
-func (x *GetHostsRequest) Reset() {
-	*x = GetHostsRequest{}
+func (x *ListVmSnapshotsResponse) Reset() {
+	*x = ListVmSnapshotsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -271,13 +424,13 @@ func (x *GetHostsRequest) Reset() {
 	}
 }
 
-func (x *GetHostsRequest) String() string {
+func (x *ListVmSnapshotsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHostsRequest) ProtoMessage() {}
+func (*ListVmSnapshotsResponse) ProtoMessage() {}
 
-func (x *GetHostsRequest) ProtoReflect() protoreflect.Message {
+func (x *ListVmSnapshotsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -289,28 +442,41 @@ func (x *GetHostsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHostsRequest.ProtoReflect.Descriptor instead.
-func (*GetHostsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListVmSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListVmSnapshotsResponse) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetHostsRequest) GetLabTag() string {
+func (x *ListVmSnapshotsResponse) GetSnapshots() []*VmSnapshot {
 	if x != nil {
-		return x.LabTag
+		return x.Snapshots
 	}
-	return ""
+	return nil
 }
 
-type GetHostsResponse struct {
+func (x *ListVmSnapshotsResponse) GetMaxSnapshots() uint32 {
+	if x != nil {
+		return x.MaxSnapshots
+	}
+	return 0
+}
+
+type ResizeExerciseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hosts []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
+	Exercise string `protobuf:"bytes,4,opt,name=exercise,proto3" json:"exercise,omitempty"`
+	// Zero values are left unchanged
+	MemoryMB uint32  `protobuf:"varint,5,opt,name=memoryMB,proto3" json:"memoryMB,omitempty"`
+	Cpu      float64 `protobuf:"fixed64,6,opt,name=cpu,proto3" json:"cpu,omitempty"`
 }
 
-func (x *GetHostsResponse) Reset() {
-	*x = GetHostsResponse{}
+func (x *ResizeExerciseRequest) Reset() {
+	*x = ResizeExerciseRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -318,13 +484,13 @@ func (x *GetHostsResponse) Reset() {
 	}
 }
 
-func (x *GetHostsResponse) String() string {
+func (x *ResizeExerciseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHostsResponse) ProtoMessage() {}
+func (*ResizeExerciseRequest) ProtoMessage() {}
 
-func (x *GetHostsResponse) ProtoReflect() protoreflect.Message {
+func (x *ResizeExerciseRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -336,31 +502,66 @@ func (x *GetHostsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHostsResponse.ProtoReflect.Descriptor instead.
-func (*GetHostsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResizeExerciseRequest.ProtoReflect.Descriptor instead.
+func (*ResizeExerciseRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetHostsResponse) GetHosts() []string {
+func (x *ResizeExerciseRequest) GetLabTag() string {
 	if x != nil {
-		return x.Hosts
+		return x.LabTag
 	}
-	return nil
+	return ""
 }
 
-type MonitorResponse struct {
+func (x *ResizeExerciseRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *ResizeExerciseRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *ResizeExerciseRequest) GetExercise() string {
+	if x != nil {
+		return x.Exercise
+	}
+	return ""
+}
+
+func (x *ResizeExerciseRequest) GetMemoryMB() uint32 {
+	if x != nil {
+		return x.MemoryMB
+	}
+	return 0
+}
+
+func (x *ResizeExerciseRequest) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+type AddFrontendRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hb          string     `protobuf:"bytes,1,opt,name=hb,proto3" json:"hb,omitempty"`
-	NewLabs     []*Lab     `protobuf:"bytes,2,rep,name=newLabs,proto3" json:"newLabs,omitempty"`
-	Resources   *Resources `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
-	QueuedTasks uint32     `protobuf:"varint,4,opt,name=queuedTasks,proto3" json:"queuedTasks,omitempty"`
+	LabTag   string    `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string    `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string    `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
+	Vm       *VmConfig `protobuf:"bytes,4,opt,name=vm,proto3" json:"vm,omitempty"`
 }
 
-func (x *MonitorResponse) Reset() {
-	*x = MonitorResponse{}
+func (x *AddFrontendRequest) Reset() {
+	*x = AddFrontendRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -368,13 +569,13 @@ func (x *MonitorResponse) Reset() {
 	}
 }
 
-func (x *MonitorResponse) String() string {
+func (x *AddFrontendRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MonitorResponse) ProtoMessage() {}
+func (*AddFrontendRequest) ProtoMessage() {}
 
-func (x *MonitorResponse) ProtoReflect() protoreflect.Message {
+func (x *AddFrontendRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -386,55 +587,54 @@ func (x *MonitorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MonitorResponse.ProtoReflect.Descriptor instead.
-func (*MonitorResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddFrontendRequest.ProtoReflect.Descriptor instead.
+func (*AddFrontendRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *MonitorResponse) GetHb() string {
+func (x *AddFrontendRequest) GetLabTag() string {
 	if x != nil {
-		return x.Hb
+		return x.LabTag
 	}
 	return ""
 }
 
-func (x *MonitorResponse) GetNewLabs() []*Lab {
+func (x *AddFrontendRequest) GetEventTag() string {
 	if x != nil {
-		return x.NewLabs
+		return x.EventTag
 	}
-	return nil
+	return ""
 }
 
-func (x *MonitorResponse) GetResources() *Resources {
+func (x *AddFrontendRequest) GetTeamId() string {
 	if x != nil {
-		return x.Resources
+		return x.TeamId
 	}
-	return nil
+	return ""
 }
 
-func (x *MonitorResponse) GetQueuedTasks() uint32 {
+func (x *AddFrontendRequest) GetVm() *VmConfig {
 	if x != nil {
-		return x.QueuedTasks
+		return x.Vm
 	}
-	return 0
+	return nil
 }
 
-type Resources struct {
+// The frontend to remove is targeted by either its name or the team member owning it
+type RemoveFrontendRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MemAvailable   uint64  `protobuf:"varint,1,opt,name=memAvailable,proto3" json:"memAvailable,omitempty"`
-	Cpu            float64 `protobuf:"fixed64,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	MemPercentUsed float64 `protobuf:"fixed64,3,opt,name=memPercentUsed,proto3" json:"memPercentUsed,omitempty"`
-	LabCount       uint32  `protobuf:"varint,4,opt,name=labCount,proto3" json:"labCount,omitempty"`
-	VmCount        uint32  `protobuf:"varint,5,opt,name=vmCount,proto3" json:"vmCount,omitempty"`
-	ContainerCount uint32  `protobuf:"varint,6,opt,name=containerCount,proto3" json:"containerCount,omitempty"`
-	MemInstalled   uint64  `protobuf:"varint,7,opt,name=memInstalled,proto3" json:"memInstalled,omitempty"`
+	LabTag         string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag       string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId         string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
+	FrontendName   string `protobuf:"bytes,4,opt,name=frontendName,proto3" json:"frontendName,omitempty"`
+	MemberUsername string `protobuf:"bytes,5,opt,name=memberUsername,proto3" json:"memberUsername,omitempty"`
 }
 
-func (x *Resources) Reset() {
-	*x = Resources{}
+func (x *RemoveFrontendRequest) Reset() {
+	*x = RemoveFrontendRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -442,13 +642,13 @@ func (x *Resources) Reset() {
 	}
 }
 
-func (x *Resources) String() string {
+func (x *RemoveFrontendRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Resources) ProtoMessage() {}
+func (*RemoveFrontendRequest) ProtoMessage() {}
 
-func (x *Resources) ProtoReflect() protoreflect.Message {
+func (x *RemoveFrontendRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -460,70 +660,58 @@ func (x *Resources) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
-func (*Resources) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveFrontendRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFrontendRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Resources) GetMemAvailable() uint64 {
+func (x *RemoveFrontendRequest) GetLabTag() string {
 	if x != nil {
-		return x.MemAvailable
+		return x.LabTag
 	}
-	return 0
+	return ""
 }
 
-func (x *Resources) GetCpu() float64 {
+func (x *RemoveFrontendRequest) GetEventTag() string {
 	if x != nil {
-		return x.Cpu
+		return x.EventTag
 	}
-	return 0
+	return ""
 }
 
-func (x *Resources) GetMemPercentUsed() float64 {
+func (x *RemoveFrontendRequest) GetTeamId() string {
 	if x != nil {
-		return x.MemPercentUsed
+		return x.TeamId
 	}
-	return 0
+	return ""
 }
 
-func (x *Resources) GetLabCount() uint32 {
+func (x *RemoveFrontendRequest) GetFrontendName() string {
 	if x != nil {
-		return x.LabCount
+		return x.FrontendName
 	}
-	return 0
+	return ""
 }
 
-func (x *Resources) GetVmCount() uint32 {
+func (x *RemoveFrontendRequest) GetMemberUsername() string {
 	if x != nil {
-		return x.VmCount
+		return x.MemberUsername
 	}
-	return 0
+	return ""
 }
 
-func (x *Resources) GetContainerCount() uint32 {
-	if x != nil {
-		return x.ContainerCount
-	}
-	return 0
-}
-
-func (x *Resources) GetMemInstalled() uint64 {
-	if x != nil {
-		return x.MemInstalled
-	}
-	return 0
-}
-
-type PingRequest struct {
+type ResetLabRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ping string `protobuf:"bytes,1,opt,name=ping,proto3" json:"ping,omitempty"`
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
 }
 
-func (x *PingRequest) Reset() {
-	*x = PingRequest{}
+func (x *ResetLabRequest) Reset() {
+	*x = ResetLabRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -531,13 +719,13 @@ func (x *PingRequest) Reset() {
 	}
 }
 
-func (x *PingRequest) String() string {
+func (x *ResetLabRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingRequest) ProtoMessage() {}
+func (*ResetLabRequest) ProtoMessage() {}
 
-func (x *PingRequest) ProtoReflect() protoreflect.Message {
+func (x *ResetLabRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -549,29 +737,44 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
-func (*PingRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResetLabRequest.ProtoReflect.Descriptor instead.
+func (*ResetLabRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *PingRequest) GetPing() string {
+func (x *ResetLabRequest) GetLabTag() string {
 	if x != nil {
-		return x.Ping
+		return x.LabTag
 	}
 	return ""
 }
 
-type PingResponse struct {
+func (x *ResetLabRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *ResetLabRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type GetLabRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Pong         string `protobuf:"bytes,1,opt,name=pong,proto3" json:"pong,omitempty"`
-	MemInstalled uint64 `protobuf:"varint,2,opt,name=memInstalled,proto3" json:"memInstalled,omitempty"`
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
 }
 
-func (x *PingResponse) Reset() {
-	*x = PingResponse{}
+func (x *GetLabRequest) Reset() {
+	*x = GetLabRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -579,13 +782,13 @@ func (x *PingResponse) Reset() {
 	}
 }
 
-func (x *PingResponse) String() string {
+func (x *GetLabRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingResponse) ProtoMessage() {}
+func (*GetLabRequest) ProtoMessage() {}
 
-func (x *PingResponse) ProtoReflect() protoreflect.Message {
+func (x *GetLabRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -597,41 +800,42 @@ func (x *PingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
-func (*PingResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetLabRequest.ProtoReflect.Descriptor instead.
+func (*GetLabRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *PingResponse) GetPong() string {
+func (x *GetLabRequest) GetLabTag() string {
 	if x != nil {
-		return x.Pong
+		return x.LabTag
 	}
 	return ""
 }
 
-func (x *PingResponse) GetMemInstalled() uint64 {
+func (x *GetLabRequest) GetEventTag() string {
 	if x != nil {
-		return x.MemInstalled
+		return x.EventTag
 	}
-	return 0
+	return ""
 }
 
-type CreatEnvRequest struct {
+func (x *GetLabRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type GetLabResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EventTag        string            `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
-	EnvType         int32             `protobuf:"varint,2,opt,name=envType,proto3" json:"envType,omitempty"`
-	Vm              *VmConfig         `protobuf:"bytes,3,opt,name=vm,proto3" json:"vm,omitempty"`
-	InitialLabs     int32             `protobuf:"varint,4,opt,name=initialLabs,proto3" json:"initialLabs,omitempty"`
-	Exercises       []string          `protobuf:"bytes,5,rep,name=exercises,proto3" json:"exercises,omitempty"`
-	TeamSize        int32             `protobuf:"varint,6,opt,name=teamSize,proto3" json:"teamSize,omitempty"`
-	ExerciseConfigs []*ExerciseConfig `protobuf:"bytes,7,rep,name=exerciseConfigs,proto3" json:"exerciseConfigs,omitempty"`
+	Lab *Lab `protobuf:"bytes,1,opt,name=lab,proto3" json:"lab,omitempty"`
 }
 
-func (x *CreatEnvRequest) Reset() {
-	*x = CreatEnvRequest{}
+func (x *GetLabResponse) Reset() {
+	*x = GetLabResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -639,13 +843,13 @@ func (x *CreatEnvRequest) Reset() {
 	}
 }
 
-func (x *CreatEnvRequest) String() string {
+func (x *GetLabResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreatEnvRequest) ProtoMessage() {}
+func (*GetLabResponse) ProtoMessage() {}
 
-func (x *CreatEnvRequest) ProtoReflect() protoreflect.Message {
+func (x *GetLabResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -657,85 +861,106 @@ func (x *CreatEnvRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreatEnvRequest.ProtoReflect.Descriptor instead.
-func (*CreatEnvRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetLabResponse.ProtoReflect.Descriptor instead.
+func (*GetLabResponse) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CreatEnvRequest) GetEventTag() string {
+func (x *GetLabResponse) GetLab() *Lab {
 	if x != nil {
-		return x.EventTag
+		return x.Lab
 	}
-	return ""
+	return nil
 }
 
-func (x *CreatEnvRequest) GetEnvType() int32 {
-	if x != nil {
-		return x.EnvType
-	}
-	return 0
+type GetHostsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
 }
 
-func (x *CreatEnvRequest) GetVm() *VmConfig {
-	if x != nil {
-		return x.Vm
+func (x *GetHostsRequest) Reset() {
+	*x = GetHostsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *CreatEnvRequest) GetInitialLabs() int32 {
-	if x != nil {
-		return x.InitialLabs
+func (x *GetHostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHostsRequest) ProtoMessage() {}
+
+func (x *GetHostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CreatEnvRequest) GetExercises() []string {
+// Deprecated: Use GetHostsRequest.ProtoReflect.Descriptor instead.
+func (*GetHostsRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetHostsRequest) GetLabTag() string {
 	if x != nil {
-		return x.Exercises
+		return x.LabTag
 	}
-	return nil
+	return ""
 }
 
-func (x *CreatEnvRequest) GetTeamSize() int32 {
+func (x *GetHostsRequest) GetEventTag() string {
 	if x != nil {
-		return x.TeamSize
+		return x.EventTag
 	}
-	return 0
+	return ""
 }
 
-func (x *CreatEnvRequest) GetExerciseConfigs() []*ExerciseConfig {
+func (x *GetHostsRequest) GetTeamId() string {
 	if x != nil {
-		return x.ExerciseConfigs
+		return x.TeamId
 	}
-	return nil
+	return ""
 }
 
-type CloseEnvRequest struct {
+type GetHostsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EventTag string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	Hosts []string `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
 }
 
-func (x *CloseEnvRequest) Reset() {
-	*x = CloseEnvRequest{}
+func (x *GetHostsResponse) Reset() {
+	*x = GetHostsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[12]
+		mi := &file_agent_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CloseEnvRequest) String() string {
+func (x *GetHostsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloseEnvRequest) ProtoMessage() {}
+func (*GetHostsResponse) ProtoMessage() {}
 
-func (x *CloseEnvRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[12]
+func (x *GetHostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,45 +971,49 @@ func (x *CloseEnvRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloseEnvRequest.ProtoReflect.Descriptor instead.
-func (*CloseEnvRequest) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetHostsResponse.ProtoReflect.Descriptor instead.
+func (*GetHostsResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *CloseEnvRequest) GetEventTag() string {
+func (x *GetHostsResponse) GetHosts() []string {
 	if x != nil {
-		return x.EventTag
+		return x.Hosts
 	}
-	return ""
+	return nil
 }
 
-type ListEnvResponse struct {
+type MonitorResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EventTags         map[string]bool `protobuf:"bytes,1,rep,name=eventTags,proto3" json:"eventTags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	StartingEventTags map[string]bool `protobuf:"bytes,2,rep,name=startingEventTags,proto3" json:"startingEventTags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	ClosingEventTags  map[string]bool `protobuf:"bytes,3,rep,name=closingEventTags,proto3" json:"closingEventTags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Hb             string           `protobuf:"bytes,1,opt,name=hb,proto3" json:"hb,omitempty"`
+	NewLabs        []*Lab           `protobuf:"bytes,2,rep,name=newLabs,proto3" json:"newLabs,omitempty"`
+	Resources      *Resources       `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`
+	QueuedTasks    uint32           `protobuf:"varint,4,opt,name=queuedTasks,proto3" json:"queuedTasks,omitempty"`
+	LabPools       []*LabPool       `protobuf:"bytes,5,rep,name=labPools,proto3" json:"labPools,omitempty"`
+	ExerciseEvents []*ExerciseEvent `protobuf:"bytes,6,rep,name=exerciseEvents,proto3" json:"exerciseEvents,omitempty"`
+	ClosedLabs     []*ClosedLab     `protobuf:"bytes,7,rep,name=closedLabs,proto3" json:"closedLabs,omitempty"`
 }
 
-func (x *ListEnvResponse) Reset() {
-	*x = ListEnvResponse{}
+func (x *MonitorResponse) Reset() {
+	*x = MonitorResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[13]
+		mi := &file_agent_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListEnvResponse) String() string {
+func (x *MonitorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListEnvResponse) ProtoMessage() {}
+func (*MonitorResponse) ProtoMessage() {}
 
-func (x *ListEnvResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[13]
+func (x *MonitorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,58 +1024,88 @@ func (x *ListEnvResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListEnvResponse.ProtoReflect.Descriptor instead.
-func (*ListEnvResponse) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use MonitorResponse.ProtoReflect.Descriptor instead.
+func (*MonitorResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListEnvResponse) GetEventTags() map[string]bool {
+func (x *MonitorResponse) GetHb() string {
 	if x != nil {
-		return x.EventTags
+		return x.Hb
+	}
+	return ""
+}
+
+func (x *MonitorResponse) GetNewLabs() []*Lab {
+	if x != nil {
+		return x.NewLabs
 	}
 	return nil
 }
 
-func (x *ListEnvResponse) GetStartingEventTags() map[string]bool {
+func (x *MonitorResponse) GetResources() *Resources {
 	if x != nil {
-		return x.StartingEventTags
+		return x.Resources
 	}
 	return nil
 }
 
-func (x *ListEnvResponse) GetClosingEventTags() map[string]bool {
+func (x *MonitorResponse) GetQueuedTasks() uint32 {
 	if x != nil {
-		return x.ClosingEventTags
+		return x.QueuedTasks
+	}
+	return 0
+}
+
+func (x *MonitorResponse) GetLabPools() []*LabPool {
+	if x != nil {
+		return x.LabPools
 	}
 	return nil
 }
 
-type CreateLabRequest struct {
+func (x *MonitorResponse) GetExerciseEvents() []*ExerciseEvent {
+	if x != nil {
+		return x.ExerciseEvents
+	}
+	return nil
+}
+
+func (x *MonitorResponse) GetClosedLabs() []*ClosedLab {
+	if x != nil {
+		return x.ClosedLabs
+	}
+	return nil
+}
+
+type LabPool struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	EventTag string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
-	IsVPN    bool   `protobuf:"varint,2,opt,name=isVPN,proto3" json:"isVPN,omitempty"`
+	Ready    uint32 `protobuf:"varint,2,opt,name=ready,proto3" json:"ready,omitempty"`
+	Building uint32 `protobuf:"varint,3,opt,name=building,proto3" json:"building,omitempty"`
+	Target   uint32 `protobuf:"varint,4,opt,name=target,proto3" json:"target,omitempty"`
 }
 
-func (x *CreateLabRequest) Reset() {
-	*x = CreateLabRequest{}
+func (x *LabPool) Reset() {
+	*x = LabPool{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[14]
+		mi := &file_agent_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateLabRequest) String() string {
+func (x *LabPool) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateLabRequest) ProtoMessage() {}
+func (*LabPool) ProtoMessage() {}
 
-func (x *CreateLabRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[14]
+func (x *LabPool) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -857,50 +1116,70 @@ func (x *CreateLabRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateLabRequest.ProtoReflect.Descriptor instead.
-func (*CreateLabRequest) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use LabPool.ProtoReflect.Descriptor instead.
+func (*LabPool) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *CreateLabRequest) GetEventTag() string {
+func (x *LabPool) GetEventTag() string {
 	if x != nil {
 		return x.EventTag
 	}
 	return ""
 }
 
-func (x *CreateLabRequest) GetIsVPN() bool {
+func (x *LabPool) GetReady() uint32 {
 	if x != nil {
-		return x.IsVPN
+		return x.Ready
 	}
-	return false
+	return 0
 }
 
-type CreateVpnConfRequest struct {
+func (x *LabPool) GetBuilding() uint32 {
+	if x != nil {
+		return x.Building
+	}
+	return 0
+}
+
+func (x *LabPool) GetTarget() uint32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+type Resources struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabTag string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	MemAvailable   uint64  `protobuf:"varint,1,opt,name=memAvailable,proto3" json:"memAvailable,omitempty"`
+	Cpu            float64 `protobuf:"fixed64,2,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	MemPercentUsed float64 `protobuf:"fixed64,3,opt,name=memPercentUsed,proto3" json:"memPercentUsed,omitempty"`
+	LabCount       uint32  `protobuf:"varint,4,opt,name=labCount,proto3" json:"labCount,omitempty"`
+	VmCount        uint32  `protobuf:"varint,5,opt,name=vmCount,proto3" json:"vmCount,omitempty"`
+	ContainerCount uint32  `protobuf:"varint,6,opt,name=containerCount,proto3" json:"containerCount,omitempty"`
+	MemInstalled   uint64  `protobuf:"varint,7,opt,name=memInstalled,proto3" json:"memInstalled,omitempty"`
 }
 
-func (x *CreateVpnConfRequest) Reset() {
-	*x = CreateVpnConfRequest{}
+func (x *Resources) Reset() {
+	*x = Resources{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[15]
+		mi := &file_agent_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateVpnConfRequest) String() string {
+func (x *Resources) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVpnConfRequest) ProtoMessage() {}
+func (*Resources) ProtoMessage() {}
 
-func (x *CreateVpnConfRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[15]
+func (x *Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -911,75 +1190,70 @@ func (x *CreateVpnConfRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVpnConfRequest.ProtoReflect.Descriptor instead.
-func (*CreateVpnConfRequest) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use Resources.ProtoReflect.Descriptor instead.
+func (*Resources) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CreateVpnConfRequest) GetLabTag() string {
+func (x *Resources) GetMemAvailable() uint64 {
 	if x != nil {
-		return x.LabTag
+		return x.MemAvailable
 	}
-	return ""
+	return 0
 }
 
-type CreateVpnConfResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Configs []string `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
+func (x *Resources) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
 }
 
-func (x *CreateVpnConfResponse) Reset() {
-	*x = CreateVpnConfResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[16]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Resources) GetMemPercentUsed() float64 {
+	if x != nil {
+		return x.MemPercentUsed
 	}
+	return 0
 }
 
-func (x *CreateVpnConfResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Resources) GetLabCount() uint32 {
+	if x != nil {
+		return x.LabCount
+	}
+	return 0
 }
 
-func (*CreateVpnConfResponse) ProtoMessage() {}
-
-func (x *CreateVpnConfResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[16]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Resources) GetVmCount() uint32 {
+	if x != nil {
+		return x.VmCount
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CreateVpnConfResponse.ProtoReflect.Descriptor instead.
-func (*CreateVpnConfResponse) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{16}
+func (x *Resources) GetContainerCount() uint32 {
+	if x != nil {
+		return x.ContainerCount
+	}
+	return 0
 }
 
-func (x *CreateVpnConfResponse) GetConfigs() []string {
+func (x *Resources) GetMemInstalled() uint64 {
 	if x != nil {
-		return x.Configs
+		return x.MemInstalled
 	}
-	return nil
+	return 0
 }
 
-type CloseLabRequest struct {
+type PingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabTag string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	Ping string `protobuf:"bytes,1,opt,name=ping,proto3" json:"ping,omitempty"`
 }
 
-func (x *CloseLabRequest) Reset() {
-	*x = CloseLabRequest{}
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -987,13 +1261,13 @@ func (x *CloseLabRequest) Reset() {
 	}
 }
 
-func (x *CloseLabRequest) String() string {
+func (x *PingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloseLabRequest) ProtoMessage() {}
+func (*PingRequest) ProtoMessage() {}
 
-func (x *CloseLabRequest) ProtoReflect() protoreflect.Message {
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1005,32 +1279,29 @@ func (x *CloseLabRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloseLabRequest.ProtoReflect.Descriptor instead.
-func (*CloseLabRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CloseLabRequest) GetLabTag() string {
+func (x *PingRequest) GetPing() string {
 	if x != nil {
-		return x.LabTag
+		return x.Ping
 	}
 	return ""
 }
 
-type ExerciseRequest struct {
+type PingResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LabTag          string            `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
-	EnvTag          string            `protobuf:"bytes,2,opt,name=envTag,proto3" json:"envTag,omitempty"`
-	Exercises       []string          `protobuf:"bytes,3,rep,name=exercises,proto3" json:"exercises,omitempty"`
-	Exercise        string            `protobuf:"bytes,4,opt,name=exercise,proto3" json:"exercise,omitempty"`
-	ExerciseConfigs []*ExerciseConfig `protobuf:"bytes,5,rep,name=exerciseConfigs,proto3" json:"exerciseConfigs,omitempty"`
+	Pong         string `protobuf:"bytes,1,opt,name=pong,proto3" json:"pong,omitempty"`
+	MemInstalled uint64 `protobuf:"varint,2,opt,name=memInstalled,proto3" json:"memInstalled,omitempty"`
 }
 
-func (x *ExerciseRequest) Reset() {
-	*x = ExerciseRequest{}
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1038,13 +1309,13 @@ func (x *ExerciseRequest) Reset() {
 	}
 }
 
-func (x *ExerciseRequest) String() string {
+func (x *PingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExerciseRequest) ProtoMessage() {}
+func (*PingResponse) ProtoMessage() {}
 
-func (x *ExerciseRequest) ProtoReflect() protoreflect.Message {
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1056,58 +1327,60 @@ func (x *ExerciseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExerciseRequest.ProtoReflect.Descriptor instead.
-func (*ExerciseRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ExerciseRequest) GetLabTag() string {
-	if x != nil {
-		return x.LabTag
-	}
-	return ""
-}
-
-func (x *ExerciseRequest) GetEnvTag() string {
-	if x != nil {
-		return x.EnvTag
-	}
-	return ""
-}
-
-func (x *ExerciseRequest) GetExercises() []string {
-	if x != nil {
-		return x.Exercises
-	}
-	return nil
-}
-
-func (x *ExerciseRequest) GetExercise() string {
+func (x *PingResponse) GetPong() string {
 	if x != nil {
-		return x.Exercise
+		return x.Pong
 	}
 	return ""
 }
 
-func (x *ExerciseRequest) GetExerciseConfigs() []*ExerciseConfig {
+func (x *PingResponse) GetMemInstalled() uint64 {
 	if x != nil {
-		return x.ExerciseConfigs
+		return x.MemInstalled
 	}
-	return nil
+	return 0
 }
 
-type VmConfig struct {
+type CreatEnvRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Image    string  `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	MemoryMB uint32  `protobuf:"varint,2,opt,name=memoryMB,proto3" json:"memoryMB,omitempty"`
-	Cpu      float64 `protobuf:"fixed64,3,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	EventTag           string            `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	EnvType            int32             `protobuf:"varint,2,opt,name=envType,proto3" json:"envType,omitempty"`
+	Vm                 *VmConfig         `protobuf:"bytes,3,opt,name=vm,proto3" json:"vm,omitempty"`
+	InitialLabs        int32             `protobuf:"varint,4,opt,name=initialLabs,proto3" json:"initialLabs,omitempty"`
+	Exercises          []string          `protobuf:"bytes,5,rep,name=exercises,proto3" json:"exercises,omitempty"`
+	TeamSize           int32             `protobuf:"varint,6,opt,name=teamSize,proto3" json:"teamSize,omitempty"`
+	ExerciseConfigs    []*ExerciseConfig `protobuf:"bytes,7,rep,name=exerciseConfigs,proto3" json:"exerciseConfigs,omitempty"`
+	LabTtlMinutes      uint32            `protobuf:"varint,8,opt,name=labTtlMinutes,proto3" json:"labTtlMinutes,omitempty"`
+	IdleTimeoutMinutes uint32            `protobuf:"varint,9,opt,name=idleTimeoutMinutes,proto3" json:"idleTimeoutMinutes,omitempty"`
+	// Optional unix timestamps for scheduled start, freeze and teardown of the environment
+	StartAt    int64 `protobuf:"varint,10,opt,name=startAt,proto3" json:"startAt,omitempty"`
+	FreezeAt   int64 `protobuf:"varint,11,opt,name=freezeAt,proto3" json:"freezeAt,omitempty"`
+	TeardownAt int64 `protobuf:"varint,12,opt,name=teardownAt,proto3" json:"teardownAt,omitempty"`
+	// Amount of pre-built labs kept ready for advanced environments
+	WarmLabs uint32 `protobuf:"varint,13,opt,name=warmLabs,proto3" json:"warmLabs,omitempty"`
+	// Minimum and maximum amount of unassigned labs kept ready for beginner environments
+	MinReadyLabs uint32 `protobuf:"varint,14,opt,name=minReadyLabs,proto3" json:"minReadyLabs,omitempty"`
+	MaxReadyLabs uint32 `protobuf:"varint,15,opt,name=maxReadyLabs,proto3" json:"maxReadyLabs,omitempty"`
+	// Allows team members to access the frontends of their teammates
+	SharedScreens bool `protobuf:"varint,16,opt,name=sharedScreens,proto3" json:"sharedScreens,omitempty"`
+	// Frontends for each lab, if empty vm is used for each team member
+	Frontends []*VmConfig `protobuf:"bytes,17,rep,name=frontends,proto3" json:"frontends,omitempty"`
+	// Labs get browser frontends and can have VPN access enabled at the same time
+	HybridLabs bool `protobuf:"varint,18,opt,name=hybridLabs,proto3" json:"hybridLabs,omitempty"`
+	// Restricts the images exercises of the environment may use
+	ImagePolicy *ImagePolicy `protobuf:"bytes,19,opt,name=imagePolicy,proto3" json:"imagePolicy,omitempty"`
 }
 
-func (x *VmConfig) Reset() {
-	*x = VmConfig{}
+func (x *CreatEnvRequest) Reset() {
+	*x = CreatEnvRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_agent_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1115,13 +1388,13 @@ func (x *VmConfig) Reset() {
 	}
 }
 
-func (x *VmConfig) String() string {
+func (x *CreatEnvRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VmConfig) ProtoMessage() {}
+func (*CreatEnvRequest) ProtoMessage() {}
 
-func (x *VmConfig) ProtoReflect() protoreflect.Message {
+func (x *CreatEnvRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_agent_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1133,109 +1406,2351 @@ func (x *VmConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VmConfig.ProtoReflect.Descriptor instead.
-func (*VmConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreatEnvRequest.ProtoReflect.Descriptor instead.
+func (*CreatEnvRequest) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *VmConfig) GetImage() string {
+func (x *CreatEnvRequest) GetEventTag() string {
 	if x != nil {
-		return x.Image
+		return x.EventTag
 	}
 	return ""
 }
 
-func (x *VmConfig) GetMemoryMB() uint32 {
+func (x *CreatEnvRequest) GetEnvType() int32 {
 	if x != nil {
-		return x.MemoryMB
+		return x.EnvType
 	}
 	return 0
 }
 
-func (x *VmConfig) GetCpu() float64 {
+func (x *CreatEnvRequest) GetVm() *VmConfig {
 	if x != nil {
-		return x.Cpu
+		return x.Vm
 	}
-	return 0
+	return nil
 }
 
-type StatusResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+func (x *CreatEnvRequest) GetInitialLabs() int32 {
+	if x != nil {
+		return x.InitialLabs
+	}
+	return 0
 }
 
-func (x *StatusResponse) Reset() {
-	*x = StatusResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[20]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *CreatEnvRequest) GetExercises() []string {
+	if x != nil {
+		return x.Exercises
 	}
+	return nil
 }
 
-func (x *StatusResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CreatEnvRequest) GetTeamSize() int32 {
+	if x != nil {
+		return x.TeamSize
+	}
+	return 0
 }
 
-func (*StatusResponse) ProtoMessage() {}
-
-func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[20]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *CreatEnvRequest) GetExerciseConfigs() []*ExerciseConfig {
+	if x != nil {
+		return x.ExerciseConfigs
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
-func (*StatusResponse) Descriptor() ([]byte, []int) {
+func (x *CreatEnvRequest) GetLabTtlMinutes() uint32 {
+	if x != nil {
+		return x.LabTtlMinutes
+	}
+	return 0
+}
+
+func (x *CreatEnvRequest) GetIdleTimeoutMinutes() uint32 {
+	if x != nil {
+		return x.IdleTimeoutMinutes
+	}
+	return 0
+}
+
+func (x *CreatEnvRequest) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *CreatEnvRequest) GetFreezeAt() int64 {
+	if x != nil {
+		return x.FreezeAt
+	}
+	return 0
+}
+
+func (x *CreatEnvRequest) GetTeardownAt() int64 {
+	if x != nil {
+		return x.TeardownAt
+	}
+	return 0
+}
+
+func (x *CreatEnvRequest) GetWarmLabs() uint32 {
+	if x != nil {
+		return x.WarmLabs
+	}
+	return 0
+}
+
+func (x *CreatEnvRequest) GetMinReadyLabs() uint32 {
+	if x != nil {
+		return x.MinReadyLabs
+	}
+	return 0
+}
+
+func (x *CreatEnvRequest) GetMaxReadyLabs() uint32 {
+	if x != nil {
+		return x.MaxReadyLabs
+	}
+	return 0
+}
+
+func (x *CreatEnvRequest) GetSharedScreens() bool {
+	if x != nil {
+		return x.SharedScreens
+	}
+	return false
+}
+
+func (x *CreatEnvRequest) GetFrontends() []*VmConfig {
+	if x != nil {
+		return x.Frontends
+	}
+	return nil
+}
+
+func (x *CreatEnvRequest) GetHybridLabs() bool {
+	if x != nil {
+		return x.HybridLabs
+	}
+	return false
+}
+
+func (x *CreatEnvRequest) GetImagePolicy() *ImagePolicy {
+	if x != nil {
+		return x.ImagePolicy
+	}
+	return nil
+}
+
+type ImagePolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Refuses images which are not pinned to a digest, ex. image@sha256:<hex>
+	RequireDigest bool `protobuf:"varint,1,opt,name=requireDigest,proto3" json:"requireDigest,omitempty"`
+	// Registries images may come from, docker.io for Docker Hub. Any registry is allowed if empty
+	AllowedRegistries []string `protobuf:"bytes,2,rep,name=allowedRegistries,proto3" json:"allowedRegistries,omitempty"`
+}
+
+func (x *ImagePolicy) Reset() {
+	*x = ImagePolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImagePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImagePolicy) ProtoMessage() {}
+
+func (x *ImagePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImagePolicy.ProtoReflect.Descriptor instead.
+func (*ImagePolicy) Descriptor() ([]byte, []int) {
 	return file_agent_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *StatusResponse) GetMessage() string {
+func (x *ImagePolicy) GetRequireDigest() bool {
 	if x != nil {
-		return x.Message
+		return x.RequireDigest
+	}
+	return false
+}
+
+func (x *ImagePolicy) GetAllowedRegistries() []string {
+	if x != nil {
+		return x.AllowedRegistries
+	}
+	return nil
+}
+
+type CloseEnvRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTag string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+}
+
+func (x *CloseEnvRequest) Reset() {
+	*x = CloseEnvRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseEnvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseEnvRequest) ProtoMessage() {}
+
+func (x *CloseEnvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseEnvRequest.ProtoReflect.Descriptor instead.
+func (*CloseEnvRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CloseEnvRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
 	}
 	return ""
 }
 
-type Lab struct {
+type SuspendEnvRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tag       string      `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	EventTag  string      `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
-	Exercises []*Exercise `protobuf:"bytes,3,rep,name=exercises,proto3" json:"exercises,omitempty"`
-	IsVPN     bool        `protobuf:"varint,4,opt,name=isVPN,proto3" json:"isVPN,omitempty"`
-	GuacCreds *GuacCreds  `protobuf:"bytes,5,opt,name=guacCreds,proto3" json:"guacCreds,omitempty"`
-	VpnConfs  []string    `protobuf:"bytes,6,rep,name=vpnConfs,proto3" json:"vpnConfs,omitempty"`
+	EventTag string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+}
+
+func (x *SuspendEnvRequest) Reset() {
+	*x = SuspendEnvRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuspendEnvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendEnvRequest) ProtoMessage() {}
+
+func (x *SuspendEnvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendEnvRequest.ProtoReflect.Descriptor instead.
+func (*SuspendEnvRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SuspendEnvRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+type ResumeEnvRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTag string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+}
+
+func (x *ResumeEnvRequest) Reset() {
+	*x = ResumeEnvRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeEnvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeEnvRequest) ProtoMessage() {}
+
+func (x *ResumeEnvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeEnvRequest.ProtoReflect.Descriptor instead.
+func (*ResumeEnvRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ResumeEnvRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+type ListEnvResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTags          map[string]bool `protobuf:"bytes,1,rep,name=eventTags,proto3" json:"eventTags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	StartingEventTags  map[string]bool `protobuf:"bytes,2,rep,name=startingEventTags,proto3" json:"startingEventTags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	ClosingEventTags   map[string]bool `protobuf:"bytes,3,rep,name=closingEventTags,proto3" json:"closingEventTags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	SuspendedEventTags map[string]bool `protobuf:"bytes,4,rep,name=suspendedEventTags,proto3" json:"suspendedEventTags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *ListEnvResponse) Reset() {
+	*x = ListEnvResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListEnvResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEnvResponse) ProtoMessage() {}
+
+func (x *ListEnvResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEnvResponse.ProtoReflect.Descriptor instead.
+func (*ListEnvResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListEnvResponse) GetEventTags() map[string]bool {
+	if x != nil {
+		return x.EventTags
+	}
+	return nil
+}
+
+func (x *ListEnvResponse) GetStartingEventTags() map[string]bool {
+	if x != nil {
+		return x.StartingEventTags
+	}
+	return nil
+}
+
+func (x *ListEnvResponse) GetClosingEventTags() map[string]bool {
+	if x != nil {
+		return x.ClosingEventTags
+	}
+	return nil
+}
+
+func (x *ListEnvResponse) GetSuspendedEventTags() map[string]bool {
+	if x != nil {
+		return x.SuspendedEventTags
+	}
+	return nil
+}
+
+type Schedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTag   string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	StartAt    int64  `protobuf:"varint,2,opt,name=startAt,proto3" json:"startAt,omitempty"`
+	FreezeAt   int64  `protobuf:"varint,3,opt,name=freezeAt,proto3" json:"freezeAt,omitempty"`
+	TeardownAt int64  `protobuf:"varint,4,opt,name=teardownAt,proto3" json:"teardownAt,omitempty"`
+	Started    bool   `protobuf:"varint,5,opt,name=started,proto3" json:"started,omitempty"`
+	Frozen     bool   `protobuf:"varint,6,opt,name=frozen,proto3" json:"frozen,omitempty"`
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Schedule) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *Schedule) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *Schedule) GetFreezeAt() int64 {
+	if x != nil {
+		return x.FreezeAt
+	}
+	return 0
+}
+
+func (x *Schedule) GetTeardownAt() int64 {
+	if x != nil {
+		return x.TeardownAt
+	}
+	return 0
+}
+
+func (x *Schedule) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *Schedule) GetFrozen() bool {
+	if x != nil {
+		return x.Frozen
+	}
+	return false
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedules []*Schedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type CreateLabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTag   string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	IsVPN      bool   `protobuf:"varint,2,opt,name=isVPN,proto3" json:"isVPN,omitempty"`
+	TtlMinutes uint32 `protobuf:"varint,3,opt,name=ttlMinutes,proto3" json:"ttlMinutes,omitempty"`
+}
+
+func (x *CreateLabRequest) Reset() {
+	*x = CreateLabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateLabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLabRequest) ProtoMessage() {}
+
+func (x *CreateLabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLabRequest.ProtoReflect.Descriptor instead.
+func (*CreateLabRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateLabRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *CreateLabRequest) GetIsVPN() bool {
+	if x != nil {
+		return x.IsVPN
+	}
+	return false
+}
+
+func (x *CreateLabRequest) GetTtlMinutes() uint32 {
+	if x != nil {
+		return x.TtlMinutes
+	}
+	return 0
+}
+
+type CreateVpnConfRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
+}
+
+func (x *CreateVpnConfRequest) Reset() {
+	*x = CreateVpnConfRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateVpnConfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVpnConfRequest) ProtoMessage() {}
+
+func (x *CreateVpnConfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVpnConfRequest.ProtoReflect.Descriptor instead.
+func (*CreateVpnConfRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateVpnConfRequest) GetLabTag() string {
+	if x != nil {
+		return x.LabTag
+	}
+	return ""
+}
+
+func (x *CreateVpnConfRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *CreateVpnConfRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type CreateVpnConfResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Configs []string `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
+}
+
+func (x *CreateVpnConfResponse) Reset() {
+	*x = CreateVpnConfResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateVpnConfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVpnConfResponse) ProtoMessage() {}
+
+func (x *CreateVpnConfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVpnConfResponse.ProtoReflect.Descriptor instead.
+func (*CreateVpnConfResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateVpnConfResponse) GetConfigs() []string {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+type CloseLabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,3,opt,name=teamId,proto3" json:"teamId,omitempty"`
+}
+
+func (x *CloseLabRequest) Reset() {
+	*x = CloseLabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseLabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseLabRequest) ProtoMessage() {}
+
+func (x *CloseLabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseLabRequest.ProtoReflect.Descriptor instead.
+func (*CloseLabRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CloseLabRequest) GetLabTag() string {
+	if x != nil {
+		return x.LabTag
+	}
+	return ""
+}
+
+func (x *CloseLabRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *CloseLabRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type AssignLabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTag string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,2,opt,name=teamId,proto3" json:"teamId,omitempty"`
+	// Optional, if empty a ready lab is claimed
+	LabTag string `protobuf:"bytes,3,opt,name=labTag,proto3" json:"labTag,omitempty"`
+}
+
+func (x *AssignLabRequest) Reset() {
+	*x = AssignLabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AssignLabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignLabRequest) ProtoMessage() {}
+
+func (x *AssignLabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignLabRequest.ProtoReflect.Descriptor instead.
+func (*AssignLabRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AssignLabRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *AssignLabRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *AssignLabRequest) GetLabTag() string {
+	if x != nil {
+		return x.LabTag
+	}
+	return ""
+}
+
+type ReleaseLabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTag string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,2,opt,name=teamId,proto3" json:"teamId,omitempty"`
+}
+
+func (x *ReleaseLabRequest) Reset() {
+	*x = ReleaseLabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseLabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseLabRequest) ProtoMessage() {}
+
+func (x *ReleaseLabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseLabRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseLabRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReleaseLabRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *ReleaseLabRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type GetLabForTeamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTag string `protobuf:"bytes,1,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,2,opt,name=teamId,proto3" json:"teamId,omitempty"`
+}
+
+func (x *GetLabForTeamRequest) Reset() {
+	*x = GetLabForTeamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLabForTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabForTeamRequest) ProtoMessage() {}
+
+func (x *GetLabForTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabForTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetLabForTeamRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetLabForTeamRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *GetLabForTeamRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type ExtendLabRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabTag   string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	Minutes  uint32 `protobuf:"varint,2,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	EventTag string `protobuf:"bytes,3,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	TeamId   string `protobuf:"bytes,4,opt,name=teamId,proto3" json:"teamId,omitempty"`
+}
+
+func (x *ExtendLabRequest) Reset() {
+	*x = ExtendLabRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendLabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendLabRequest) ProtoMessage() {}
+
+func (x *ExtendLabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendLabRequest.ProtoReflect.Descriptor instead.
+func (*ExtendLabRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExtendLabRequest) GetLabTag() string {
+	if x != nil {
+		return x.LabTag
+	}
+	return ""
+}
+
+func (x *ExtendLabRequest) GetMinutes() uint32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *ExtendLabRequest) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *ExtendLabRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type ExtendLabResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExpiresAt int64 `protobuf:"varint,1,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+}
+
+func (x *ExtendLabResponse) Reset() {
+	*x = ExtendLabResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtendLabResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendLabResponse) ProtoMessage() {}
+
+func (x *ExtendLabResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendLabResponse.ProtoReflect.Descriptor instead.
+func (*ExtendLabResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ExtendLabResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type ExerciseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabTag          string            `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	EnvTag          string            `protobuf:"bytes,2,opt,name=envTag,proto3" json:"envTag,omitempty"`
+	Exercises       []string          `protobuf:"bytes,3,rep,name=exercises,proto3" json:"exercises,omitempty"`
+	Exercise        string            `protobuf:"bytes,4,opt,name=exercise,proto3" json:"exercise,omitempty"`
+	ExerciseConfigs []*ExerciseConfig `protobuf:"bytes,5,rep,name=exerciseConfigs,proto3" json:"exerciseConfigs,omitempty"`
+	TeamId          string            `protobuf:"bytes,6,opt,name=teamId,proto3" json:"teamId,omitempty"`
+}
+
+func (x *ExerciseRequest) Reset() {
+	*x = ExerciseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExerciseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExerciseRequest) ProtoMessage() {}
+
+func (x *ExerciseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExerciseRequest.ProtoReflect.Descriptor instead.
+func (*ExerciseRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ExerciseRequest) GetLabTag() string {
+	if x != nil {
+		return x.LabTag
+	}
+	return ""
+}
+
+func (x *ExerciseRequest) GetEnvTag() string {
+	if x != nil {
+		return x.EnvTag
+	}
+	return ""
+}
+
+func (x *ExerciseRequest) GetExercises() []string {
+	if x != nil {
+		return x.Exercises
+	}
+	return nil
+}
+
+func (x *ExerciseRequest) GetExercise() string {
+	if x != nil {
+		return x.Exercise
+	}
+	return ""
+}
+
+func (x *ExerciseRequest) GetExerciseConfigs() []*ExerciseConfig {
+	if x != nil {
+		return x.ExerciseConfigs
+	}
+	return nil
+}
+
+func (x *ExerciseRequest) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+type VmConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Image    string  `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	MemoryMB uint32  `protobuf:"varint,2,opt,name=memoryMB,proto3" json:"memoryMB,omitempty"`
+	Cpu      float64 `protobuf:"fixed64,3,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Name     string  `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// vbox (default) or docker
+	Type string `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	// rdp (default) or vnc
+	Protocol string `protobuf:"bytes,6,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// Remote desktop port inside docker frontends
+	Port uint32 `protobuf:"varint,7,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (x *VmConfig) Reset() {
+	*x = VmConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VmConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VmConfig) ProtoMessage() {}
+
+func (x *VmConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VmConfig.ProtoReflect.Descriptor instead.
+func (*VmConfig) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *VmConfig) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *VmConfig) GetMemoryMB() uint32 {
+	if x != nil {
+		return x.MemoryMB
+	}
+	return 0
+}
+
+func (x *VmConfig) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *VmConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VmConfig) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VmConfig) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *VmConfig) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type Lab struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag       string      `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	EventTag  string      `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	Exercises []*Exercise `protobuf:"bytes,3,rep,name=exercises,proto3" json:"exercises,omitempty"`
+	IsVPN     bool        `protobuf:"varint,4,opt,name=isVPN,proto3" json:"isVPN,omitempty"`
+	GuacCreds *GuacCreds  `protobuf:"bytes,5,opt,name=guacCreds,proto3" json:"guacCreds,omitempty"`
+	VpnConfs  []string    `protobuf:"bytes,6,rep,name=vpnConfs,proto3" json:"vpnConfs,omitempty"`
+	ExpiresAt int64       `protobuf:"varint,7,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	TeamId    string      `protobuf:"bytes,8,opt,name=teamId,proto3" json:"teamId,omitempty"`
+	// Credentials of the individual team members if the team size is larger than one, each owning one frontend
+	MemberCreds []*GuacCreds `protobuf:"bytes,9,rep,name=memberCreds,proto3" json:"memberCreds,omitempty"`
+	// Hybrid labs have browser frontends and can have VPN access at the same time
+	IsHybrid bool `protobuf:"varint,10,opt,name=isHybrid,proto3" json:"isHybrid,omitempty"`
+}
+
+func (x *Lab) Reset() {
+	*x = Lab{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lab) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lab) ProtoMessage() {}
+
+func (x *Lab) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lab.ProtoReflect.Descriptor instead.
+func (*Lab) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Lab) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Lab) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *Lab) GetExercises() []*Exercise {
+	if x != nil {
+		return x.Exercises
+	}
+	return nil
+}
+
+func (x *Lab) GetIsVPN() bool {
+	if x != nil {
+		return x.IsVPN
+	}
+	return false
+}
+
+func (x *Lab) GetGuacCreds() *GuacCreds {
+	if x != nil {
+		return x.GuacCreds
+	}
+	return nil
+}
+
+func (x *Lab) GetVpnConfs() []string {
+	if x != nil {
+		return x.VpnConfs
+	}
+	return nil
+}
+
+func (x *Lab) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Lab) GetTeamId() string {
+	if x != nil {
+		return x.TeamId
+	}
+	return ""
+}
+
+func (x *Lab) GetMemberCreds() []*GuacCreds {
+	if x != nil {
+		return x.MemberCreds
+	}
+	return nil
+}
+
+func (x *Lab) GetIsHybrid() bool {
+	if x != nil {
+		return x.IsHybrid
+	}
+	return false
+}
+
+type Exercise struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag            string           `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	ChildExercises []*ChildExercise `protobuf:"bytes,2,rep,name=childExercises,proto3" json:"childExercises,omitempty"`
+	Machines       []*Machine       `protobuf:"bytes,3,rep,name=machines,proto3" json:"machines,omitempty"`
+	// creating, starting, running, stopped, resetting or failed
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// Set if the exercise has failed
+	StatusReason    string `protobuf:"bytes,5,opt,name=statusReason,proto3" json:"statusReason,omitempty"`
+	StatusChangedAt int64  `protobuf:"varint,6,opt,name=statusChangedAt,proto3" json:"statusChangedAt,omitempty"`
+}
+
+func (x *Exercise) Reset() {
+	*x = Exercise{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Exercise) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Exercise) ProtoMessage() {}
+
+func (x *Exercise) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Exercise.ProtoReflect.Descriptor instead.
+func (*Exercise) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Exercise) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Exercise) GetChildExercises() []*ChildExercise {
+	if x != nil {
+		return x.ChildExercises
+	}
+	return nil
+}
+
+func (x *Exercise) GetMachines() []*Machine {
+	if x != nil {
+		return x.Machines
+	}
+	return nil
+}
+
+func (x *Exercise) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Exercise) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Exercise) GetStatusChangedAt() int64 {
+	if x != nil {
+		return x.StatusChangedAt
+	}
+	return 0
+}
+
+type ExerciseEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabTag      string `protobuf:"bytes,1,opt,name=labTag,proto3" json:"labTag,omitempty"`
+	ExerciseTag string `protobuf:"bytes,2,opt,name=exerciseTag,proto3" json:"exerciseTag,omitempty"`
+	Status      string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason      string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Timestamp   int64  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ExerciseEvent) Reset() {
+	*x = ExerciseEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExerciseEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExerciseEvent) ProtoMessage() {}
+
+func (x *ExerciseEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExerciseEvent.ProtoReflect.Descriptor instead.
+func (*ExerciseEvent) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ExerciseEvent) GetLabTag() string {
+	if x != nil {
+		return x.LabTag
+	}
+	return ""
+}
+
+func (x *ExerciseEvent) GetExerciseTag() string {
+	if x != nil {
+		return x.ExerciseTag
+	}
+	return ""
+}
+
+func (x *ExerciseEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExerciseEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ExerciseEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// Lab closed by the agent on its own, which the daemon must no longer hand out to teams
+type ClosedLab struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag      string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	EventTag string `protobuf:"bytes,2,opt,name=eventTag,proto3" json:"eventTag,omitempty"`
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ClosedLab) Reset() {
+	*x = ClosedLab{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClosedLab) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosedLab) ProtoMessage() {}
+
+func (x *ClosedLab) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosedLab.ProtoReflect.Descriptor instead.
+func (*ClosedLab) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ClosedLab) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ClosedLab) GetEventTag() string {
+	if x != nil {
+		return x.EventTag
+	}
+	return ""
+}
+
+func (x *ClosedLab) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ChildExercise struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag  string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Flag string `protobuf:"bytes,2,opt,name=flag,proto3" json:"flag,omitempty"`
+}
+
+func (x *ChildExercise) Reset() {
+	*x = ChildExercise{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChildExercise) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChildExercise) ProtoMessage() {}
+
+func (x *ChildExercise) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChildExercise.ProtoReflect.Descriptor instead.
+func (*ChildExercise) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ChildExercise) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ChildExercise) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+type Machine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Image  string   `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Errors []string `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	Type   string   `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	// Digest of the image of containers
+	Digest string `protobuf:"bytes,6,opt,name=digest,proto3" json:"digest,omitempty"`
+	// none, starting, healthy or unhealthy. Only containers with a health check have a health
+	Health string `protobuf:"bytes,7,opt,name=health,proto3" json:"health,omitempty"`
+	// Error of the latest failed health check
+	HealthError string `protobuf:"bytes,8,opt,name=healthError,proto3" json:"healthError,omitempty"`
+}
+
+func (x *Machine) Reset() {
+	*x = Machine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Machine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Machine) ProtoMessage() {}
+
+func (x *Machine) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Machine.ProtoReflect.Descriptor instead.
+func (*Machine) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Machine) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Machine) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Machine) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Machine) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *Machine) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Machine) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *Machine) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *Machine) GetHealthError() string {
+	if x != nil {
+		return x.HealthError
+	}
+	return ""
+}
+
+type GuacCreds struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *GuacCreds) Reset() {
+	*x = GuacCreds{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuacCreds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuacCreds) ProtoMessage() {}
+
+func (x *GuacCreds) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuacCreds.ProtoReflect.Descriptor instead.
+func (*GuacCreds) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GuacCreds) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GuacCreds) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ExerciseConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag      string                    `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Static   bool                      `protobuf:"varint,2,opt,name=static,proto3" json:"static,omitempty"`
+	Instance []*ExerciseInstanceConfig `protobuf:"bytes,3,rep,name=instance,proto3" json:"instance,omitempty"`
+	// Tags of exercises in the same lab which must be ready before this exercise is started
+	DependsOn []string `protobuf:"bytes,4,rep,name=dependsOn,proto3" json:"dependsOn,omitempty"`
+}
+
+func (x *ExerciseConfig) Reset() {
+	*x = ExerciseConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExerciseConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExerciseConfig) ProtoMessage() {}
+
+func (x *ExerciseConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExerciseConfig.ProtoReflect.Descriptor instead.
+func (*ExerciseConfig) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ExerciseConfig) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ExerciseConfig) GetStatic() bool {
+	if x != nil {
+		return x.Static
+	}
+	return false
+}
+
+func (x *ExerciseConfig) GetInstance() []*ExerciseInstanceConfig {
+	if x != nil {
+		return x.Instance
+	}
+	return nil
+}
+
+func (x *ExerciseConfig) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+type ExerciseInstanceConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Image       string                `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Memory      uint32                `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Cpu         float32               `protobuf:"fixed32,3,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Envs        []*EnvVarConfig       `protobuf:"bytes,4,rep,name=envs,proto3" json:"envs,omitempty"`
+	Children    []*ChildrenChalConfig `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"`
+	Records     []*RecordConfig       `protobuf:"bytes,6,rep,name=records,proto3" json:"records,omitempty"`
+	Healthcheck *HealthCheck          `protobuf:"bytes,7,opt,name=healthcheck,proto3" json:"healthcheck,omitempty"`
+	// Names of instances of the same exercise which must be ready before this instance is started
+	Name      string   `protobuf:"bytes,8,opt,name=name,proto3" json:"name,omitempty"`
+	DependsOn []string `protobuf:"bytes,9,rep,name=dependsOn,proto3" json:"dependsOn,omitempty"`
+	// Overrides of the command, entrypoint, working directory and user of the image
+	Cmd        []string `protobuf:"bytes,10,rep,name=cmd,proto3" json:"cmd,omitempty"`
+	Entrypoint []string `protobuf:"bytes,11,rep,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	WorkingDir string   `protobuf:"bytes,12,opt,name=workingDir,proto3" json:"workingDir,omitempty"`
+	User       string   `protobuf:"bytes,13,opt,name=user,proto3" json:"user,omitempty"`
+	// Linux capabilities added to and dropped from the default set, ex. NET_ADMIN
+	CapAdd  []string `protobuf:"bytes,14,rep,name=capAdd,proto3" json:"capAdd,omitempty"`
+	CapDrop []string `protobuf:"bytes,15,rep,name=capDrop,proto3" json:"capDrop,omitempty"`
+	// Namespaced kernel parameters, ex. net.ipv4.ip_forward
+	Sysctls map[string]string `protobuf:"bytes,16,rep,name=sysctls,proto3" json:"sysctls,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Tmpfs mounts by path, with mount options such as size=64m
+	Tmpfs          map[string]string `protobuf:"bytes,17,rep,name=tmpfs,proto3" json:"tmpfs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ReadOnlyRootfs bool              `protobuf:"varint,18,opt,name=readOnlyRootfs,proto3" json:"readOnlyRootfs,omitempty"`
+	// Extra /etc/hosts entries as hostname:ip
+	ExtraHosts []string `protobuf:"bytes,19,rep,name=extraHosts,proto3" json:"extraHosts,omitempty"`
+}
+
+func (x *ExerciseInstanceConfig) Reset() {
+	*x = ExerciseInstanceConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExerciseInstanceConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExerciseInstanceConfig) ProtoMessage() {}
+
+func (x *ExerciseInstanceConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExerciseInstanceConfig.ProtoReflect.Descriptor instead.
+func (*ExerciseInstanceConfig) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ExerciseInstanceConfig) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *ExerciseInstanceConfig) GetMemory() uint32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *ExerciseInstanceConfig) GetCpu() float32 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *ExerciseInstanceConfig) GetEnvs() []*EnvVarConfig {
+	if x != nil {
+		return x.Envs
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetChildren() []*ChildrenChalConfig {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetRecords() []*RecordConfig {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetHealthcheck() *HealthCheck {
+	if x != nil {
+		return x.Healthcheck
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExerciseInstanceConfig) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetCmd() []string {
+	if x != nil {
+		return x.Cmd
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetEntrypoint() []string {
+	if x != nil {
+		return x.Entrypoint
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *ExerciseInstanceConfig) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ExerciseInstanceConfig) GetCapAdd() []string {
+	if x != nil {
+		return x.CapAdd
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetCapDrop() []string {
+	if x != nil {
+		return x.CapDrop
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetSysctls() map[string]string {
+	if x != nil {
+		return x.Sysctls
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetTmpfs() map[string]string {
+	if x != nil {
+		return x.Tmpfs
+	}
+	return nil
+}
+
+func (x *ExerciseInstanceConfig) GetReadOnlyRootfs() bool {
+	if x != nil {
+		return x.ReadOnlyRootfs
+	}
+	return false
+}
+
+func (x *ExerciseInstanceConfig) GetExtraHosts() []string {
+	if x != nil {
+		return x.ExtraHosts
+	}
+	return nil
+}
+
+type HealthCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tcp, http or exec
+	Type           string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Port           uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Path           string   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	ExpectedStatus int32    `protobuf:"varint,4,opt,name=expectedStatus,proto3" json:"expectedStatus,omitempty"`
+	Command        []string `protobuf:"bytes,5,rep,name=command,proto3" json:"command,omitempty"`
+	// Seconds
+	Interval    uint32 `protobuf:"varint,6,opt,name=interval,proto3" json:"interval,omitempty"`
+	Timeout     uint32 `protobuf:"varint,7,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	StartPeriod uint32 `protobuf:"varint,8,opt,name=startPeriod,proto3" json:"startPeriod,omitempty"`
+	// Consecutive failures before the container is unhealthy
+	Retries uint32 `protobuf:"varint,9,opt,name=retries,proto3" json:"retries,omitempty"`
+	// restart or reset
+	Action string `protobuf:"bytes,10,opt,name=action,proto3" json:"action,omitempty"`
+}
+
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheck) ProtoMessage() {}
+
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *HealthCheck) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetExpectedStatus() int32 {
+	if x != nil {
+		return x.ExpectedStatus
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *HealthCheck) GetInterval() uint32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetTimeout() uint32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetStartPeriod() uint32 {
+	if x != nil {
+		return x.StartPeriod
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetRetries() uint32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type EnvVarConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *EnvVarConfig) Reset() {
+	*x = EnvVarConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnvVarConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvVarConfig) ProtoMessage() {}
+
+func (x *EnvVarConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvVarConfig.ProtoReflect.Descriptor instead.
+func (*EnvVarConfig) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *EnvVarConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EnvVarConfig) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ChildrenChalConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag     string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	EnvFlag string `protobuf:"bytes,3,opt,name=envFlag,proto3" json:"envFlag,omitempty"`
+	Static  string `protobuf:"bytes,4,opt,name=static,proto3" json:"static,omitempty"`
 }
 
-func (x *Lab) Reset() {
-	*x = Lab{}
+func (x *ChildrenChalConfig) Reset() {
+	*x = ChildrenChalConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[21]
+		mi := &file_agent_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Lab) String() string {
+func (x *ChildrenChalConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Lab) ProtoMessage() {}
+func (*ChildrenChalConfig) ProtoMessage() {}
 
-func (x *Lab) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[21]
+func (x *ChildrenChalConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1246,80 +3761,66 @@ func (x *Lab) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Lab.ProtoReflect.Descriptor instead.
-func (*Lab) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ChildrenChalConfig.ProtoReflect.Descriptor instead.
+func (*ChildrenChalConfig) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *Lab) GetTag() string {
+func (x *ChildrenChalConfig) GetTag() string {
 	if x != nil {
 		return x.Tag
 	}
 	return ""
 }
 
-func (x *Lab) GetEventTag() string {
+func (x *ChildrenChalConfig) GetName() string {
 	if x != nil {
-		return x.EventTag
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Lab) GetExercises() []*Exercise {
-	if x != nil {
-		return x.Exercises
-	}
-	return nil
-}
-
-func (x *Lab) GetIsVPN() bool {
-	if x != nil {
-		return x.IsVPN
-	}
-	return false
-}
-
-func (x *Lab) GetGuacCreds() *GuacCreds {
+func (x *ChildrenChalConfig) GetEnvFlag() string {
 	if x != nil {
-		return x.GuacCreds
+		return x.EnvFlag
 	}
-	return nil
+	return ""
 }
 
-func (x *Lab) GetVpnConfs() []string {
+func (x *ChildrenChalConfig) GetStatic() string {
 	if x != nil {
-		return x.VpnConfs
+		return x.Static
 	}
-	return nil
+	return ""
 }
 
-type Exercise struct {
+type RecordConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tag            string           `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	ChildExercises []*ChildExercise `protobuf:"bytes,2,rep,name=childExercises,proto3" json:"childExercises,omitempty"`
-	Machines       []*Machine       `protobuf:"bytes,3,rep,name=machines,proto3" json:"machines,omitempty"`
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Data string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *Exercise) Reset() {
-	*x = Exercise{}
+func (x *RecordConfig) Reset() {
+	*x = RecordConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[22]
+		mi := &file_agent_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Exercise) String() string {
+func (x *RecordConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Exercise) ProtoMessage() {}
+func (*RecordConfig) ProtoMessage() {}
 
-func (x *Exercise) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[22]
+func (x *RecordConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1330,58 +3831,64 @@ func (x *Exercise) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Exercise.ProtoReflect.Descriptor instead.
-func (*Exercise) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use RecordConfig.ProtoReflect.Descriptor instead.
+func (*RecordConfig) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *Exercise) GetTag() string {
+func (x *RecordConfig) GetType() string {
 	if x != nil {
-		return x.Tag
+		return x.Type
 	}
 	return ""
 }
 
-func (x *Exercise) GetChildExercises() []*ChildExercise {
+func (x *RecordConfig) GetName() string {
 	if x != nil {
-		return x.ChildExercises
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *Exercise) GetMachines() []*Machine {
+func (x *RecordConfig) GetData() string {
 	if x != nil {
-		return x.Machines
+		return x.Data
 	}
-	return nil
+	return ""
 }
 
-type ChildExercise struct {
+type Ova struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tag  string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	Flag string `protobuf:"bytes,2,opt,name=flag,proto3" json:"flag,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Hex encoded crc32 checksum, empty if not calculated yet
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	// True if the base vm has been imported
+	Imported bool `protobuf:"varint,4,opt,name=imported,proto3" json:"imported,omitempty"`
+	// True if used by an environment on the agent
+	InUse bool `protobuf:"varint,5,opt,name=inUse,proto3" json:"inUse,omitempty"`
 }
 
-func (x *ChildExercise) Reset() {
-	*x = ChildExercise{}
+func (x *Ova) Reset() {
+	*x = Ova{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[23]
+		mi := &file_agent_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ChildExercise) String() string {
+func (x *Ova) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChildExercise) ProtoMessage() {}
+func (*Ova) ProtoMessage() {}
 
-func (x *ChildExercise) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[23]
+func (x *Ova) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1392,54 +3899,71 @@ func (x *ChildExercise) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChildExercise.ProtoReflect.Descriptor instead.
-func (*ChildExercise) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use Ova.ProtoReflect.Descriptor instead.
+func (*Ova) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *ChildExercise) GetTag() string {
+func (x *Ova) GetName() string {
 	if x != nil {
-		return x.Tag
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ChildExercise) GetFlag() string {
+func (x *Ova) GetSize() int64 {
 	if x != nil {
-		return x.Flag
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Ova) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
 	}
 	return ""
 }
 
-type Machine struct {
+func (x *Ova) GetImported() bool {
+	if x != nil {
+		return x.Imported
+	}
+	return false
+}
+
+func (x *Ova) GetInUse() bool {
+	if x != nil {
+		return x.InUse
+	}
+	return false
+}
+
+type ListOvasResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id     string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	Image  string   `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
-	Errors []string `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
-	Type   string   `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Ovas []*Ova `protobuf:"bytes,1,rep,name=ovas,proto3" json:"ovas,omitempty"`
 }
 
-func (x *Machine) Reset() {
-	*x = Machine{}
+func (x *ListOvasResponse) Reset() {
+	*x = ListOvasResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[24]
+		mi := &file_agent_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Machine) String() string {
+func (x *ListOvasResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Machine) ProtoMessage() {}
+func (*ListOvasResponse) ProtoMessage() {}
 
-func (x *Machine) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[24]
+func (x *ListOvasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1450,72 +3974,108 @@ func (x *Machine) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Machine.ProtoReflect.Descriptor instead.
-func (*Machine) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ListOvasResponse.ProtoReflect.Descriptor instead.
+func (*ListOvasResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *Machine) GetId() string {
+func (x *ListOvasResponse) GetOvas() []*Ova {
 	if x != nil {
-		return x.Id
+		return x.Ovas
 	}
-	return ""
+	return nil
 }
 
-func (x *Machine) GetStatus() string {
-	if x != nil {
-		return x.Status
+type OvaChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the ova without extension, required in the first chunk
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Optional hex encoded crc32 checksum of the whole ova
+	Checksum string `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+}
+
+func (x *OvaChunk) Reset() {
+	*x = OvaChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_agent_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Machine) GetImage() string {
+func (x *OvaChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OvaChunk) ProtoMessage() {}
+
+func (x *OvaChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OvaChunk.ProtoReflect.Descriptor instead.
+func (*OvaChunk) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *OvaChunk) GetName() string {
 	if x != nil {
-		return x.Image
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Machine) GetErrors() []string {
+func (x *OvaChunk) GetData() []byte {
 	if x != nil {
-		return x.Errors
+		return x.Data
 	}
 	return nil
 }
 
-func (x *Machine) GetType() string {
+func (x *OvaChunk) GetChecksum() string {
 	if x != nil {
-		return x.Type
+		return x.Checksum
 	}
 	return ""
 }
 
-type GuacCreds struct {
+type OvaRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *GuacCreds) Reset() {
-	*x = GuacCreds{}
+func (x *OvaRequest) Reset() {
+	*x = OvaRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[25]
+		mi := &file_agent_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GuacCreds) String() string {
+func (x *OvaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GuacCreds) ProtoMessage() {}
+func (*OvaRequest) ProtoMessage() {}
 
-func (x *GuacCreds) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[25]
+func (x *OvaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1526,52 +4086,46 @@ func (x *GuacCreds) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GuacCreds.ProtoReflect.Descriptor instead.
-func (*GuacCreds) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *GuacCreds) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
+// Deprecated: Use OvaRequest.ProtoReflect.Descriptor instead.
+func (*OvaRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *GuacCreds) GetPassword() string {
+func (x *OvaRequest) GetName() string {
 	if x != nil {
-		return x.Password
+		return x.Name
 	}
 	return ""
 }
 
-type ExerciseConfig struct {
+type PrePullImagesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tag      string                    `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	Static   bool                      `protobuf:"varint,2,opt,name=static,proto3" json:"static,omitempty"`
-	Instance []*ExerciseInstanceConfig `protobuf:"bytes,3,rep,name=instance,proto3" json:"instance,omitempty"`
+	// Pulls the images used by the environment if set
+	EnvTag string `protobuf:"bytes,1,opt,name=envTag,proto3" json:"envTag,omitempty"`
+	// Additional images to pull
+	Images []string `protobuf:"bytes,2,rep,name=images,proto3" json:"images,omitempty"`
 }
 
-func (x *ExerciseConfig) Reset() {
-	*x = ExerciseConfig{}
+func (x *PrePullImagesRequest) Reset() {
+	*x = PrePullImagesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[26]
+		mi := &file_agent_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExerciseConfig) String() string {
+func (x *PrePullImagesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExerciseConfig) ProtoMessage() {}
+func (*PrePullImagesRequest) ProtoMessage() {}
 
-func (x *ExerciseConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[26]
+func (x *PrePullImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1582,62 +4136,56 @@ func (x *ExerciseConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExerciseConfig.ProtoReflect.Descriptor instead.
-func (*ExerciseConfig) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use PrePullImagesRequest.ProtoReflect.Descriptor instead.
+func (*PrePullImagesRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *ExerciseConfig) GetTag() string {
+func (x *PrePullImagesRequest) GetEnvTag() string {
 	if x != nil {
-		return x.Tag
+		return x.EnvTag
 	}
 	return ""
 }
 
-func (x *ExerciseConfig) GetStatic() bool {
-	if x != nil {
-		return x.Static
-	}
-	return false
-}
-
-func (x *ExerciseConfig) GetInstance() []*ExerciseInstanceConfig {
+func (x *PrePullImagesRequest) GetImages() []string {
 	if x != nil {
-		return x.Instance
+		return x.Images
 	}
 	return nil
 }
 
-type ExerciseInstanceConfig struct {
+type ImagePullProgress struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Image    string                `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	Memory   uint32                `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	Cpu      float32               `protobuf:"fixed32,3,opt,name=cpu,proto3" json:"cpu,omitempty"`
-	Envs     []*EnvVarConfig       `protobuf:"bytes,4,rep,name=envs,proto3" json:"envs,omitempty"`
-	Children []*ChildrenChalConfig `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"`
-	Records  []*RecordConfig       `protobuf:"bytes,6,rep,name=records,proto3" json:"records,omitempty"`
+	Image string `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// One of waiting, pulling, pulled, up-to-date or failed
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Bytes downloaded and total bytes of the layers being downloaded
+	Current int64  `protobuf:"varint,3,opt,name=current,proto3" json:"current,omitempty"`
+	Total   int64  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Error   string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *ExerciseInstanceConfig) Reset() {
-	*x = ExerciseInstanceConfig{}
+func (x *ImagePullProgress) Reset() {
+	*x = ImagePullProgress{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[27]
+		mi := &file_agent_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExerciseInstanceConfig) String() string {
+func (x *ImagePullProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExerciseInstanceConfig) ProtoMessage() {}
+func (*ImagePullProgress) ProtoMessage() {}
 
-func (x *ExerciseInstanceConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[27]
+func (x *ImagePullProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1648,79 +4196,74 @@ func (x *ExerciseInstanceConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExerciseInstanceConfig.ProtoReflect.Descriptor instead.
-func (*ExerciseInstanceConfig) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *ExerciseInstanceConfig) GetImage() string {
-	if x != nil {
-		return x.Image
-	}
-	return ""
+// Deprecated: Use ImagePullProgress.ProtoReflect.Descriptor instead.
+func (*ImagePullProgress) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ExerciseInstanceConfig) GetMemory() uint32 {
+func (x *ImagePullProgress) GetImage() string {
 	if x != nil {
-		return x.Memory
+		return x.Image
 	}
-	return 0
+	return ""
 }
 
-func (x *ExerciseInstanceConfig) GetCpu() float32 {
+func (x *ImagePullProgress) GetStatus() string {
 	if x != nil {
-		return x.Cpu
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *ExerciseInstanceConfig) GetEnvs() []*EnvVarConfig {
+func (x *ImagePullProgress) GetCurrent() int64 {
 	if x != nil {
-		return x.Envs
+		return x.Current
 	}
-	return nil
+	return 0
 }
 
-func (x *ExerciseInstanceConfig) GetChildren() []*ChildrenChalConfig {
+func (x *ImagePullProgress) GetTotal() int64 {
 	if x != nil {
-		return x.Children
+		return x.Total
 	}
-	return nil
+	return 0
 }
 
-func (x *ExerciseInstanceConfig) GetRecords() []*RecordConfig {
+func (x *ImagePullProgress) GetError() string {
 	if x != nil {
-		return x.Records
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-type EnvVarConfig struct {
+type PruneImagesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Only reports the images which would be removed
+	DryRun bool `protobuf:"varint,1,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	// Overrides the configured max age if set
+	MaxAgeMinutes uint32 `protobuf:"varint,2,opt,name=maxAgeMinutes,proto3" json:"maxAgeMinutes,omitempty"`
 }
 
-func (x *EnvVarConfig) Reset() {
-	*x = EnvVarConfig{}
+func (x *PruneImagesRequest) Reset() {
+	*x = PruneImagesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[28]
+		mi := &file_agent_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EnvVarConfig) String() string {
+func (x *PruneImagesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnvVarConfig) ProtoMessage() {}
+func (*PruneImagesRequest) ProtoMessage() {}
 
-func (x *EnvVarConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[28]
+func (x *PruneImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1731,53 +4274,54 @@ func (x *EnvVarConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnvVarConfig.ProtoReflect.Descriptor instead.
-func (*EnvVarConfig) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use PruneImagesRequest.ProtoReflect.Descriptor instead.
+func (*PruneImagesRequest) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *EnvVarConfig) GetName() string {
+func (x *PruneImagesRequest) GetDryRun() bool {
 	if x != nil {
-		return x.Name
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-func (x *EnvVarConfig) GetValue() string {
+func (x *PruneImagesRequest) GetMaxAgeMinutes() uint32 {
 	if x != nil {
-		return x.Value
+		return x.MaxAgeMinutes
 	}
-	return ""
+	return 0
 }
 
-type ChildrenChalConfig struct {
+type PrunedImage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tag     string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
-	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	EnvFlag string `protobuf:"bytes,3,opt,name=envFlag,proto3" json:"envFlag,omitempty"`
-	Static  string `protobuf:"bytes,4,opt,name=static,proto3" json:"static,omitempty"`
+	Id   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tags []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Size int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Either age or disk-usage
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *ChildrenChalConfig) Reset() {
-	*x = ChildrenChalConfig{}
+func (x *PrunedImage) Reset() {
+	*x = PrunedImage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[29]
+		mi := &file_agent_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ChildrenChalConfig) String() string {
+func (x *PrunedImage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChildrenChalConfig) ProtoMessage() {}
+func (*PrunedImage) ProtoMessage() {}
 
-func (x *ChildrenChalConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[29]
+func (x *PrunedImage) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1788,66 +4332,65 @@ func (x *ChildrenChalConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChildrenChalConfig.ProtoReflect.Descriptor instead.
-func (*ChildrenChalConfig) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use PrunedImage.ProtoReflect.Descriptor instead.
+func (*PrunedImage) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ChildrenChalConfig) GetTag() string {
+func (x *PrunedImage) GetId() string {
 	if x != nil {
-		return x.Tag
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ChildrenChalConfig) GetName() string {
+func (x *PrunedImage) GetTags() []string {
 	if x != nil {
-		return x.Name
+		return x.Tags
 	}
-	return ""
+	return nil
 }
 
-func (x *ChildrenChalConfig) GetEnvFlag() string {
+func (x *PrunedImage) GetSize() int64 {
 	if x != nil {
-		return x.EnvFlag
+		return x.Size
 	}
-	return ""
+	return 0
 }
 
-func (x *ChildrenChalConfig) GetStatic() string {
+func (x *PrunedImage) GetReason() string {
 	if x != nil {
-		return x.Static
+		return x.Reason
 	}
 	return ""
 }
 
-type RecordConfig struct {
+type PruneImagesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Data string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Images         []*PrunedImage `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	ReclaimedBytes int64          `protobuf:"varint,2,opt,name=reclaimedBytes,proto3" json:"reclaimedBytes,omitempty"`
 }
 
-func (x *RecordConfig) Reset() {
-	*x = RecordConfig{}
+func (x *PruneImagesResponse) Reset() {
+	*x = PruneImagesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_agent_proto_msgTypes[30]
+		mi := &file_agent_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordConfig) String() string {
+func (x *PruneImagesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordConfig) ProtoMessage() {}
+func (*PruneImagesResponse) ProtoMessage() {}
 
-func (x *RecordConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_agent_proto_msgTypes[30]
+func (x *PruneImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agent_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1858,150 +4401,350 @@ func (x *RecordConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordConfig.ProtoReflect.Descriptor instead.
-func (*RecordConfig) Descriptor() ([]byte, []int) {
-	return file_agent_proto_rawDescGZIP(), []int{30}
-}
-
-func (x *RecordConfig) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
+// Deprecated: Use PruneImagesResponse.ProtoReflect.Descriptor instead.
+func (*PruneImagesResponse) Descriptor() ([]byte, []int) {
+	return file_agent_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *RecordConfig) GetName() string {
+func (x *PruneImagesResponse) GetImages() []*PrunedImage {
 	if x != nil {
-		return x.Name
+		return x.Images
 	}
-	return ""
+	return nil
 }
 
-func (x *RecordConfig) GetData() string {
+func (x *PruneImagesResponse) GetReclaimedBytes() int64 {
 	if x != nil {
-		return x.Data
+		return x.ReclaimedBytes
 	}
-	return ""
+	return 0
 }
 
 var File_agent_proto protoreflect.FileDescriptor
 
 var file_agent_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x57, 0x0a,
-	0x09, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61,
-	0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54,
-	0x61, 0x67, 0x12, 0x32, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x29, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4c,
-	0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62,
-	0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61,
-	0x67, 0x22, 0x27, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x22, 0x2e, 0x0a, 0x0e, 0x47, 0x65,
-	0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x03,
-	0x6c, 0x61, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x4c, 0x61, 0x62, 0x52, 0x03, 0x6c, 0x61, 0x62, 0x22, 0x29, 0x0a, 0x0f, 0x47, 0x65,
-	0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c,
-	0x61, 0x62, 0x54, 0x61, 0x67, 0x22, 0x28, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73,
-	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22,
-	0x99, 0x01, 0x0a, 0x0f, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x68, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x68, 0x62, 0x12, 0x24, 0x0a, 0x07, 0x6e, 0x65, 0x77, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62,
-	0x52, 0x07, 0x6e, 0x65, 0x77, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x2e, 0x0a, 0x09, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09,
-	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x65,
-	0x75, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
-	0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x22, 0xeb, 0x01, 0x0a, 0x09,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x65, 0x6d,
-	0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x0c, 0x6d, 0x65, 0x6d, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x63, 0x70, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12,
-	0x26, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x50, 0x65, 0x72, 0x63,
-	0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x62, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x61, 0x62, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a,
-	0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d, 0x65, 0x6d,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x22, 0x21, 0x0a, 0x0b, 0x50, 0x69, 0x6e,
-	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x69, 0x6e, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x22, 0x46, 0x0a, 0x0c,
-	0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x6f, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x6f, 0x6e, 0x67,
-	0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x49, 0x6e, 0x73, 0x74, 0x61,
-	0x6c, 0x6c, 0x65, 0x64, 0x22, 0x85, 0x02, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x45, 0x6e,
-	0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x76, 0x54, 0x79, 0x70, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x76, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f,
-	0x0a, 0x02, 0x76, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x67, 0x65,
-	0x6e, 0x74, 0x2e, 0x56, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x02, 0x76, 0x6d, 0x12,
-	0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x4c, 0x61, 0x62,
-	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x18, 0x05,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12,
-	0x1a, 0x0a, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x65,
-	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x07,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65,
-	0x72, 0x63, 0x69, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0f, 0x65, 0x78, 0x65,
-	0x72, 0x63, 0x69, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x22, 0x2d, 0x0a, 0x0f,
-	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x67, 0x65, 0x6e, 0x74, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xd7, 0x01,
+	0x0a, 0x09, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x54, 0x61, 0x67, 0x12, 0x32, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xd7, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x69,
+	0x7a, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x54, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x12,
+	0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x63, 0x70,
+	0x75, 0x22, 0xbd, 0x01, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x12, 0x1a, 0x0a,
+	0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61,
+	0x6d, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x8a, 0x01, 0x0a, 0x0a, 0x56, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x72, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x6e,
+	0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61,
+	0x78, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0xad,
+	0x01, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54,
+	0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65,
+	0x61, 0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x70, 0x75, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x63, 0x70, 0x75, 0x22, 0x81,
+	0x01, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x46, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x12, 0x1a, 0x0a,
+	0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61,
+	0x6d, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x12, 0x1f, 0x0a, 0x02, 0x76, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x02,
+	0x76, 0x6d, 0x22, 0xaf, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61,
+	0x62, 0x54, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x5d, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x61, 0x62,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x12,
+	0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61,
+	0x6d, 0x49, 0x64, 0x22, 0x5b, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64,
+	0x22, 0x2e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x03, 0x6c, 0x61, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x52, 0x03, 0x6c, 0x61, 0x62,
+	0x22, 0x5d, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x22,
+	0x28, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0xb5, 0x02, 0x0a, 0x0f, 0x4d, 0x6f,
+	0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x68, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x68, 0x62, 0x12, 0x24, 0x0a,
+	0x07, 0x6e, 0x65, 0x77, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x4c,
+	0x61, 0x62, 0x73, 0x12, 0x2e, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x54, 0x61, 0x73,
+	0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64,
+	0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x2a, 0x0a, 0x08, 0x6c, 0x61, 0x62, 0x50, 0x6f, 0x6f, 0x6c,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x4c, 0x61, 0x62, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x08, 0x6c, 0x61, 0x62, 0x50, 0x6f, 0x6f, 0x6c,
+	0x73, 0x12, 0x3c, 0x0a, 0x0e, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x0e, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x30, 0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73,
+	0x65, 0x64, 0x4c, 0x61, 0x62, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4c, 0x61, 0x62,
+	0x73, 0x22, 0x6f, 0x0a, 0x07, 0x4c, 0x61, 0x62, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x1a,
+	0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x22, 0xeb, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x41, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e,
+	0x6d, 0x65, 0x6d, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x6c, 0x61, 0x62, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x6c, 0x61, 0x62, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x6d,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x6d, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x6d, 0x65, 0x6d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64,
+	0x22, 0x21, 0x0a, 0x0b, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70,
+	0x69, 0x6e, 0x67, 0x22, 0x46, 0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x6f, 0x6e, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x65, 0x6d, 0x49, 0x6e,
+	0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d,
+	0x65, 0x6d, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x22, 0xc0, 0x05, 0x0a, 0x0f,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x22, 0xd6, 0x03, 0x0a, 0x0f,
-	0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x43, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45,
-	0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74,
-	0x54, 0x61, 0x67, 0x73, 0x12, 0x5b, 0x0a, 0x11, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x2d, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67,
-	0x73, 0x12, 0x58, 0x0a, 0x10, 0x63, 0x6c, 0x6f, 0x73, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x67,
-	0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10, 0x63, 0x6c, 0x6f, 0x73, 0x69,
-	0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x44, 0x0a, 0x16, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e,
+	0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x6e, 0x76, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e,
+	0x76, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x02, 0x76, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x6d, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x02, 0x76, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x6c, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x6c, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x65,
+	0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x53, 0x69,
+	0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x0f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x61, 0x62, 0x54, 0x74, 0x6c, 0x4d, 0x69, 0x6e,
+	0x75, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x54,
+	0x74, 0x6c, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x69, 0x64, 0x6c,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x41, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x74, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x74, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6d, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6d, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d,
+	0x69, 0x6e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4c, 0x61, 0x62, 0x73, 0x12,
+	0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4c, 0x61, 0x62, 0x73, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x61, 0x64, 0x79, 0x4c,
+	0x61, 0x62, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x53, 0x63, 0x72,
+	0x65, 0x65, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x09, 0x66, 0x72, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x66,
+	0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x79, 0x62, 0x72,
+	0x69, 0x64, 0x4c, 0x61, 0x62, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x68, 0x79,
+	0x62, 0x72, 0x69, 0x64, 0x4c, 0x61, 0x62, 0x73, 0x12, 0x34, 0x0a, 0x0b, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x52, 0x0b, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x61,
+	0x0a, 0x0b, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x24, 0x0a,
+	0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x22, 0x2d, 0x0a, 0x0f, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67,
+	0x22, 0x2f, 0x0a, 0x11, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x76, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61,
+	0x67, 0x22, 0x2e, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61,
+	0x67, 0x22, 0xfd, 0x04, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x12, 0x5b, 0x0a, 0x11, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x12, 0x58, 0x0a, 0x10, 0x63, 0x6c, 0x6f, 0x73, 0x69,
+	0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e,
+	0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x69, 0x6e,
+	0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x10, 0x63, 0x6c, 0x6f, 0x73, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67,
+	0x73, 0x12, 0x5e, 0x0a, 0x12, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x73,
+	0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67,
+	0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e,
 	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
-	0x43, 0x0a, 0x15, 0x43, 0x6c, 0x6f, 0x73, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
-	0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x44, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61,
-	0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x73, 0x56, 0x50, 0x4e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x56, 0x50, 0x4e, 0x22, 0x2e, 0x0a, 0x14, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x22, 0x31, 0x0a, 0x15, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x22, 0x29, 0x0a,
-	0x0f, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x22, 0xbc, 0x01, 0x0a, 0x0f, 0x45, 0x78, 0x65,
+	0x44, 0x0a, 0x16, 0x53, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x43, 0x0a, 0x15, 0x43, 0x6c, 0x6f, 0x73, 0x69, 0x6e, 0x67,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x45, 0x0a, 0x17, 0x53, 0x75,
+	0x73, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xae, 0x01, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x65, 0x61, 0x72, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x72,
+	0x6f, 0x7a, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x72, 0x6f, 0x7a,
+	0x65, 0x6e, 0x22, 0x46, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x73,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52,
+	0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x64, 0x0a, 0x10, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x73,
+	0x56, 0x50, 0x4e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x56, 0x50, 0x4e,
+	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x74, 0x6c, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73,
+	0x22, 0x62, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54,
+	0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67,
+	0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65,
+	0x61, 0x6d, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x70,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x22, 0x5d, 0x0a, 0x0f, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61,
+	0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54,
+	0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x22, 0x5e, 0x0a, 0x10, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x22, 0x47, 0x0a, 0x11, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x22,
+	0x4a, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x46, 0x6f, 0x72, 0x54, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x22, 0x78, 0x0a, 0x10, 0x45,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x65, 0x61, 0x6d, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x11, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x4c,
+	0x61, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0xd4, 0x01, 0x0a, 0x0f, 0x45, 0x78, 0x65,
 	0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
 	0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61,
 	0x62, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x76, 0x54, 0x61, 0x67, 0x18, 0x02,
@@ -2013,163 +4756,402 @@ var file_agent_proto_rawDesc = []byte{
 	0x73, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
 	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0f, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x22, 0x4e, 0x0a, 0x08, 0x56, 0x6d, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6d,
-	0x6f, 0x72, 0x79, 0x4d, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x65, 0x6d,
-	0x6f, 0x72, 0x79, 0x4d, 0x42, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x03, 0x63, 0x70, 0x75, 0x22, 0x2a, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0xc4, 0x01, 0x0a, 0x03, 0x4c, 0x61, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x74,
-	0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1a, 0x0a,
-	0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x2d, 0x0a, 0x09, 0x65, 0x78, 0x65,
-	0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x09, 0x65,
-	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x73, 0x56, 0x50,
-	0x4e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x56, 0x50, 0x4e, 0x12, 0x2e,
-	0x0a, 0x09, 0x67, 0x75, 0x61, 0x63, 0x43, 0x72, 0x65, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x75, 0x61, 0x63, 0x43, 0x72,
-	0x65, 0x64, 0x73, 0x52, 0x09, 0x67, 0x75, 0x61, 0x63, 0x43, 0x72, 0x65, 0x64, 0x73, 0x12, 0x1a,
-	0x0a, 0x08, 0x76, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x08, 0x76, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x08, 0x45,
-	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x3c, 0x0a, 0x0e, 0x63, 0x68, 0x69,
-	0x6c, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x45,
-	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x0e, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x45, 0x78,
-	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69,
-	0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x52, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69,
-	0x6e, 0x65, 0x73, 0x22, 0x35, 0x0a, 0x0d, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x45, 0x78, 0x65, 0x72,
-	0x63, 0x69, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x22, 0x73, 0x0a, 0x07, 0x4d, 0x61,
-	0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22,
-	0x43, 0x0a, 0x09, 0x47, 0x75, 0x61, 0x63, 0x43, 0x72, 0x65, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08,
-	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73,
-	0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73,
-	0x77, 0x6f, 0x72, 0x64, 0x22, 0x75, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63,
-	0x12, 0x39, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x22,
+	0xa6, 0x01, 0x0a, 0x08, 0x56, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x12, 0x10,
+	0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x63, 0x70, 0x75,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x2a, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0xca, 0x02, 0x0a, 0x03, 0x4c, 0x61, 0x62, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x2d, 0x0a, 0x09, 0x65, 0x78,
+	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x09,
+	0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x73, 0x56,
+	0x50, 0x4e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x56, 0x50, 0x4e, 0x12,
+	0x2e, 0x0a, 0x09, 0x67, 0x75, 0x61, 0x63, 0x43, 0x72, 0x65, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x75, 0x61, 0x63, 0x43,
+	0x72, 0x65, 0x64, 0x73, 0x52, 0x09, 0x67, 0x75, 0x61, 0x63, 0x43, 0x72, 0x65, 0x64, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x76, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x08, 0x76, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x61,
+	0x6d, 0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x12, 0x32, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x72, 0x65, 0x64, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47,
+	0x75, 0x61, 0x63, 0x43, 0x72, 0x65, 0x64, 0x73, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x43, 0x72, 0x65, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x73, 0x48, 0x79, 0x62, 0x72, 0x69,
+	0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x48, 0x79, 0x62, 0x72, 0x69,
+	0x64, 0x22, 0xec, 0x01, 0x0a, 0x08, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67,
+	0x12, 0x3c, 0x0a, 0x0e, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x0e,
+	0x63, 0x68, 0x69, 0x6c, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x12, 0x2a,
+	0x0a, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x52, 0x08, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74,
+	0x22, 0x97, 0x01, 0x0a, 0x0d, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x54, 0x61, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x78,
+	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x65, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x51, 0x0a, 0x09, 0x43, 0x6c,
+	0x6f, 0x73, 0x65, 0x64, 0x4c, 0x61, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x35, 0x0a,
+	0x0d, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x66, 0x6c, 0x61, 0x67, 0x22, 0xc5, 0x01, 0x0a, 0x07, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x43, 0x0a, 0x09,
+	0x47, 0x75, 0x61, 0x63, 0x43, 0x72, 0x65, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x22, 0x93, 0x01, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x12, 0x39,
+	0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x22, 0xab, 0x06, 0x0a, 0x16, 0x45, 0x78, 0x65, 0x72,
+	0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x63,
+	0x70, 0x75, 0x12, 0x27, 0x0a, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x43, 0x68,
+	0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72,
+	0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x12, 0x34, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x64,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x77,
+	0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x61, 0x70, 0x41, 0x64, 0x64, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x61, 0x70, 0x41, 0x64, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x61, 0x70, 0x44, 0x72,
+	0x6f, 0x70, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x61, 0x70, 0x44, 0x72, 0x6f,
+	0x70, 0x12, 0x44, 0x0a, 0x07, 0x73, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x73, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63,
 	0x69, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x22, 0xe7, 0x01, 0x0a, 0x16,
-	0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x65,
-	0x6d, 0x6f, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x27, 0x0a, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6e, 0x76,
-	0x56, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x04, 0x65, 0x6e, 0x76, 0x73, 0x12,
-	0x35, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72,
-	0x65, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x08, 0x63, 0x68,
-	0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x72, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x38, 0x0a, 0x0c, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
-	0x6c, 0x0a, 0x12, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65,
-	0x6e, 0x76, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e,
-	0x76, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x22, 0x4a, 0x0a,
-	0x0c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xef, 0x08, 0x0a, 0x05, 0x41, 0x67,
-	0x65, 0x6e, 0x74, 0x12, 0x44, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x76,
+	0x67, 0x2e, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07,
+	0x73, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x73, 0x12, 0x3e, 0x0a, 0x05, 0x74, 0x6d, 0x70, 0x66, 0x73,
+	0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x6d, 0x70, 0x66, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x05, 0x74, 0x6d, 0x70, 0x66, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x4f,
+	0x6e, 0x6c, 0x79, 0x52, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c, 0x79, 0x52, 0x6f, 0x6f, 0x74, 0x66, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x13, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x61, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x1a,
+	0x3a, 0x0a, 0x0c, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x38, 0x0a, 0x0a, 0x54,
+	0x6d, 0x70, 0x66, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x95, 0x02, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x72, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x38, 0x0a,
+	0x0c, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6c, 0x0a, 0x12, 0x43, 0x68, 0x69, 0x6c, 0x64,
+	0x72, 0x65, 0x6e, 0x43, 0x68, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x0a,
+	0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x76, 0x46, 0x6c, 0x61, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x76, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x69, 0x63, 0x22, 0x4a, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x22, 0x7b, 0x0a, 0x03, 0x4f, 0x76, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x55, 0x73,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x22, 0x32,
+	0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x76, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1e, 0x0a, 0x04, 0x6f, 0x76, 0x61, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x76, 0x61, 0x52, 0x04, 0x6f, 0x76,
+	0x61, 0x73, 0x22, 0x4e, 0x0a, 0x08, 0x4f, 0x76, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x22, 0x20, 0x0a, 0x0a, 0x4f, 0x76, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x46, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x6e, 0x76, 0x54, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e,
+	0x76, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x22, 0x87, 0x01, 0x0a,
+	0x11, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x52, 0x0a, 0x12, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x4d, 0x69,
+	0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6d, 0x61, 0x78,
+	0x41, 0x67, 0x65, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x22, 0x5d, 0x0a, 0x0b, 0x50, 0x72,
+	0x75, 0x6e, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x69, 0x0a, 0x13, 0x50, 0x72, 0x75,
+	0x6e, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2a, 0x0a, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x64, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e,
+	0x72, 0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x32, 0xb6, 0x14, 0x0a, 0x05, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x44,
+	0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x10, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x45, 0x6e, 0x76,
 	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x10, 0x43, 0x6c, 0x6f,
-	0x73, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x2e,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x45, 0x6e, 0x76, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a,
-	0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x12, 0x0c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0f, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x46, 0x6f, 0x72, 0x45, 0x6e, 0x76, 0x12, 0x17, 0x2e,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x52, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
-	0x46, 0x6f, 0x72, 0x4c, 0x61, 0x62, 0x12, 0x1b, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x4c, 0x61, 0x62, 0x12,
-	0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x4c, 0x61, 0x62,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x44, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73,
-	0x54, 0x6f, 0x45, 0x6e, 0x76, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x10, 0x4c, 0x69, 0x73,
+	0x74, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x0c, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c,
+	0x61, 0x62, 0x46, 0x6f, 0x72, 0x45, 0x6e, 0x76, 0x12, 0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x13, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x46, 0x6f, 0x72, 0x4c, 0x61,
+	0x62, 0x12, 0x1b, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x70, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e,
+	0x0a, 0x0f, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x50, 0x4e, 0x46, 0x6f, 0x72, 0x4c, 0x61,
+	0x62, 0x12, 0x1b, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x56, 0x70, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x70, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b,
+	0x0a, 0x08, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x11, 0x41,
+	0x64, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x54, 0x6f, 0x45, 0x6e, 0x76,
+	0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x44, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x73, 0x54, 0x6f, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45,
+	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x65, 0x74,
+	0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x65,
+	0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x65, 0x74, 0x45, 0x78, 0x65,
+	0x72, 0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x12, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x4c, 0x61,
+	0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69,
+	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x44, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69,
+	0x73, 0x65, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67,
+	0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0d, 0x4d,
+	0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x12, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x37,
+	0x0a, 0x06, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x12, 0x14, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x48, 0x6f,
+	0x73, 0x74, 0x73, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0c, 0x52,
+	0x65, 0x73, 0x65, 0x74, 0x56, 0x6d, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x10, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65,
+	0x56, 0x6d, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x69,
+	0x7a, 0x65, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12,
+	0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x45, 0x78,
 	0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
 	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x45, 0x78, 0x65,
-	0x72, 0x63, 0x69, 0x73, 0x65, 0x73, 0x54, 0x6f, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67,
-	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08,
-	0x52, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
-	0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x56, 0x6d, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0f, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12, 0x16, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x52, 0x65, 0x76, 0x65, 0x72,
+	0x74, 0x56, 0x6d, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x10, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x56, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40,
+	0x0a, 0x09, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x4c, 0x61, 0x62, 0x12, 0x17, 0x2e, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x47, 0x0a, 0x12, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53,
+	0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x12, 0x52, 0x65, 0x73,
-	0x65, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12,
-	0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x11, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x17,
+	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x45, 0x6e, 0x76,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
 	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x45, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x72, 0x74, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73,
-	0x65, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45,
-	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
-	0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x70, 0x45,
-	0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x78, 0x65, 0x72, 0x63, 0x69, 0x73, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a,
-	0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69,
-	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x41, 0x0a, 0x0d, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x12, 0x12, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x6f,
-	0x6e, 0x69, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28,
-	0x01, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x12, 0x14, 0x2e,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4c,
-	0x61, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d,
-	0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x49, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x16, 0x2e,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65,
-	0x74, 0x48, 0x6f, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x39, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x65, 0x74, 0x56, 0x6d, 0x49, 0x6e, 0x4c, 0x61, 0x62,
-	0x12, 0x10, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x36, 0x5a, 0x34, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x61, 0x75, 0x2d, 0x6e, 0x65,
-	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x68,
-	0x61, 0x61, 0x75, 0x6b, 0x69, 0x6e, 0x73, 0x2d, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x3d, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x73, 0x12, 0x0c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3d, 0x0a, 0x09, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x4c, 0x61, 0x62, 0x12, 0x17, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x4c, 0x61, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f,
+	0x0a, 0x0a, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x61, 0x62, 0x12, 0x18, 0x2e, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x61, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x45, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x46, 0x6f, 0x72, 0x54, 0x65, 0x61, 0x6d,
+	0x12, 0x1b, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x46,
+	0x6f, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x46, 0x72, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x64, 0x54, 0x6f, 0x4c, 0x61, 0x62, 0x12, 0x19, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x41, 0x64, 0x64, 0x46, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x47, 0x65,
+	0x74, 0x4c, 0x61, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e,
+	0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64,
+	0x46, 0x72, 0x6f, 0x6d, 0x4c, 0x61, 0x62, 0x12, 0x1c, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33,
+	0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x76, 0x61, 0x73, 0x12, 0x0c, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x76, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x09, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x76, 0x61,
+	0x12, 0x0f, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x76, 0x61, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x1a, 0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x76, 0x61, 0x22, 0x00, 0x28,
+	0x01, 0x12, 0x2d, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x4f, 0x76, 0x61, 0x12,
+	0x11, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x76, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x76, 0x61, 0x22, 0x00,
+	0x12, 0x37, 0x0a, 0x09, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4f, 0x76, 0x61, 0x12, 0x11, 0x2e,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x76, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0d, 0x50, 0x72, 0x65,
+	0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x50, 0x72, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x49, 0x6d, 0x61, 0x67, 0x65, 0x50, 0x75, 0x6c, 0x6c, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x22, 0x00, 0x30, 0x01, 0x12, 0x46, 0x0a, 0x0b, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x72, 0x75,
+	0x6e, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x72, 0x75, 0x6e, 0x65, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x36, 0x5a,
+	0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x61, 0x75, 0x2d,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x2f, 0x68, 0x61, 0x61, 0x75, 0x6b, 0x69, 0x6e, 0x73, 0x2d, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2184,100 +5166,192 @@ func file_agent_proto_rawDescGZIP() []byte {
 	return file_agent_proto_rawDescData
 }
 
-var file_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
 var file_agent_proto_goTypes = []interface{}{
-	(*Empty)(nil),                  // 0: agent.Empty
-	(*VmRequest)(nil),              // 1: agent.VmRequest
-	(*ResetLabRequest)(nil),        // 2: agent.ResetLabRequest
-	(*GetLabRequest)(nil),          // 3: agent.GetLabRequest
-	(*GetLabResponse)(nil),         // 4: agent.GetLabResponse
-	(*GetHostsRequest)(nil),        // 5: agent.GetHostsRequest
-	(*GetHostsResponse)(nil),       // 6: agent.GetHostsResponse
-	(*MonitorResponse)(nil),        // 7: agent.MonitorResponse
-	(*Resources)(nil),              // 8: agent.Resources
-	(*PingRequest)(nil),            // 9: agent.PingRequest
-	(*PingResponse)(nil),           // 10: agent.PingResponse
-	(*CreatEnvRequest)(nil),        // 11: agent.CreatEnvRequest
-	(*CloseEnvRequest)(nil),        // 12: agent.CloseEnvRequest
-	(*ListEnvResponse)(nil),        // 13: agent.ListEnvResponse
-	(*CreateLabRequest)(nil),       // 14: agent.CreateLabRequest
-	(*CreateVpnConfRequest)(nil),   // 15: agent.CreateVpnConfRequest
-	(*CreateVpnConfResponse)(nil),  // 16: agent.CreateVpnConfResponse
-	(*CloseLabRequest)(nil),        // 17: agent.CloseLabRequest
-	(*ExerciseRequest)(nil),        // 18: agent.ExerciseRequest
-	(*VmConfig)(nil),               // 19: agent.VmConfig
-	(*StatusResponse)(nil),         // 20: agent.StatusResponse
-	(*Lab)(nil),                    // 21: agent.Lab
-	(*Exercise)(nil),               // 22: agent.Exercise
-	(*ChildExercise)(nil),          // 23: agent.ChildExercise
-	(*Machine)(nil),                // 24: agent.Machine
-	(*GuacCreds)(nil),              // 25: agent.GuacCreds
-	(*ExerciseConfig)(nil),         // 26: agent.ExerciseConfig
-	(*ExerciseInstanceConfig)(nil), // 27: agent.ExerciseInstanceConfig
-	(*EnvVarConfig)(nil),           // 28: agent.EnvVarConfig
-	(*ChildrenChalConfig)(nil),     // 29: agent.ChildrenChalConfig
-	(*RecordConfig)(nil),           // 30: agent.RecordConfig
-	nil,                            // 31: agent.ListEnvResponse.EventTagsEntry
-	nil,                            // 32: agent.ListEnvResponse.StartingEventTagsEntry
-	nil,                            // 33: agent.ListEnvResponse.ClosingEventTagsEntry
+	(*Empty)(nil),                   // 0: agent.Empty
+	(*VmRequest)(nil),               // 1: agent.VmRequest
+	(*ResizeVmRequest)(nil),         // 2: agent.ResizeVmRequest
+	(*SnapshotRequest)(nil),         // 3: agent.SnapshotRequest
+	(*VmSnapshot)(nil),              // 4: agent.VmSnapshot
+	(*ListVmSnapshotsResponse)(nil), // 5: agent.ListVmSnapshotsResponse
+	(*ResizeExerciseRequest)(nil),   // 6: agent.ResizeExerciseRequest
+	(*AddFrontendRequest)(nil),      // 7: agent.AddFrontendRequest
+	(*RemoveFrontendRequest)(nil),   // 8: agent.RemoveFrontendRequest
+	(*ResetLabRequest)(nil),         // 9: agent.ResetLabRequest
+	(*GetLabRequest)(nil),           // 10: agent.GetLabRequest
+	(*GetLabResponse)(nil),          // 11: agent.GetLabResponse
+	(*GetHostsRequest)(nil),         // 12: agent.GetHostsRequest
+	(*GetHostsResponse)(nil),        // 13: agent.GetHostsResponse
+	(*MonitorResponse)(nil),         // 14: agent.MonitorResponse
+	(*LabPool)(nil),                 // 15: agent.LabPool
+	(*Resources)(nil),               // 16: agent.Resources
+	(*PingRequest)(nil),             // 17: agent.PingRequest
+	(*PingResponse)(nil),            // 18: agent.PingResponse
+	(*CreatEnvRequest)(nil),         // 19: agent.CreatEnvRequest
+	(*ImagePolicy)(nil),             // 20: agent.ImagePolicy
+	(*CloseEnvRequest)(nil),         // 21: agent.CloseEnvRequest
+	(*SuspendEnvRequest)(nil),       // 22: agent.SuspendEnvRequest
+	(*ResumeEnvRequest)(nil),        // 23: agent.ResumeEnvRequest
+	(*ListEnvResponse)(nil),         // 24: agent.ListEnvResponse
+	(*Schedule)(nil),                // 25: agent.Schedule
+	(*ListSchedulesResponse)(nil),   // 26: agent.ListSchedulesResponse
+	(*CreateLabRequest)(nil),        // 27: agent.CreateLabRequest
+	(*CreateVpnConfRequest)(nil),    // 28: agent.CreateVpnConfRequest
+	(*CreateVpnConfResponse)(nil),   // 29: agent.CreateVpnConfResponse
+	(*CloseLabRequest)(nil),         // 30: agent.CloseLabRequest
+	(*AssignLabRequest)(nil),        // 31: agent.AssignLabRequest
+	(*ReleaseLabRequest)(nil),       // 32: agent.ReleaseLabRequest
+	(*GetLabForTeamRequest)(nil),    // 33: agent.GetLabForTeamRequest
+	(*ExtendLabRequest)(nil),        // 34: agent.ExtendLabRequest
+	(*ExtendLabResponse)(nil),       // 35: agent.ExtendLabResponse
+	(*ExerciseRequest)(nil),         // 36: agent.ExerciseRequest
+	(*VmConfig)(nil),                // 37: agent.VmConfig
+	(*StatusResponse)(nil),          // 38: agent.StatusResponse
+	(*Lab)(nil),                     // 39: agent.Lab
+	(*Exercise)(nil),                // 40: agent.Exercise
+	(*ExerciseEvent)(nil),           // 41: agent.ExerciseEvent
+	(*ClosedLab)(nil),               // 42: agent.ClosedLab
+	(*ChildExercise)(nil),           // 43: agent.ChildExercise
+	(*Machine)(nil),                 // 44: agent.Machine
+	(*GuacCreds)(nil),               // 45: agent.GuacCreds
+	(*ExerciseConfig)(nil),          // 46: agent.ExerciseConfig
+	(*ExerciseInstanceConfig)(nil),  // 47: agent.ExerciseInstanceConfig
+	(*HealthCheck)(nil),             // 48: agent.HealthCheck
+	(*EnvVarConfig)(nil),            // 49: agent.EnvVarConfig
+	(*ChildrenChalConfig)(nil),      // 50: agent.ChildrenChalConfig
+	(*RecordConfig)(nil),            // 51: agent.RecordConfig
+	(*Ova)(nil),                     // 52: agent.Ova
+	(*ListOvasResponse)(nil),        // 53: agent.ListOvasResponse
+	(*OvaChunk)(nil),                // 54: agent.OvaChunk
+	(*OvaRequest)(nil),              // 55: agent.OvaRequest
+	(*PrePullImagesRequest)(nil),    // 56: agent.PrePullImagesRequest
+	(*ImagePullProgress)(nil),       // 57: agent.ImagePullProgress
+	(*PruneImagesRequest)(nil),      // 58: agent.PruneImagesRequest
+	(*PrunedImage)(nil),             // 59: agent.PrunedImage
+	(*PruneImagesResponse)(nil),     // 60: agent.PruneImagesResponse
+	nil,                             // 61: agent.ListEnvResponse.EventTagsEntry
+	nil,                             // 62: agent.ListEnvResponse.StartingEventTagsEntry
+	nil,                             // 63: agent.ListEnvResponse.ClosingEventTagsEntry
+	nil,                             // 64: agent.ListEnvResponse.SuspendedEventTagsEntry
+	nil,                             // 65: agent.ExerciseInstanceConfig.SysctlsEntry
+	nil,                             // 66: agent.ExerciseInstanceConfig.TmpfsEntry
 }
 var file_agent_proto_depIdxs = []int32{
-	21, // 0: agent.GetLabResponse.lab:type_name -> agent.Lab
-	21, // 1: agent.MonitorResponse.newLabs:type_name -> agent.Lab
-	8,  // 2: agent.MonitorResponse.resources:type_name -> agent.Resources
-	19, // 3: agent.CreatEnvRequest.vm:type_name -> agent.VmConfig
-	26, // 4: agent.CreatEnvRequest.exerciseConfigs:type_name -> agent.ExerciseConfig
-	31, // 5: agent.ListEnvResponse.eventTags:type_name -> agent.ListEnvResponse.EventTagsEntry
-	32, // 6: agent.ListEnvResponse.startingEventTags:type_name -> agent.ListEnvResponse.StartingEventTagsEntry
-	33, // 7: agent.ListEnvResponse.closingEventTags:type_name -> agent.ListEnvResponse.ClosingEventTagsEntry
-	26, // 8: agent.ExerciseRequest.exerciseConfigs:type_name -> agent.ExerciseConfig
-	22, // 9: agent.Lab.exercises:type_name -> agent.Exercise
-	25, // 10: agent.Lab.guacCreds:type_name -> agent.GuacCreds
-	23, // 11: agent.Exercise.childExercises:type_name -> agent.ChildExercise
-	24, // 12: agent.Exercise.machines:type_name -> agent.Machine
-	27, // 13: agent.ExerciseConfig.instance:type_name -> agent.ExerciseInstanceConfig
-	28, // 14: agent.ExerciseInstanceConfig.envs:type_name -> agent.EnvVarConfig
-	29, // 15: agent.ExerciseInstanceConfig.children:type_name -> agent.ChildrenChalConfig
-	30, // 16: agent.ExerciseInstanceConfig.records:type_name -> agent.RecordConfig
-	11, // 17: agent.Agent.CreateEnvironment:input_type -> agent.CreatEnvRequest
-	12, // 18: agent.Agent.CloseEnvironment:input_type -> agent.CloseEnvRequest
-	0,  // 19: agent.Agent.ListEnvironments:input_type -> agent.Empty
-	14, // 20: agent.Agent.CreateLabForEnv:input_type -> agent.CreateLabRequest
-	15, // 21: agent.Agent.CreateVpnConfForLab:input_type -> agent.CreateVpnConfRequest
-	17, // 22: agent.Agent.CloseLab:input_type -> agent.CloseLabRequest
-	18, // 23: agent.Agent.AddExercisesToEnv:input_type -> agent.ExerciseRequest
-	18, // 24: agent.Agent.AddExercisesToLab:input_type -> agent.ExerciseRequest
-	2,  // 25: agent.Agent.ResetLab:input_type -> agent.ResetLabRequest
-	18, // 26: agent.Agent.ResetExerciseInLab:input_type -> agent.ExerciseRequest
-	18, // 27: agent.Agent.StartExerciseInLab:input_type -> agent.ExerciseRequest
-	18, // 28: agent.Agent.StopExerciseInLab:input_type -> agent.ExerciseRequest
-	9,  // 29: agent.Agent.Ping:input_type -> agent.PingRequest
-	9,  // 30: agent.Agent.MonitorStream:input_type -> agent.PingRequest
-	3,  // 31: agent.Agent.GetLab:input_type -> agent.GetLabRequest
-	5,  // 32: agent.Agent.GetHostsInLab:input_type -> agent.GetHostsRequest
-	1,  // 33: agent.Agent.ResetVmInLab:input_type -> agent.VmRequest
-	20, // 34: agent.Agent.CreateEnvironment:output_type -> agent.StatusResponse
-	20, // 35: agent.Agent.CloseEnvironment:output_type -> agent.StatusResponse
-	13, // 36: agent.Agent.ListEnvironments:output_type -> agent.ListEnvResponse
-	20, // 37: agent.Agent.CreateLabForEnv:output_type -> agent.StatusResponse
-	16, // 38: agent.Agent.CreateVpnConfForLab:output_type -> agent.CreateVpnConfResponse
-	20, // 39: agent.Agent.CloseLab:output_type -> agent.StatusResponse
-	20, // 40: agent.Agent.AddExercisesToEnv:output_type -> agent.StatusResponse
-	20, // 41: agent.Agent.AddExercisesToLab:output_type -> agent.StatusResponse
-	20, // 42: agent.Agent.ResetLab:output_type -> agent.StatusResponse
-	20, // 43: agent.Agent.ResetExerciseInLab:output_type -> agent.StatusResponse
-	20, // 44: agent.Agent.StartExerciseInLab:output_type -> agent.StatusResponse
-	20, // 45: agent.Agent.StopExerciseInLab:output_type -> agent.StatusResponse
-	10, // 46: agent.Agent.Ping:output_type -> agent.PingResponse
-	7,  // 47: agent.Agent.MonitorStream:output_type -> agent.MonitorResponse
-	4,  // 48: agent.Agent.GetLab:output_type -> agent.GetLabResponse
-	6,  // 49: agent.Agent.GetHostsInLab:output_type -> agent.GetHostsResponse
-	20, // 50: agent.Agent.ResetVmInLab:output_type -> agent.StatusResponse
-	34, // [34:51] is the sub-list for method output_type
-	17, // [17:34] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	4,  // 0: agent.ListVmSnapshotsResponse.snapshots:type_name -> agent.VmSnapshot
+	37, // 1: agent.AddFrontendRequest.vm:type_name -> agent.VmConfig
+	39, // 2: agent.GetLabResponse.lab:type_name -> agent.Lab
+	39, // 3: agent.MonitorResponse.newLabs:type_name -> agent.Lab
+	16, // 4: agent.MonitorResponse.resources:type_name -> agent.Resources
+	15, // 5: agent.MonitorResponse.labPools:type_name -> agent.LabPool
+	41, // 6: agent.MonitorResponse.exerciseEvents:type_name -> agent.ExerciseEvent
+	42, // 7: agent.MonitorResponse.closedLabs:type_name -> agent.ClosedLab
+	37, // 8: agent.CreatEnvRequest.vm:type_name -> agent.VmConfig
+	46, // 9: agent.CreatEnvRequest.exerciseConfigs:type_name -> agent.ExerciseConfig
+	37, // 10: agent.CreatEnvRequest.frontends:type_name -> agent.VmConfig
+	20, // 11: agent.CreatEnvRequest.imagePolicy:type_name -> agent.ImagePolicy
+	61, // 12: agent.ListEnvResponse.eventTags:type_name -> agent.ListEnvResponse.EventTagsEntry
+	62, // 13: agent.ListEnvResponse.startingEventTags:type_name -> agent.ListEnvResponse.StartingEventTagsEntry
+	63, // 14: agent.ListEnvResponse.closingEventTags:type_name -> agent.ListEnvResponse.ClosingEventTagsEntry
+	64, // 15: agent.ListEnvResponse.suspendedEventTags:type_name -> agent.ListEnvResponse.SuspendedEventTagsEntry
+	25, // 16: agent.ListSchedulesResponse.schedules:type_name -> agent.Schedule
+	46, // 17: agent.ExerciseRequest.exerciseConfigs:type_name -> agent.ExerciseConfig
+	40, // 18: agent.Lab.exercises:type_name -> agent.Exercise
+	45, // 19: agent.Lab.guacCreds:type_name -> agent.GuacCreds
+	45, // 20: agent.Lab.memberCreds:type_name -> agent.GuacCreds
+	43, // 21: agent.Exercise.childExercises:type_name -> agent.ChildExercise
+	44, // 22: agent.Exercise.machines:type_name -> agent.Machine
+	47, // 23: agent.ExerciseConfig.instance:type_name -> agent.ExerciseInstanceConfig
+	49, // 24: agent.ExerciseInstanceConfig.envs:type_name -> agent.EnvVarConfig
+	50, // 25: agent.ExerciseInstanceConfig.children:type_name -> agent.ChildrenChalConfig
+	51, // 26: agent.ExerciseInstanceConfig.records:type_name -> agent.RecordConfig
+	48, // 27: agent.ExerciseInstanceConfig.healthcheck:type_name -> agent.HealthCheck
+	65, // 28: agent.ExerciseInstanceConfig.sysctls:type_name -> agent.ExerciseInstanceConfig.SysctlsEntry
+	66, // 29: agent.ExerciseInstanceConfig.tmpfs:type_name -> agent.ExerciseInstanceConfig.TmpfsEntry
+	52, // 30: agent.ListOvasResponse.ovas:type_name -> agent.Ova
+	59, // 31: agent.PruneImagesResponse.images:type_name -> agent.PrunedImage
+	19, // 32: agent.Agent.CreateEnvironment:input_type -> agent.CreatEnvRequest
+	21, // 33: agent.Agent.CloseEnvironment:input_type -> agent.CloseEnvRequest
+	0,  // 34: agent.Agent.ListEnvironments:input_type -> agent.Empty
+	27, // 35: agent.Agent.CreateLabForEnv:input_type -> agent.CreateLabRequest
+	28, // 36: agent.Agent.CreateVpnConfForLab:input_type -> agent.CreateVpnConfRequest
+	28, // 37: agent.Agent.EnableVPNForLab:input_type -> agent.CreateVpnConfRequest
+	30, // 38: agent.Agent.CloseLab:input_type -> agent.CloseLabRequest
+	36, // 39: agent.Agent.AddExercisesToEnv:input_type -> agent.ExerciseRequest
+	36, // 40: agent.Agent.AddExercisesToLab:input_type -> agent.ExerciseRequest
+	9,  // 41: agent.Agent.ResetLab:input_type -> agent.ResetLabRequest
+	36, // 42: agent.Agent.ResetExerciseInLab:input_type -> agent.ExerciseRequest
+	36, // 43: agent.Agent.StartExerciseInLab:input_type -> agent.ExerciseRequest
+	36, // 44: agent.Agent.StopExerciseInLab:input_type -> agent.ExerciseRequest
+	17, // 45: agent.Agent.Ping:input_type -> agent.PingRequest
+	17, // 46: agent.Agent.MonitorStream:input_type -> agent.PingRequest
+	10, // 47: agent.Agent.GetLab:input_type -> agent.GetLabRequest
+	12, // 48: agent.Agent.GetHostsInLab:input_type -> agent.GetHostsRequest
+	1,  // 49: agent.Agent.ResetVmInLab:input_type -> agent.VmRequest
+	2,  // 50: agent.Agent.ResizeVmInLab:input_type -> agent.ResizeVmRequest
+	6,  // 51: agent.Agent.ResizeExerciseInLab:input_type -> agent.ResizeExerciseRequest
+	3,  // 52: agent.Agent.SnapshotVmInLab:input_type -> agent.SnapshotRequest
+	3,  // 53: agent.Agent.ListVmSnapshots:input_type -> agent.SnapshotRequest
+	3,  // 54: agent.Agent.RevertVmInLab:input_type -> agent.SnapshotRequest
+	3,  // 55: agent.Agent.DeleteVmSnapshot:input_type -> agent.SnapshotRequest
+	34, // 56: agent.Agent.ExtendLab:input_type -> agent.ExtendLabRequest
+	22, // 57: agent.Agent.SuspendEnvironment:input_type -> agent.SuspendEnvRequest
+	23, // 58: agent.Agent.ResumeEnvironment:input_type -> agent.ResumeEnvRequest
+	0,  // 59: agent.Agent.ListSchedules:input_type -> agent.Empty
+	31, // 60: agent.Agent.AssignLab:input_type -> agent.AssignLabRequest
+	32, // 61: agent.Agent.ReleaseLab:input_type -> agent.ReleaseLabRequest
+	33, // 62: agent.Agent.GetLabForTeam:input_type -> agent.GetLabForTeamRequest
+	7,  // 63: agent.Agent.AddFrontendToLab:input_type -> agent.AddFrontendRequest
+	8,  // 64: agent.Agent.RemoveFrontendFromLab:input_type -> agent.RemoveFrontendRequest
+	0,  // 65: agent.Agent.ListOvas:input_type -> agent.Empty
+	54, // 66: agent.Agent.UploadOva:input_type -> agent.OvaChunk
+	55, // 67: agent.Agent.PrepareOva:input_type -> agent.OvaRequest
+	55, // 68: agent.Agent.RemoveOva:input_type -> agent.OvaRequest
+	56, // 69: agent.Agent.PrePullImages:input_type -> agent.PrePullImagesRequest
+	58, // 70: agent.Agent.PruneImages:input_type -> agent.PruneImagesRequest
+	38, // 71: agent.Agent.CreateEnvironment:output_type -> agent.StatusResponse
+	38, // 72: agent.Agent.CloseEnvironment:output_type -> agent.StatusResponse
+	24, // 73: agent.Agent.ListEnvironments:output_type -> agent.ListEnvResponse
+	38, // 74: agent.Agent.CreateLabForEnv:output_type -> agent.StatusResponse
+	29, // 75: agent.Agent.CreateVpnConfForLab:output_type -> agent.CreateVpnConfResponse
+	29, // 76: agent.Agent.EnableVPNForLab:output_type -> agent.CreateVpnConfResponse
+	38, // 77: agent.Agent.CloseLab:output_type -> agent.StatusResponse
+	38, // 78: agent.Agent.AddExercisesToEnv:output_type -> agent.StatusResponse
+	38, // 79: agent.Agent.AddExercisesToLab:output_type -> agent.StatusResponse
+	38, // 80: agent.Agent.ResetLab:output_type -> agent.StatusResponse
+	38, // 81: agent.Agent.ResetExerciseInLab:output_type -> agent.StatusResponse
+	38, // 82: agent.Agent.StartExerciseInLab:output_type -> agent.StatusResponse
+	38, // 83: agent.Agent.StopExerciseInLab:output_type -> agent.StatusResponse
+	18, // 84: agent.Agent.Ping:output_type -> agent.PingResponse
+	14, // 85: agent.Agent.MonitorStream:output_type -> agent.MonitorResponse
+	11, // 86: agent.Agent.GetLab:output_type -> agent.GetLabResponse
+	13, // 87: agent.Agent.GetHostsInLab:output_type -> agent.GetHostsResponse
+	38, // 88: agent.Agent.ResetVmInLab:output_type -> agent.StatusResponse
+	38, // 89: agent.Agent.ResizeVmInLab:output_type -> agent.StatusResponse
+	38, // 90: agent.Agent.ResizeExerciseInLab:output_type -> agent.StatusResponse
+	38, // 91: agent.Agent.SnapshotVmInLab:output_type -> agent.StatusResponse
+	5,  // 92: agent.Agent.ListVmSnapshots:output_type -> agent.ListVmSnapshotsResponse
+	38, // 93: agent.Agent.RevertVmInLab:output_type -> agent.StatusResponse
+	38, // 94: agent.Agent.DeleteVmSnapshot:output_type -> agent.StatusResponse
+	35, // 95: agent.Agent.ExtendLab:output_type -> agent.ExtendLabResponse
+	38, // 96: agent.Agent.SuspendEnvironment:output_type -> agent.StatusResponse
+	38, // 97: agent.Agent.ResumeEnvironment:output_type -> agent.StatusResponse
+	26, // 98: agent.Agent.ListSchedules:output_type -> agent.ListSchedulesResponse
+	11, // 99: agent.Agent.AssignLab:output_type -> agent.GetLabResponse
+	38, // 100: agent.Agent.ReleaseLab:output_type -> agent.StatusResponse
+	11, // 101: agent.Agent.GetLabForTeam:output_type -> agent.GetLabResponse
+	11, // 102: agent.Agent.AddFrontendToLab:output_type -> agent.GetLabResponse
+	38, // 103: agent.Agent.RemoveFrontendFromLab:output_type -> agent.StatusResponse
+	53, // 104: agent.Agent.ListOvas:output_type -> agent.ListOvasResponse
+	52, // 105: agent.Agent.UploadOva:output_type -> agent.Ova
+	52, // 106: agent.Agent.PrepareOva:output_type -> agent.Ova
+	38, // 107: agent.Agent.RemoveOva:output_type -> agent.StatusResponse
+	57, // 108: agent.Agent.PrePullImages:output_type -> agent.ImagePullProgress
+	60, // 109: agent.Agent.PruneImages:output_type -> agent.PruneImagesResponse
+	71, // [71:110] is the sub-list for method output_type
+	32, // [32:71] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_agent_proto_init() }
@@ -2298,8 +5372,152 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VmRequest); i {
+		file_agent_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VmRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResizeVmRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VmSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVmSnapshotsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResizeExerciseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddFrontendRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveFrontendRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetLabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLabResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHostsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2310,8 +5528,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResetLabRequest); i {
+		file_agent_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MonitorResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2322,8 +5540,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLabRequest); i {
+		file_agent_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabPool); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2334,8 +5552,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLabResponse); i {
+		file_agent_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resources); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2346,8 +5564,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetHostsRequest); i {
+		file_agent_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PingRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2358,8 +5576,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetHostsResponse); i {
+		file_agent_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PingResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2370,8 +5588,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MonitorResponse); i {
+		file_agent_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreatEnvRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2382,8 +5600,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Resources); i {
+		file_agent_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImagePolicy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2394,8 +5612,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PingRequest); i {
+		file_agent_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseEnvRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2406,8 +5624,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PingResponse); i {
+		file_agent_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuspendEnvRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2418,8 +5636,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreatEnvRequest); i {
+		file_agent_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeEnvRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2430,8 +5648,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CloseEnvRequest); i {
+		file_agent_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListEnvResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2442,8 +5660,8 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListEnvResponse); i {
+		file_agent_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Schedule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2454,7 +5672,19 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSchedulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateLabRequest); i {
 			case 0:
 				return &v.state
@@ -2466,7 +5696,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateVpnConfRequest); i {
 			case 0:
 				return &v.state
@@ -2478,7 +5708,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateVpnConfResponse); i {
 			case 0:
 				return &v.state
@@ -2490,7 +5720,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CloseLabRequest); i {
 			case 0:
 				return &v.state
@@ -2502,7 +5732,67 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AssignLabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseLabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLabForTeamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendLabRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExtendLabResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ExerciseRequest); i {
 			case 0:
 				return &v.state
@@ -2514,7 +5804,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VmConfig); i {
 			case 0:
 				return &v.state
@@ -2526,7 +5816,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StatusResponse); i {
 			case 0:
 				return &v.state
@@ -2538,7 +5828,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Lab); i {
 			case 0:
 				return &v.state
@@ -2550,7 +5840,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Exercise); i {
 			case 0:
 				return &v.state
@@ -2562,7 +5852,31 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExerciseEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClosedLab); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ChildExercise); i {
 			case 0:
 				return &v.state
@@ -2574,7 +5888,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Machine); i {
 			case 0:
 				return &v.state
@@ -2586,7 +5900,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GuacCreds); i {
 			case 0:
 				return &v.state
@@ -2598,7 +5912,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ExerciseConfig); i {
 			case 0:
 				return &v.state
@@ -2610,7 +5924,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ExerciseInstanceConfig); i {
 			case 0:
 				return &v.state
@@ -2622,7 +5936,19 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EnvVarConfig); i {
 			case 0:
 				return &v.state
@@ -2634,7 +5960,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ChildrenChalConfig); i {
 			case 0:
 				return &v.state
@@ -2646,7 +5972,7 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
-		file_agent_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_agent_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordConfig); i {
 			case 0:
 				return &v.state
@@ -2658,6 +5984,114 @@ func file_agent_proto_init() {
 				return nil
 			}
 		}
+		file_agent_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ova); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListOvasResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OvaChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OvaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrePullImagesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImagePullProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PruneImagesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrunedImage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_agent_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PruneImagesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2665,7 +6099,7 @@ func file_agent_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_agent_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   34,
+			NumMessages:   67,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,7 @@ type AgentClient interface {
 	ListEnvironments(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListEnvResponse, error)
 	CreateLabForEnv(ctx context.Context, in *CreateLabRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	CreateVpnConfForLab(ctx context.Context, in *CreateVpnConfRequest, opts ...grpc.CallOption) (*CreateVpnConfResponse, error)
+	EnableVPNForLab(ctx context.Context, in *CreateVpnConfRequest, opts ...grpc.CallOption) (*CreateVpnConfResponse, error)
 	CloseLab(ctx context.Context, in *CloseLabRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	AddExercisesToEnv(ctx context.Context, in *ExerciseRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	AddExercisesToLab(ctx context.Context, in *ExerciseRequest, opts ...grpc.CallOption) (*StatusResponse, error)
@@ -39,6 +40,27 @@ type AgentClient interface {
 	GetLab(ctx context.Context, in *GetLabRequest, opts ...grpc.CallOption) (*GetLabResponse, error)
 	GetHostsInLab(ctx context.Context, in *GetHostsRequest, opts ...grpc.CallOption) (*GetHostsResponse, error)
 	ResetVmInLab(ctx context.Context, in *VmRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ResizeVmInLab(ctx context.Context, in *ResizeVmRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ResizeExerciseInLab(ctx context.Context, in *ResizeExerciseRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	SnapshotVmInLab(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListVmSnapshots(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*ListVmSnapshotsResponse, error)
+	RevertVmInLab(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	DeleteVmSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ExtendLab(ctx context.Context, in *ExtendLabRequest, opts ...grpc.CallOption) (*ExtendLabResponse, error)
+	SuspendEnvironment(ctx context.Context, in *SuspendEnvRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ResumeEnvironment(ctx context.Context, in *ResumeEnvRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListSchedules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	AssignLab(ctx context.Context, in *AssignLabRequest, opts ...grpc.CallOption) (*GetLabResponse, error)
+	ReleaseLab(ctx context.Context, in *ReleaseLabRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetLabForTeam(ctx context.Context, in *GetLabForTeamRequest, opts ...grpc.CallOption) (*GetLabResponse, error)
+	AddFrontendToLab(ctx context.Context, in *AddFrontendRequest, opts ...grpc.CallOption) (*GetLabResponse, error)
+	RemoveFrontendFromLab(ctx context.Context, in *RemoveFrontendRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListOvas(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListOvasResponse, error)
+	UploadOva(ctx context.Context, opts ...grpc.CallOption) (Agent_UploadOvaClient, error)
+	PrepareOva(ctx context.Context, in *OvaRequest, opts ...grpc.CallOption) (*Ova, error)
+	RemoveOva(ctx context.Context, in *OvaRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	PrePullImages(ctx context.Context, in *PrePullImagesRequest, opts ...grpc.CallOption) (Agent_PrePullImagesClient, error)
+	PruneImages(ctx context.Context, in *PruneImagesRequest, opts ...grpc.CallOption) (*PruneImagesResponse, error)
 }
 
 type agentClient struct {
@@ -94,6 +116,15 @@ func (c *agentClient) CreateVpnConfForLab(ctx context.Context, in *CreateVpnConf
 	return out, nil
 }
 
+func (c *agentClient) EnableVPNForLab(ctx context.Context, in *CreateVpnConfRequest, opts ...grpc.CallOption) (*CreateVpnConfResponse, error) {
+	out := new(CreateVpnConfResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/EnableVPNForLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentClient) CloseLab(ctx context.Context, in *CloseLabRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
 	out := new(StatusResponse)
 	err := c.cc.Invoke(ctx, "/agent.Agent/CloseLab", in, out, opts...)
@@ -224,6 +255,243 @@ func (c *agentClient) ResetVmInLab(ctx context.Context, in *VmRequest, opts ...g
 	return out, nil
 }
 
+func (c *agentClient) ResizeVmInLab(ctx context.Context, in *ResizeVmRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/ResizeVmInLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ResizeExerciseInLab(ctx context.Context, in *ResizeExerciseRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/ResizeExerciseInLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) SnapshotVmInLab(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/SnapshotVmInLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ListVmSnapshots(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*ListVmSnapshotsResponse, error) {
+	out := new(ListVmSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/ListVmSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RevertVmInLab(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/RevertVmInLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteVmSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/DeleteVmSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ExtendLab(ctx context.Context, in *ExtendLabRequest, opts ...grpc.CallOption) (*ExtendLabResponse, error) {
+	out := new(ExtendLabResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/ExtendLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) SuspendEnvironment(ctx context.Context, in *SuspendEnvRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/SuspendEnvironment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ResumeEnvironment(ctx context.Context, in *ResumeEnvRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/ResumeEnvironment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ListSchedules(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) AssignLab(ctx context.Context, in *AssignLabRequest, opts ...grpc.CallOption) (*GetLabResponse, error) {
+	out := new(GetLabResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/AssignLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ReleaseLab(ctx context.Context, in *ReleaseLabRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/ReleaseLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetLabForTeam(ctx context.Context, in *GetLabForTeamRequest, opts ...grpc.CallOption) (*GetLabResponse, error) {
+	out := new(GetLabResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/GetLabForTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) AddFrontendToLab(ctx context.Context, in *AddFrontendRequest, opts ...grpc.CallOption) (*GetLabResponse, error) {
+	out := new(GetLabResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/AddFrontendToLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RemoveFrontendFromLab(ctx context.Context, in *RemoveFrontendRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/RemoveFrontendFromLab", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ListOvas(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListOvasResponse, error) {
+	out := new(ListOvasResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/ListOvas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) UploadOva(ctx context.Context, opts ...grpc.CallOption) (Agent_UploadOvaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[1], "/agent.Agent/UploadOva", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentUploadOvaClient{stream}
+	return x, nil
+}
+
+type Agent_UploadOvaClient interface {
+	Send(*OvaChunk) error
+	CloseAndRecv() (*Ova, error)
+	grpc.ClientStream
+}
+
+type agentUploadOvaClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentUploadOvaClient) Send(m *OvaChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentUploadOvaClient) CloseAndRecv() (*Ova, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Ova)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) PrepareOva(ctx context.Context, in *OvaRequest, opts ...grpc.CallOption) (*Ova, error) {
+	out := new(Ova)
+	err := c.cc.Invoke(ctx, "/agent.Agent/PrepareOva", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) RemoveOva(ctx context.Context, in *OvaRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/RemoveOva", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) PrePullImages(ctx context.Context, in *PrePullImagesRequest, opts ...grpc.CallOption) (Agent_PrePullImagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[2], "/agent.Agent/PrePullImages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentPrePullImagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_PrePullImagesClient interface {
+	Recv() (*ImagePullProgress, error)
+	grpc.ClientStream
+}
+
+type agentPrePullImagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentPrePullImagesClient) Recv() (*ImagePullProgress, error) {
+	m := new(ImagePullProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) PruneImages(ctx context.Context, in *PruneImagesRequest, opts ...grpc.CallOption) (*PruneImagesResponse, error) {
+	out := new(PruneImagesResponse)
+	err := c.cc.Invoke(ctx, "/agent.Agent/PruneImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 // All implementations must embed UnimplementedAgentServer
 // for forward compatibility
@@ -233,6 +501,7 @@ type AgentServer interface {
 	ListEnvironments(context.Context, *Empty) (*ListEnvResponse, error)
 	CreateLabForEnv(context.Context, *CreateLabRequest) (*StatusResponse, error)
 	CreateVpnConfForLab(context.Context, *CreateVpnConfRequest) (*CreateVpnConfResponse, error)
+	EnableVPNForLab(context.Context, *CreateVpnConfRequest) (*CreateVpnConfResponse, error)
 	CloseLab(context.Context, *CloseLabRequest) (*StatusResponse, error)
 	AddExercisesToEnv(context.Context, *ExerciseRequest) (*StatusResponse, error)
 	AddExercisesToLab(context.Context, *ExerciseRequest) (*StatusResponse, error)
@@ -245,6 +514,27 @@ type AgentServer interface {
 	GetLab(context.Context, *GetLabRequest) (*GetLabResponse, error)
 	GetHostsInLab(context.Context, *GetHostsRequest) (*GetHostsResponse, error)
 	ResetVmInLab(context.Context, *VmRequest) (*StatusResponse, error)
+	ResizeVmInLab(context.Context, *ResizeVmRequest) (*StatusResponse, error)
+	ResizeExerciseInLab(context.Context, *ResizeExerciseRequest) (*StatusResponse, error)
+	SnapshotVmInLab(context.Context, *SnapshotRequest) (*StatusResponse, error)
+	ListVmSnapshots(context.Context, *SnapshotRequest) (*ListVmSnapshotsResponse, error)
+	RevertVmInLab(context.Context, *SnapshotRequest) (*StatusResponse, error)
+	DeleteVmSnapshot(context.Context, *SnapshotRequest) (*StatusResponse, error)
+	ExtendLab(context.Context, *ExtendLabRequest) (*ExtendLabResponse, error)
+	SuspendEnvironment(context.Context, *SuspendEnvRequest) (*StatusResponse, error)
+	ResumeEnvironment(context.Context, *ResumeEnvRequest) (*StatusResponse, error)
+	ListSchedules(context.Context, *Empty) (*ListSchedulesResponse, error)
+	AssignLab(context.Context, *AssignLabRequest) (*GetLabResponse, error)
+	ReleaseLab(context.Context, *ReleaseLabRequest) (*StatusResponse, error)
+	GetLabForTeam(context.Context, *GetLabForTeamRequest) (*GetLabResponse, error)
+	AddFrontendToLab(context.Context, *AddFrontendRequest) (*GetLabResponse, error)
+	RemoveFrontendFromLab(context.Context, *RemoveFrontendRequest) (*StatusResponse, error)
+	ListOvas(context.Context, *Empty) (*ListOvasResponse, error)
+	UploadOva(Agent_UploadOvaServer) error
+	PrepareOva(context.Context, *OvaRequest) (*Ova, error)
+	RemoveOva(context.Context, *OvaRequest) (*StatusResponse, error)
+	PrePullImages(*PrePullImagesRequest, Agent_PrePullImagesServer) error
+	PruneImages(context.Context, *PruneImagesRequest) (*PruneImagesResponse, error)
 	mustEmbedUnimplementedAgentServer()
 }
 
@@ -267,6 +557,9 @@ func (UnimplementedAgentServer) CreateLabForEnv(context.Context, *CreateLabReque
 func (UnimplementedAgentServer) CreateVpnConfForLab(context.Context, *CreateVpnConfRequest) (*CreateVpnConfResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateVpnConfForLab not implemented")
 }
+func (UnimplementedAgentServer) EnableVPNForLab(context.Context, *CreateVpnConfRequest) (*CreateVpnConfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableVPNForLab not implemented")
+}
 func (UnimplementedAgentServer) CloseLab(context.Context, *CloseLabRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseLab not implemented")
 }
@@ -303,6 +596,69 @@ func (UnimplementedAgentServer) GetHostsInLab(context.Context, *GetHostsRequest)
 func (UnimplementedAgentServer) ResetVmInLab(context.Context, *VmRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetVmInLab not implemented")
 }
+func (UnimplementedAgentServer) ResizeVmInLab(context.Context, *ResizeVmRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResizeVmInLab not implemented")
+}
+func (UnimplementedAgentServer) ResizeExerciseInLab(context.Context, *ResizeExerciseRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResizeExerciseInLab not implemented")
+}
+func (UnimplementedAgentServer) SnapshotVmInLab(context.Context, *SnapshotRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotVmInLab not implemented")
+}
+func (UnimplementedAgentServer) ListVmSnapshots(context.Context, *SnapshotRequest) (*ListVmSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVmSnapshots not implemented")
+}
+func (UnimplementedAgentServer) RevertVmInLab(context.Context, *SnapshotRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertVmInLab not implemented")
+}
+func (UnimplementedAgentServer) DeleteVmSnapshot(context.Context, *SnapshotRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVmSnapshot not implemented")
+}
+func (UnimplementedAgentServer) ExtendLab(context.Context, *ExtendLabRequest) (*ExtendLabResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendLab not implemented")
+}
+func (UnimplementedAgentServer) SuspendEnvironment(context.Context, *SuspendEnvRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendEnvironment not implemented")
+}
+func (UnimplementedAgentServer) ResumeEnvironment(context.Context, *ResumeEnvRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeEnvironment not implemented")
+}
+func (UnimplementedAgentServer) ListSchedules(context.Context, *Empty) (*ListSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedAgentServer) AssignLab(context.Context, *AssignLabRequest) (*GetLabResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignLab not implemented")
+}
+func (UnimplementedAgentServer) ReleaseLab(context.Context, *ReleaseLabRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLab not implemented")
+}
+func (UnimplementedAgentServer) GetLabForTeam(context.Context, *GetLabForTeamRequest) (*GetLabResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLabForTeam not implemented")
+}
+func (UnimplementedAgentServer) AddFrontendToLab(context.Context, *AddFrontendRequest) (*GetLabResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFrontendToLab not implemented")
+}
+func (UnimplementedAgentServer) RemoveFrontendFromLab(context.Context, *RemoveFrontendRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFrontendFromLab not implemented")
+}
+func (UnimplementedAgentServer) ListOvas(context.Context, *Empty) (*ListOvasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOvas not implemented")
+}
+func (UnimplementedAgentServer) UploadOva(Agent_UploadOvaServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadOva not implemented")
+}
+func (UnimplementedAgentServer) PrepareOva(context.Context, *OvaRequest) (*Ova, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareOva not implemented")
+}
+func (UnimplementedAgentServer) RemoveOva(context.Context, *OvaRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveOva not implemented")
+}
+func (UnimplementedAgentServer) PrePullImages(*PrePullImagesRequest, Agent_PrePullImagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method PrePullImages not implemented")
+}
+func (UnimplementedAgentServer) PruneImages(context.Context, *PruneImagesRequest) (*PruneImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneImages not implemented")
+}
 func (UnimplementedAgentServer) mustEmbedUnimplementedAgentServer() {}
 
 // UnsafeAgentServer may be embedded to opt out of forward compatibility for this service.
@@ -406,6 +762,24 @@ func _Agent_CreateVpnConfForLab_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_EnableVPNForLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVpnConfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).EnableVPNForLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/EnableVPNForLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).EnableVPNForLab(ctx, req.(*CreateVpnConfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Agent_CloseLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CloseLabRequest)
 	if err := dec(in); err != nil {
@@ -630,47 +1004,440 @@ func _Agent_ResetVmInLab_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
-// Agent_ServiceDesc is the grpc.ServiceDesc for Agent service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Agent_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "agent.Agent",
-	HandlerType: (*AgentServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateEnvironment",
-			Handler:    _Agent_CreateEnvironment_Handler,
-		},
-		{
-			MethodName: "CloseEnvironment",
-			Handler:    _Agent_CloseEnvironment_Handler,
-		},
-		{
-			MethodName: "ListEnvironments",
-			Handler:    _Agent_ListEnvironments_Handler,
-		},
-		{
-			MethodName: "CreateLabForEnv",
-			Handler:    _Agent_CreateLabForEnv_Handler,
-		},
-		{
-			MethodName: "CreateVpnConfForLab",
-			Handler:    _Agent_CreateVpnConfForLab_Handler,
-		},
-		{
-			MethodName: "CloseLab",
-			Handler:    _Agent_CloseLab_Handler,
-		},
-		{
-			MethodName: "AddExercisesToEnv",
-			Handler:    _Agent_AddExercisesToEnv_Handler,
-		},
-		{
-			MethodName: "AddExercisesToLab",
-			Handler:    _Agent_AddExercisesToLab_Handler,
-		},
-		{
-			MethodName: "ResetLab",
+func _Agent_ResizeVmInLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeVmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ResizeVmInLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/ResizeVmInLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ResizeVmInLab(ctx, req.(*ResizeVmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ResizeExerciseInLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeExerciseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ResizeExerciseInLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/ResizeExerciseInLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ResizeExerciseInLab(ctx, req.(*ResizeExerciseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_SnapshotVmInLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).SnapshotVmInLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/SnapshotVmInLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).SnapshotVmInLab(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ListVmSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListVmSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/ListVmSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListVmSnapshots(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_RevertVmInLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).RevertVmInLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/RevertVmInLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).RevertVmInLab(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_DeleteVmSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).DeleteVmSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/DeleteVmSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).DeleteVmSnapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ExtendLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendLabRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ExtendLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/ExtendLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ExtendLab(ctx, req.(*ExtendLabRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_SuspendEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).SuspendEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/SuspendEnvironment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).SuspendEnvironment(ctx, req.(*SuspendEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ResumeEnvironment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ResumeEnvironment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/ResumeEnvironment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ResumeEnvironment(ctx, req.(*ResumeEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListSchedules(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_AssignLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignLabRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).AssignLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/AssignLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).AssignLab(ctx, req.(*AssignLabRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ReleaseLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLabRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ReleaseLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/ReleaseLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ReleaseLab(ctx, req.(*ReleaseLabRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetLabForTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLabForTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetLabForTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/GetLabForTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetLabForTeam(ctx, req.(*GetLabForTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_AddFrontendToLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFrontendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).AddFrontendToLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/AddFrontendToLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).AddFrontendToLab(ctx, req.(*AddFrontendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_RemoveFrontendFromLab_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFrontendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).RemoveFrontendFromLab(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/RemoveFrontendFromLab",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).RemoveFrontendFromLab(ctx, req.(*RemoveFrontendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ListOvas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListOvas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/ListOvas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListOvas(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_UploadOva_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).UploadOva(&agentUploadOvaServer{stream})
+}
+
+type Agent_UploadOvaServer interface {
+	SendAndClose(*Ova) error
+	Recv() (*OvaChunk, error)
+	grpc.ServerStream
+}
+
+type agentUploadOvaServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentUploadOvaServer) SendAndClose(m *Ova) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentUploadOvaServer) Recv() (*OvaChunk, error) {
+	m := new(OvaChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_PrepareOva_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OvaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).PrepareOva(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/PrepareOva",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).PrepareOva(ctx, req.(*OvaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_RemoveOva_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OvaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).RemoveOva(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/RemoveOva",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).RemoveOva(ctx, req.(*OvaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_PrePullImages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrePullImagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).PrePullImages(m, &agentPrePullImagesServer{stream})
+}
+
+type Agent_PrePullImagesServer interface {
+	Send(*ImagePullProgress) error
+	grpc.ServerStream
+}
+
+type agentPrePullImagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentPrePullImagesServer) Send(m *ImagePullProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_PruneImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).PruneImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agent.Agent/PruneImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).PruneImages(ctx, req.(*PruneImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Agent_ServiceDesc is the grpc.ServiceDesc for Agent service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Agent_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "agent.Agent",
+	HandlerType: (*AgentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEnvironment",
+			Handler:    _Agent_CreateEnvironment_Handler,
+		},
+		{
+			MethodName: "CloseEnvironment",
+			Handler:    _Agent_CloseEnvironment_Handler,
+		},
+		{
+			MethodName: "ListEnvironments",
+			Handler:    _Agent_ListEnvironments_Handler,
+		},
+		{
+			MethodName: "CreateLabForEnv",
+			Handler:    _Agent_CreateLabForEnv_Handler,
+		},
+		{
+			MethodName: "CreateVpnConfForLab",
+			Handler:    _Agent_CreateVpnConfForLab_Handler,
+		},
+		{
+			MethodName: "EnableVPNForLab",
+			Handler:    _Agent_EnableVPNForLab_Handler,
+		},
+		{
+			MethodName: "CloseLab",
+			Handler:    _Agent_CloseLab_Handler,
+		},
+		{
+			MethodName: "AddExercisesToEnv",
+			Handler:    _Agent_AddExercisesToEnv_Handler,
+		},
+		{
+			MethodName: "AddExercisesToLab",
+			Handler:    _Agent_AddExercisesToLab_Handler,
+		},
+		{
+			MethodName: "ResetLab",
 			Handler:    _Agent_ResetLab_Handler,
 		},
 		{
@@ -701,6 +1468,82 @@ var Agent_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResetVmInLab",
 			Handler:    _Agent_ResetVmInLab_Handler,
 		},
+		{
+			MethodName: "ResizeVmInLab",
+			Handler:    _Agent_ResizeVmInLab_Handler,
+		},
+		{
+			MethodName: "ResizeExerciseInLab",
+			Handler:    _Agent_ResizeExerciseInLab_Handler,
+		},
+		{
+			MethodName: "SnapshotVmInLab",
+			Handler:    _Agent_SnapshotVmInLab_Handler,
+		},
+		{
+			MethodName: "ListVmSnapshots",
+			Handler:    _Agent_ListVmSnapshots_Handler,
+		},
+		{
+			MethodName: "RevertVmInLab",
+			Handler:    _Agent_RevertVmInLab_Handler,
+		},
+		{
+			MethodName: "DeleteVmSnapshot",
+			Handler:    _Agent_DeleteVmSnapshot_Handler,
+		},
+		{
+			MethodName: "ExtendLab",
+			Handler:    _Agent_ExtendLab_Handler,
+		},
+		{
+			MethodName: "SuspendEnvironment",
+			Handler:    _Agent_SuspendEnvironment_Handler,
+		},
+		{
+			MethodName: "ResumeEnvironment",
+			Handler:    _Agent_ResumeEnvironment_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _Agent_ListSchedules_Handler,
+		},
+		{
+			MethodName: "AssignLab",
+			Handler:    _Agent_AssignLab_Handler,
+		},
+		{
+			MethodName: "ReleaseLab",
+			Handler:    _Agent_ReleaseLab_Handler,
+		},
+		{
+			MethodName: "GetLabForTeam",
+			Handler:    _Agent_GetLabForTeam_Handler,
+		},
+		{
+			MethodName: "AddFrontendToLab",
+			Handler:    _Agent_AddFrontendToLab_Handler,
+		},
+		{
+			MethodName: "RemoveFrontendFromLab",
+			Handler:    _Agent_RemoveFrontendFromLab_Handler,
+		},
+		{
+			MethodName: "ListOvas",
+			Handler:    _Agent_ListOvas_Handler,
+		},
+		{
+			MethodName: "PrepareOva",
+			Handler:    _Agent_PrepareOva_Handler,
+		},
+		{
+			MethodName: "RemoveOva",
+			Handler:    _Agent_RemoveOva_Handler,
+		},
+		{
+			MethodName: "PruneImages",
+			Handler:    _Agent_PruneImages_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -709,6 +1552,16 @@ var Agent_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "UploadOva",
+			Handler:       _Agent_UploadOva_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PrePullImages",
+			Handler:       _Agent_PrePullImages_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "agent.proto",
 }